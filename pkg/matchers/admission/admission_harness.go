@@ -0,0 +1,276 @@
+package admission
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	cradmission "sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Option configures a Harness.
+type Option func(*Harness)
+
+// WithScheme sets the scheme used to resolve the kind of typed objects,
+// defaults to the client-go scheme.
+func WithScheme(scheme *runtime.Scheme) Option {
+	return func(h *Harness) {
+		h.scheme = scheme
+	}
+}
+
+// WithUserInfo sets the user the admission requests are issued on behalf of.
+func WithUserInfo(info authenticationv1.UserInfo) Option {
+	return func(h *Harness) {
+		h.userInfo = info
+	}
+}
+
+// WithDryRun marks the admission requests as dry-run.
+func WithDryRun() Option {
+	return func(h *Harness) {
+		h.dryRun = true
+	}
+}
+
+// Harness builds AdmissionReview requests from objects and sends them to a
+// local webhook handler, so webhooks can be unit tested without an API
+// server:
+//
+//	h := admission.New(&admission.Webhook{Handler: myDefaulter})
+//	res, err := h.Create(ctx, &pod)
+//	Expect(err).ShouldNot(HaveOccurred())
+//	Expect(res).Should(admission.BeAllowed())
+//	Expect(res.Response).Should(jsonpatch.MatchPatch(...))
+type Harness struct {
+	review   func(ctx context.Context, in *admissionv1.AdmissionReview) (*admissionv1.AdmissionReview, error)
+	scheme   *runtime.Scheme
+	userInfo authenticationv1.UserInfo
+	dryRun   bool
+}
+
+// New creates a Harness invoking the given controller-runtime admission
+// handler.
+func New(handler cradmission.Handler, opts ...Option) *Harness {
+	h := newHarness(opts...)
+	h.review = func(ctx context.Context, in *admissionv1.AdmissionReview) (*admissionv1.AdmissionReview, error) {
+		resp := handler.Handle(ctx, cradmission.Request{AdmissionRequest: *in.Request})
+		if err := resp.Complete(cradmission.Request{AdmissionRequest: *in.Request}); err != nil {
+			return nil, fmt.Errorf("unable to complete admission response: %w", err)
+		}
+
+		out := in.DeepCopy()
+		out.Response = &resp.AdmissionResponse
+
+		return out, nil
+	}
+
+	return h
+}
+
+// NewHTTP creates a Harness posting AdmissionReview requests to the given
+// http.Handler, as the API server would do.
+func NewHTTP(handler http.Handler, opts ...Option) *Harness {
+	h := newHarness(opts...)
+	h.review = func(ctx context.Context, in *admissionv1.AdmissionReview) (*admissionv1.AdmissionReview, error) {
+		body, err := json.Marshal(in)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal admission review: %w", err)
+		}
+
+		req := httptest.NewRequestWithContext(ctx, http.MethodPost, "/", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status code %d: %s", rec.Code, rec.Body.String())
+		}
+
+		out := admissionv1.AdmissionReview{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal admission review: %w", err)
+		}
+
+		if out.Response == nil {
+			return nil, errors.New("the admission review has no response")
+		}
+
+		return &out, nil
+	}
+
+	return h
+}
+
+func newHarness(opts ...Option) *Harness {
+	h := Harness{
+		scheme: clientgoscheme.Scheme,
+	}
+
+	for _, opt := range opts {
+		opt(&h)
+	}
+
+	return &h
+}
+
+// Result holds the request sent to the webhook and its response.
+type Result struct {
+	Request  *admissionv1.AdmissionRequest
+	Response *admissionv1.AdmissionResponse
+}
+
+// Patched returns the object of the request with the patch of the response
+// applied, decoded as a map so it can be asserted with jq.Match.
+func (r *Result) Patched() (map[string]any, error) {
+	if len(r.Request.Object.Raw) == 0 {
+		return nil, errors.New("the admission request has no object")
+	}
+
+	doc := r.Request.Object.Raw
+
+	if len(r.Response.Patch) > 0 {
+		p, err := jsonpatch.DecodePatch(r.Response.Patch)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode patch: %w", err)
+		}
+
+		doc, err = p.Apply(doc)
+		if err != nil {
+			return nil, fmt.Errorf("unable to apply patch: %w", err)
+		}
+	}
+
+	out := map[string]any{}
+	if err := json.Unmarshal(doc, &out); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal patched object: %w", err)
+	}
+
+	return out, nil
+}
+
+// Create sends a CREATE admission request for the given object.
+func (h *Harness) Create(ctx context.Context, obj client.Object) (*Result, error) {
+	return h.Review(ctx, admissionv1.Create, obj, nil)
+}
+
+// Update sends an UPDATE admission request for the given object and its
+// previous version.
+func (h *Harness) Update(ctx context.Context, obj client.Object, old client.Object) (*Result, error) {
+	return h.Review(ctx, admissionv1.Update, obj, old)
+}
+
+// Delete sends a DELETE admission request for the given object.
+func (h *Harness) Delete(ctx context.Context, old client.Object) (*Result, error) {
+	return h.Review(ctx, admissionv1.Delete, nil, old)
+}
+
+// Review sends an admission request for the given operation, either of the
+// objects can be nil depending on the operation.
+func (h *Harness) Review(ctx context.Context, op admissionv1.Operation, obj client.Object, old client.Object) (*Result, error) {
+	req, err := h.request(op, obj, old)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := h.review(ctx, &admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: admissionv1.SchemeGroupVersion.String(),
+			Kind:       "AdmissionReview",
+		},
+		Request: req,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if out.Response.UID != req.UID {
+		return nil, fmt.Errorf("the response UID %q does not match the request UID %q", out.Response.UID, req.UID)
+	}
+
+	return &Result{
+		Request:  req,
+		Response: out.Response,
+	}, nil
+}
+
+func (h *Harness) request(op admissionv1.Operation, obj client.Object, old client.Object) (*admissionv1.AdmissionRequest, error) {
+	ref := obj
+	if ref == nil {
+		ref = old
+	}
+
+	if ref == nil {
+		return nil, errors.New("an object is required")
+	}
+
+	gvk, err := apiutil.GVKForObject(ref, h.scheme)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine the kind of the object: %w", err)
+	}
+
+	gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+
+	req := admissionv1.AdmissionRequest{
+		UID:             uuid.NewUUID(),
+		Kind:            metav1.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind},
+		Resource:        metav1.GroupVersionResource{Group: gvr.Group, Version: gvr.Version, Resource: gvr.Resource},
+		RequestKind:     &metav1.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind},
+		RequestResource: &metav1.GroupVersionResource{Group: gvr.Group, Version: gvr.Version, Resource: gvr.Resource},
+		Name:            ref.GetName(),
+		Namespace:       ref.GetNamespace(),
+		Operation:       op,
+		UserInfo:        h.userInfo,
+		DryRun:          &h.dryRun,
+	}
+
+	if obj != nil {
+		req.Object, err = h.raw(obj, gvk.GroupVersion().String(), gvk.Kind)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if old != nil {
+		req.OldObject, err = h.raw(old, gvk.GroupVersion().String(), gvk.Kind)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &req, nil
+}
+
+// raw marshals the given object, setting apiVersion and kind which are
+// usually left empty on typed objects.
+func (h *Harness) raw(obj client.Object, apiVersion string, kind string) (runtime.RawExtension, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return runtime.RawExtension{}, fmt.Errorf("unable to convert object: %w", err)
+	}
+
+	u["apiVersion"] = apiVersion
+	u["kind"] = kind
+
+	data, err := json.Marshal(u)
+	if err != nil {
+		return runtime.RawExtension{}, fmt.Errorf("unable to marshal object: %w", err)
+	}
+
+	return runtime.RawExtension{Raw: data}, nil
+}
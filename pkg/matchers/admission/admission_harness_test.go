@@ -0,0 +1,138 @@
+package admission_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/admission"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jsonpatch"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cradmission "sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	. "github.com/onsi/gomega"
+)
+
+// defaulter labels pods with the user issuing the request and denies pods
+// named "bad".
+var defaulter = cradmission.HandlerFunc(func(_ context.Context, req cradmission.Request) cradmission.Response {
+	if req.Operation == admissionv1.Delete {
+		return cradmission.Allowed("")
+	}
+
+	if req.Name == "bad" {
+		return cradmission.Denied("bad pods are not allowed")
+	}
+
+	pod := corev1.Pod{}
+	if err := json.Unmarshal(req.Object.Raw, &pod); err != nil {
+		return cradmission.Errored(http.StatusBadRequest, err)
+	}
+
+	if pod.Labels == nil {
+		pod.Labels = map[string]string{}
+	}
+
+	pod.Labels["created-by"] = req.UserInfo.Username
+
+	data, err := json.Marshal(pod)
+	if err != nil {
+		return cradmission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return cradmission.PatchResponseFromRaw(req.Object.Raw, data)
+})
+
+func newPod(name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app", Image: "app:1"}},
+		},
+	}
+}
+
+func TestHarness(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	h := admission.New(defaulter, admission.WithUserInfo(authenticationv1.UserInfo{Username: "alice"}))
+
+	res, err := h.Create(t.Context(), newPod("foo"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(res).Should(admission.BeAllowed())
+	g.Expect(res.Request.Kind.Kind).Should(Equal("Pod"))
+	g.Expect(res.Request.Resource.Resource).Should(Equal("pods"))
+	g.Expect(res.Request.Namespace).Should(Equal("default"))
+
+	g.Expect(res.Response).Should(
+		jsonpatch.MatchPatch(jsonpatch.Operation{
+			Op:    "add",
+			Path:  "/metadata/labels",
+			Value: HaveKeyWithValue("created-by", "alice"),
+		}),
+	)
+
+	g.Expect(res.Patched()).Should(
+		jq.Match(`.metadata.labels."created-by" == "alice" and .kind == "Pod"`),
+	)
+
+	res, err = h.Create(t.Context(), newPod("bad"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(res).Should(admission.BeDeniedWith("not allowed"))
+
+	res, err = h.Delete(t.Context(), newPod("foo"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(res).Should(admission.BeAllowed())
+	g.Expect(res.Request.OldObject.Raw).ShouldNot(BeEmpty())
+	g.Expect(res.Request.Object.Raw).Should(BeEmpty())
+
+	_, err = res.Patched()
+	g.Expect(err).Should(HaveOccurred())
+}
+
+func TestHarnessHTTP(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	h := admission.NewHTTP(&cradmission.Webhook{Handler: defaulter})
+
+	old := newPod("foo")
+	obj := newPod("foo")
+	obj.Labels = map[string]string{"app": "foo"}
+
+	res, err := h.Update(t.Context(), obj, old)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(res).Should(admission.BeAllowed())
+	g.Expect(res.Request.Operation).Should(Equal(admissionv1.Update))
+
+	g.Expect(res.Request.Object.Raw).Should(
+		jsonpatch.ApplyAndMatch(res.Response, jq.Match(`.metadata.labels | has("app") and has("created-by")`)),
+	)
+
+	res, err = h.Create(t.Context(), newPod("bad"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(res).Should(admission.BeDeniedWith("bad pods"))
+	g.Expect(res).ShouldNot(admission.BeAllowed())
+}
+
+func TestHarnessUnknownKind(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	h := admission.New(defaulter)
+
+	_, err := h.Create(t.Context(), &metav1.PartialObjectMetadata{})
+	g.Expect(err).Should(MatchError(ContainSubstring("unable to determine the kind")))
+}
@@ -0,0 +1,98 @@
+package admission
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	admissionv1 "k8s.io/api/admission/v1"
+)
+
+// BeAllowed succeeds if the actual admission Result, AdmissionResponse or
+// AdmissionReview allows the request.
+func BeAllowed() types.GomegaMatcher {
+	return &allowedMatcher{}
+}
+
+// BeDeniedWith succeeds if the actual admission Result, AdmissionResponse or
+// AdmissionReview denies the request with a message containing the given
+// substring.
+func BeDeniedWith(substr string) types.GomegaMatcher {
+	return &allowedMatcher{
+		denied: true,
+		substr: substr,
+	}
+}
+
+var _ types.GomegaMatcher = &allowedMatcher{}
+
+type allowedMatcher struct {
+	denied  bool
+	substr  string
+	allowed bool
+	message string
+}
+
+func (matcher *allowedMatcher) Match(actual interface{}) (bool, error) {
+	resp, err := toResponse(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.allowed = resp.Allowed
+	matcher.message = ""
+
+	if resp.Result != nil {
+		matcher.message = resp.Result.Message
+	}
+
+	if !matcher.denied {
+		return matcher.allowed, nil
+	}
+
+	return !matcher.allowed && strings.Contains(matcher.message, matcher.substr), nil
+}
+
+func (matcher *allowedMatcher) FailureMessage(_ interface{}) string {
+	if matcher.denied {
+		return fmt.Sprintf("Expected admission to be denied with %q, allowed: %t, message: %q",
+			matcher.substr, matcher.allowed, matcher.message)
+	}
+
+	return fmt.Sprintf("Expected admission to be allowed, denied with: %q", matcher.message)
+}
+
+func (matcher *allowedMatcher) NegatedFailureMessage(_ interface{}) string {
+	if matcher.denied {
+		return fmt.Sprintf("Expected admission not to be denied with %q, message: %q",
+			matcher.substr, matcher.message)
+	}
+
+	return "Expected admission not to be allowed"
+}
+
+func toResponse(actual interface{}) (*admissionv1.AdmissionResponse, error) {
+	var resp *admissionv1.AdmissionResponse
+
+	switch v := actual.(type) {
+	case *Result:
+		if v != nil {
+			resp = v.Response
+		}
+	case *admissionv1.AdmissionResponse:
+		resp = v
+	case *admissionv1.AdmissionReview:
+		if v != nil {
+			resp = v.Response
+		}
+	default:
+		return nil, fmt.Errorf("expected an admission result, response or review, got:\n%s", format.Object(actual, 1))
+	}
+
+	if resp == nil {
+		return nil, fmt.Errorf("no admission response found in:\n%s", format.Object(actual, 1))
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,34 @@
+package admission_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/admission"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestBeAllowed(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	allowed := &admissionv1.AdmissionResponse{Allowed: true}
+	denied := &admissionv1.AdmissionResponse{Result: &metav1.Status{Message: "replicas must be positive"}}
+
+	g.Expect(allowed).Should(admission.BeAllowed())
+	g.Expect(&admissionv1.AdmissionReview{Response: allowed}).Should(admission.BeAllowed())
+	g.Expect(denied).ShouldNot(admission.BeAllowed())
+
+	g.Expect(denied).Should(admission.BeDeniedWith("must be positive"))
+	g.Expect(denied).ShouldNot(admission.BeDeniedWith("must be negative"))
+	g.Expect(allowed).ShouldNot(admission.BeDeniedWith(""))
+
+	_, err := admission.BeAllowed().Match(&admissionv1.AdmissionReview{})
+	g.Expect(err).Should(HaveOccurred())
+
+	_, err = admission.BeAllowed().Match("allowed")
+	g.Expect(err).Should(HaveOccurred())
+}
@@ -0,0 +1,234 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/onsi/gomega/format"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// kindEventList is the kind of the batches written by the webhook backend
+	kindEventList = "EventList"
+)
+
+// Event is an API server audit event, as written by the log backend. It
+// mirrors the audit.k8s.io/v1 Event, omitting the request and response
+// objects, so that the API server module is not required.
+type Event struct {
+	Level                    string                     `json:"level,omitempty"`
+	AuditID                  string                     `json:"auditID,omitempty"`
+	Stage                    string                     `json:"stage,omitempty"`
+	RequestURI               string                     `json:"requestURI,omitempty"`
+	Verb                     string                     `json:"verb,omitempty"`
+	User                     authenticationv1.UserInfo  `json:"user"`
+	ImpersonatedUser         *authenticationv1.UserInfo `json:"impersonatedUser,omitempty"`
+	SourceIPs                []string                   `json:"sourceIPs,omitempty"`
+	UserAgent                string                     `json:"userAgent,omitempty"`
+	ObjectRef                *ObjectReference           `json:"objectRef,omitempty"`
+	ResponseStatus           *metav1.Status             `json:"responseStatus,omitempty"`
+	RequestReceivedTimestamp metav1.MicroTime           `json:"requestReceivedTimestamp"`
+	StageTimestamp           metav1.MicroTime           `json:"stageTimestamp"`
+	Annotations              map[string]string          `json:"annotations,omitempty"`
+}
+
+// ObjectReference identifies the object an audit event refers to.
+type ObjectReference struct {
+	Resource        string `json:"resource,omitempty"`
+	Namespace       string `json:"namespace,omitempty"`
+	Name            string `json:"name,omitempty"`
+	UID             string `json:"uid,omitempty"`
+	APIGroup        string `json:"apiGroup,omitempty"`
+	APIVersion      string `json:"apiVersion,omitempty"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+	Subresource     string `json:"subresource,omitempty"`
+}
+
+// Time returns the time the request was received, or the time of the stage
+// if the former is not known.
+func (e *Event) Time() time.Time {
+	if !e.RequestReceivedTimestamp.IsZero() {
+		return e.RequestReceivedTimestamp.Time
+	}
+
+	return e.StageTimestamp.Time
+}
+
+// Resource returns the resource the event refers to, followed by the
+// subresource if any, i.e. "deployments/status".
+func (e *Event) Resource() string {
+	if e.ObjectRef == nil {
+		return ""
+	}
+
+	if e.ObjectRef.Subresource != "" {
+		return e.ObjectRef.Resource + "/" + e.ObjectRef.Subresource
+	}
+
+	return e.ObjectRef.Resource
+}
+
+// Code returns the HTTP status code of the response, or 0 if the event
+// does not carry one, i.e. at the RequestReceived stage.
+func (e *Event) Code() int32 {
+	if e.ResponseStatus == nil {
+		return 0
+	}
+
+	return e.ResponseStatus.Code
+}
+
+// String describes the event on a single line, for failure messages, i.e.
+// "2024-05-01T10:00:00Z get deployments ns/app by alice: 200".
+func (e *Event) String() string {
+	target := e.Resource()
+	if target == "" {
+		target = e.RequestURI
+	}
+
+	if e.ObjectRef != nil {
+		switch {
+		case e.ObjectRef.Namespace != "" && e.ObjectRef.Name != "":
+			target += " " + e.ObjectRef.Namespace + "/" + e.ObjectRef.Name
+		case e.ObjectRef.Namespace != "":
+			target += " in " + e.ObjectRef.Namespace
+		case e.ObjectRef.Name != "":
+			target += " " + e.ObjectRef.Name
+		}
+	}
+
+	s := fmt.Sprintf("%s %s %s by %s", e.Time().UTC().Format(time.RFC3339Nano), e.Verb, target, e.User.Username)
+
+	if code := e.Code(); code != 0 {
+		s += fmt.Sprintf(": %d", code)
+	}
+
+	return s
+}
+
+// Parse parses the given audit log, made of one JSON event per line. Lines
+// holding an EventList, as sent by the webhook backend, are expanded to
+// their items.
+func Parse(data []byte) ([]Event, error) {
+	return Read(bytes.NewReader(data))
+}
+
+// Read reads an audit log, made of one JSON event per line, from the given
+// reader. A trailing line which cannot be decoded is ignored, as it is
+// likely being written by the API server.
+func Read(r io.Reader) ([]Event, error) {
+	var events []Event
+
+	br := bufio.NewReader(r)
+
+	for n := 1; ; n++ {
+		data, err := br.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("unable to read audit log, %w", err)
+		}
+
+		eof := errors.Is(err, io.EOF)
+
+		if line := bytes.TrimSpace(data); len(line) > 0 {
+			decoded, derr := decodeLine(line)
+
+			switch {
+			case derr != nil && eof:
+				// partial line
+			case derr != nil:
+				return nil, fmt.Errorf("unable to decode audit event at line %d, %w", n, derr)
+			default:
+				events = append(events, decoded...)
+			}
+		}
+
+		if eof {
+			return events, nil
+		}
+	}
+}
+
+// ReadFile reads the audit log at the given path.
+func ReadFile(path string) ([]Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open audit log, %w", err)
+	}
+
+	defer func() { _ = f.Close() }()
+
+	return Read(f)
+}
+
+// File returns a function reading the audit log at the given path each time
+// it is called, to be polled while the API server flushes its events:
+//
+//	Eventually(audit.File("/var/log/kube-apiserver/audit.log")).Should(audit.HaveEvent("delete", "pods", "system:serviceaccount:ns:operator"))
+func File(path string) func() ([]Event, error) {
+	return func() ([]Event, error) {
+		return ReadFile(path)
+	}
+}
+
+func decodeLine(line []byte) ([]Event, error) {
+	var in struct {
+		Event
+
+		Kind  string  `json:"kind"`
+		Items []Event `json:"items"`
+	}
+
+	if err := json.Unmarshal(line, &in); err != nil {
+		return nil, err
+	}
+
+	if in.Kind == kindEventList {
+		return in.Items, nil
+	}
+
+	return []Event{in.Event}, nil
+}
+
+// toEvents converts the actual value, either events or an audit log, to
+// events.
+func toEvents(actual interface{}) ([]Event, error) {
+	switch v := actual.(type) {
+	case []Event:
+		return v, nil
+	case []*Event:
+		events := make([]Event, 0, len(v))
+
+		for _, e := range v {
+			if e != nil {
+				events = append(events, *e)
+			}
+		}
+
+		return events, nil
+	case Event:
+		return []Event{v}, nil
+	case *Event:
+		if v == nil {
+			return nil, errors.New("expected audit events, got nil")
+		}
+
+		return []Event{*v}, nil
+	case []byte:
+		return Parse(v)
+	case string:
+		return Read(strings.NewReader(v))
+	case io.Reader:
+		return Read(v)
+	default:
+		return nil, fmt.Errorf("expected audit events or an audit log, got:\n%s", format.Object(actual, 1))
+	}
+}
@@ -0,0 +1,71 @@
+package audit_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/audit"
+
+	. "github.com/onsi/gomega"
+)
+
+const auditLog = `
+{"kind":"Event","apiVersion":"audit.k8s.io/v1","level":"Metadata","auditID":"1","stage":"ResponseComplete","requestURI":"/apis/apps/v1/namespaces/ns/deployments/app","verb":"get","user":{"username":"system:serviceaccount:ns:operator"},"objectRef":{"resource":"deployments","namespace":"ns","name":"app","apiGroup":"apps","apiVersion":"v1"},"responseStatus":{"metadata":{},"code":200},"requestReceivedTimestamp":"2024-05-01T10:00:00.000000Z","stageTimestamp":"2024-05-01T10:00:00.010000Z"}
+{"kind":"Event","apiVersion":"audit.k8s.io/v1","level":"Metadata","auditID":"2","stage":"ResponseComplete","requestURI":"/apis/apps/v1/namespaces/ns/deployments/app/status","verb":"patch","user":{"username":"system:serviceaccount:ns:operator"},"objectRef":{"resource":"deployments","namespace":"ns","name":"app","apiGroup":"apps","apiVersion":"v1","subresource":"status"},"responseStatus":{"metadata":{},"code":200},"requestReceivedTimestamp":"2024-05-01T10:01:00.000000Z","stageTimestamp":"2024-05-01T10:01:00.010000Z"}
+{"kind":"Event","apiVersion":"audit.k8s.io/v1","level":"Metadata","auditID":"3","stage":"ResponseComplete","requestURI":"/api/v1/namespaces/ns/secrets/db","verb":"delete","user":{"username":"alice"},"objectRef":{"resource":"secrets","namespace":"ns","name":"db","apiVersion":"v1"},"responseStatus":{"metadata":{},"status":"Failure","reason":"Forbidden","code":403},"requestReceivedTimestamp":"2024-05-01T10:02:00.000000Z","stageTimestamp":"2024-05-01T10:02:00.010000Z"}
+`
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	events, err := audit.Parse([]byte(auditLog))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(events).Should(HaveLen(3))
+
+	g.Expect(events[1].Resource()).Should(Equal("deployments/status"))
+	g.Expect(events[2].Code()).Should(BeEquivalentTo(403))
+	g.Expect(events[2].String()).Should(Equal("2024-05-01T10:02:00Z delete secrets ns/db by alice: 403"))
+}
+
+func TestParsePartialLine(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	// the last line is still being written
+	events, err := audit.Read(strings.NewReader(auditLog + `{"kind":"Event","verb":"g`))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(events).Should(HaveLen(3))
+
+	_, err = audit.Read(strings.NewReader(`{"kind":"Event","verb":"g` + "\n" + auditLog))
+	g.Expect(err).Should(MatchError(ContainSubstring("line 1")))
+}
+
+func TestParseEventList(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	events, err := audit.Parse([]byte(`{"kind":"EventList","apiVersion":"audit.k8s.io/v1","items":[{"verb":"get"},{"verb":"list"}]}`))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(events).Should(HaveLen(2))
+	g.Expect(events[1].Verb).Should(Equal("list"))
+}
+
+func TestFile(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	g.Expect(os.WriteFile(path, []byte(auditLog), 0o600)).Should(Succeed())
+	g.Eventually(audit.File(path)).Should(audit.HaveEvent("delete", "secrets", "alice"))
+
+	_, err := audit.ReadFile(filepath.Join(t.TempDir(), "missing.log"))
+	g.Expect(err).Should(HaveOccurred())
+}
@@ -0,0 +1,346 @@
+package audit
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/onsi/gomega/types"
+)
+
+const (
+	// maxReportedEvents bounds the events listed by failure messages
+	maxReportedEvents = 10
+)
+
+// HaveEvent succeeds if the actual audit log, either events or the content
+// of a log file, holds an event for the given verb, resource and user. The
+// resource may include a subresource, i.e. "deployments/status", and empty
+// arguments match anything. Further expectations can be added with
+// InNamespace, Named, WithCode, Within and Since:
+//
+//	Expect(audit.ReadFile(path)).Should(audit.HaveEvent("patch", "deployments", "system:serviceaccount:ns:operator").
+//	    InNamespace("ns").
+//	    Since(start))
+func HaveEvent(verb string, resource string, user string) *EventMatcher {
+	return &EventMatcher{
+		verb:     verb,
+		resource: resource,
+		user:     user,
+	}
+}
+
+var _ types.GomegaMatcher = &EventMatcher{}
+
+// EventMatcher is the matcher returned by HaveEvent.
+type EventMatcher struct {
+	verb      string
+	resource  string
+	user      string
+	namespace string
+	name      string
+	code      int
+	window    window
+
+	events []Event
+}
+
+// window is a time window, a zero bound leaving it open on that side.
+type window struct {
+	from time.Time
+	to   time.Time
+}
+
+func (w window) contains(t time.Time) bool {
+	return (w.from.IsZero() || !t.Before(w.from)) && (w.to.IsZero() || !t.After(w.to))
+}
+
+func (w window) String() string {
+	switch {
+	case !w.from.IsZero() && !w.to.IsZero():
+		return fmt.Sprintf("between %s and %s", w.from.UTC().Format(time.RFC3339Nano), w.to.UTC().Format(time.RFC3339Nano))
+	case !w.from.IsZero():
+		return "since " + w.from.UTC().Format(time.RFC3339Nano)
+	case !w.to.IsZero():
+		return "until " + w.to.UTC().Format(time.RFC3339Nano)
+	default:
+		return ""
+	}
+}
+
+// InNamespace requires the event to refer to an object in the given
+// namespace.
+func (matcher *EventMatcher) InNamespace(namespace string) *EventMatcher {
+	matcher.namespace = namespace
+
+	return matcher
+}
+
+// Named requires the event to refer to the object with the given name.
+func (matcher *EventMatcher) Named(name string) *EventMatcher {
+	matcher.name = name
+
+	return matcher
+}
+
+// WithCode requires the response of the request to have the given HTTP
+// status code, i.e. http.StatusForbidden.
+func (matcher *EventMatcher) WithCode(code int) *EventMatcher {
+	matcher.code = code
+
+	return matcher
+}
+
+// Within requires the request to be received between the given times,
+// inclusive.
+func (matcher *EventMatcher) Within(from time.Time, to time.Time) *EventMatcher {
+	matcher.window = window{from: from, to: to}
+
+	return matcher
+}
+
+// Since requires the request to be received at or after the given time,
+// i.e. the start of the test.
+func (matcher *EventMatcher) Since(from time.Time) *EventMatcher {
+	matcher.window = window{from: from}
+
+	return matcher
+}
+
+func (matcher *EventMatcher) Match(actual interface{}) (bool, error) {
+	events, err := toEvents(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.events = events
+
+	for i := range events {
+		if matcher.matches(&events[i]) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (matcher *EventMatcher) matches(e *Event) bool {
+	switch {
+	case matcher.verb != "" && e.Verb != matcher.verb:
+		return false
+	case matcher.resource != "" && e.Resource() != matcher.resource:
+		return false
+	case matcher.user != "" && e.User.Username != matcher.user:
+		return false
+	case matcher.namespace != "" && (e.ObjectRef == nil || e.ObjectRef.Namespace != matcher.namespace):
+		return false
+	case matcher.name != "" && (e.ObjectRef == nil || e.ObjectRef.Name != matcher.name):
+		return false
+	case matcher.code != 0 && int(e.Code()) != matcher.code:
+		return false
+	default:
+		return matcher.window.contains(e.Time())
+	}
+}
+
+func (matcher *EventMatcher) FailureMessage(_ interface{}) string {
+	msg := fmt.Sprintf("Expected audit log to contain %s, but none of its %d events matches", matcher, len(matcher.events))
+
+	// the events of the same user are the most likely to explain the failure
+	var related []Event
+
+	for i := range matcher.events {
+		if matcher.user == "" || matcher.events[i].User.Username == matcher.user {
+			related = append(related, matcher.events[i])
+		}
+	}
+
+	if len(related) > 0 {
+		msg += "\n\nevents:\n" + describeEvents(related)
+	}
+
+	return msg
+}
+
+func (matcher *EventMatcher) NegatedFailureMessage(_ interface{}) string {
+	msg := fmt.Sprintf("Expected audit log not to contain %s", matcher)
+
+	var found []Event
+
+	for i := range matcher.events {
+		if matcher.matches(&matcher.events[i]) {
+			found = append(found, matcher.events[i])
+		}
+	}
+
+	if len(found) > 0 {
+		msg += ", but found:\n" + describeEvents(found)
+	}
+
+	return msg
+}
+
+// String describes the expected event, i.e. "a patch of deployments by
+// alice in namespace ns".
+func (matcher *EventMatcher) String() string {
+	verb := matcher.verb
+	if verb == "" {
+		verb = "request"
+	}
+
+	msg := "a " + verb
+
+	if matcher.resource != "" {
+		msg += " of " + matcher.resource
+	}
+
+	if matcher.name != "" {
+		msg += " named " + matcher.name
+	}
+
+	if matcher.user != "" {
+		msg += " by " + matcher.user
+	}
+
+	if matcher.namespace != "" {
+		msg += " in namespace " + matcher.namespace
+	}
+
+	if matcher.code != 0 {
+		msg += fmt.Sprintf(" with code %d", matcher.code)
+	}
+
+	if w := matcher.window.String(); w != "" {
+		msg += " " + w
+	}
+
+	return msg
+}
+
+// HaveOnlyEvents succeeds if every event of the actual audit log issued by
+// the given user matches at least one of the given event matchers, so that
+// e2e suites can verify an operator only performs the expected API
+// operations:
+//
+//	Expect(audit.ReadFile(path)).Should(audit.HaveOnlyEvents("system:serviceaccount:ns:operator",
+//	    audit.HaveEvent("get", "", ""),
+//	    audit.HaveEvent("list", "", ""),
+//	    audit.HaveEvent("watch", "", ""),
+//	    audit.HaveEvent("patch", "deployments", "").InNamespace("ns"),
+//	).Since(start))
+func HaveOnlyEvents(user string, allowed ...*EventMatcher) *OnlyEventsMatcher {
+	return &OnlyEventsMatcher{
+		user:    user,
+		allowed: allowed,
+	}
+}
+
+var _ types.GomegaMatcher = &OnlyEventsMatcher{}
+
+// OnlyEventsMatcher is the matcher returned by HaveOnlyEvents.
+type OnlyEventsMatcher struct {
+	user    string
+	allowed []*EventMatcher
+	window  window
+
+	unexpected []Event
+}
+
+// Within only considers the requests received between the given times,
+// inclusive.
+func (matcher *OnlyEventsMatcher) Within(from time.Time, to time.Time) *OnlyEventsMatcher {
+	matcher.window = window{from: from, to: to}
+
+	return matcher
+}
+
+// Since only considers the requests received at or after the given time.
+func (matcher *OnlyEventsMatcher) Since(from time.Time) *OnlyEventsMatcher {
+	matcher.window = window{from: from}
+
+	return matcher
+}
+
+func (matcher *OnlyEventsMatcher) Match(actual interface{}) (bool, error) {
+	events, err := toEvents(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.unexpected = nil
+
+	for i := range events {
+		e := &events[i]
+
+		if e.User.Username != matcher.user || !matcher.window.contains(e.Time()) {
+			continue
+		}
+
+		if !matcher.isAllowed(e) {
+			matcher.unexpected = append(matcher.unexpected, *e)
+		}
+	}
+
+	return len(matcher.unexpected) == 0, nil
+}
+
+func (matcher *OnlyEventsMatcher) isAllowed(e *Event) bool {
+	for _, a := range matcher.allowed {
+		if a.matches(e) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (matcher *OnlyEventsMatcher) FailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected %s to only issue %s%s, but found %d unexpected events:\n%s",
+		matcher.user, matcher.describeAllowed(), matcher.describeWindow(), len(matcher.unexpected), describeEvents(matcher.unexpected))
+}
+
+func (matcher *OnlyEventsMatcher) NegatedFailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected %s not to only issue %s%s", matcher.user, matcher.describeAllowed(), matcher.describeWindow())
+}
+
+func (matcher *OnlyEventsMatcher) describeAllowed() string {
+	if len(matcher.allowed) == 0 {
+		return "no request"
+	}
+
+	allowed := make([]string, 0, len(matcher.allowed))
+
+	for _, a := range matcher.allowed {
+		allowed = append(allowed, a.String())
+	}
+
+	return strings.Join(allowed, ", ")
+}
+
+func (matcher *OnlyEventsMatcher) describeWindow() string {
+	if w := matcher.window.String(); w != "" {
+		return " " + w
+	}
+
+	return ""
+}
+
+// describeEvents lists the given events one per line, keeping the most
+// recent ones if there are too many.
+func describeEvents(events []Event) string {
+	var sb strings.Builder
+
+	if skipped := len(events) - maxReportedEvents; skipped > 0 {
+		fmt.Fprintf(&sb, "  ... %d more\n", skipped)
+
+		events = events[skipped:]
+	}
+
+	for i := range events {
+		sb.WriteString("  ")
+		sb.WriteString(events[i].String())
+		sb.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
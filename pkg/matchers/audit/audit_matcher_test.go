@@ -0,0 +1,101 @@
+package audit_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/audit"
+
+	. "github.com/onsi/gomega"
+)
+
+const operator = "system:serviceaccount:ns:operator"
+
+func TestHaveEvent(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	events, err := audit.Parse([]byte(auditLog))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(auditLog).Should(audit.HaveEvent("get", "deployments", operator))
+	g.Expect(events).Should(audit.HaveEvent("get", "deployments", operator).InNamespace("ns").Named("app"))
+	g.Expect(events).Should(audit.HaveEvent("patch", "deployments/status", ""))
+	g.Expect(events).Should(audit.HaveEvent("delete", "", "alice").WithCode(http.StatusForbidden))
+	g.Expect(events).Should(audit.HaveEvent("", "", operator))
+
+	g.Expect(events).ShouldNot(audit.HaveEvent("patch", "deployments", operator))
+	g.Expect(events).ShouldNot(audit.HaveEvent("get", "deployments", "alice"))
+	g.Expect(events).ShouldNot(audit.HaveEvent("get", "deployments", operator).InNamespace("other"))
+	g.Expect(events).ShouldNot(audit.HaveEvent("delete", "secrets", "alice").WithCode(http.StatusOK))
+
+	_, err = audit.HaveEvent("get", "", "").Match(42)
+	g.Expect(err).Should(HaveOccurred())
+}
+
+func TestHaveEventWithin(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	events, err := audit.Parse([]byte(auditLog))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	start := time.Date(2024, 5, 1, 10, 0, 30, 0, time.UTC)
+
+	g.Expect(events).Should(audit.HaveEvent("patch", "", operator).Since(start))
+	g.Expect(events).ShouldNot(audit.HaveEvent("get", "", operator).Since(start))
+	g.Expect(events).Should(audit.HaveEvent("get", "", operator).Within(start.Add(-time.Minute), start))
+	g.Expect(events).ShouldNot(audit.HaveEvent("delete", "", "").Within(start.Add(-time.Minute), start))
+}
+
+func TestHaveEventFailureMessage(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	m := audit.HaveEvent("delete", "deployments", operator).InNamespace("ns")
+
+	ok, err := m.Match(auditLog)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ok).Should(BeFalse())
+
+	msg := m.FailureMessage(auditLog)
+	g.Expect(msg).Should(ContainSubstring("to contain a delete of deployments by " + operator + " in namespace ns, but none of its 3 events matches"))
+	g.Expect(msg).Should(ContainSubstring("patch deployments/status ns/app by " + operator + ": 200"))
+	g.Expect(msg).ShouldNot(ContainSubstring("alice"))
+
+	m = audit.HaveEvent("delete", "secrets", "")
+
+	ok, err = m.Match(auditLog)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ok).Should(BeTrue())
+	g.Expect(m.NegatedFailureMessage(auditLog)).Should(ContainSubstring("but found:\n  2024-05-01T10:02:00Z delete secrets ns/db by alice: 403"))
+}
+
+func TestHaveOnlyEvents(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	g.Expect(auditLog).Should(audit.HaveOnlyEvents(operator,
+		audit.HaveEvent("get", "", ""),
+		audit.HaveEvent("patch", "deployments/status", "").InNamespace("ns"),
+	))
+
+	g.Expect(auditLog).Should(audit.HaveOnlyEvents(operator,
+		audit.HaveEvent("patch", "", ""),
+	).Since(time.Date(2024, 5, 1, 10, 0, 30, 0, time.UTC)))
+
+	m := audit.HaveOnlyEvents(operator, audit.HaveEvent("get", "", ""))
+
+	ok, err := m.Match(auditLog)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ok).Should(BeFalse())
+	g.Expect(m.FailureMessage(auditLog)).Should(And(
+		ContainSubstring("to only issue a get, but found 1 unexpected events"),
+		ContainSubstring("patch deployments/status ns/app"),
+	))
+}
@@ -0,0 +1,124 @@
+package matchers
+
+import (
+	"sync/atomic"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/yq"
+)
+
+//nolint:gochecknoglobals
+var defaults atomic.Pointer[defaultMatchers]
+
+// defaultMatchers holds the default Config along with the jq and yq Matchers
+// created from it, shared by the facade functions so that they reuse compiled
+// expressions.
+type defaultMatchers struct {
+	config Config
+	jq     *jq.Matcher
+	yq     *yq.Matcher
+}
+
+// Config holds the settings of the jq and yq matchers. Matchers created
+// through a Config only depend on its settings, and on the process wide
+// settings of the jq package for the zero ones, hence different Configs can
+// safely be used by parallel tests or Ginkgo nodes, i.e.:
+//
+//	var cfg = matchers.Config{Strict: true, RedactPaths: []string{".data[]?"}}
+//
+//	Expect(secret).Should(cfg.JQ().Match(`.type == "Opaque"`))
+type Config struct {
+	// MaxOutputLength bounds the length of the actual value rendered in
+	// failure messages, zero keeps the limit set by jq.SetMaxOutputLength.
+	MaxOutputLength int
+
+	// RedactPaths lists the jq paths whose values are redacted in failure
+	// messages, when empty the paths set by jq.RedactPaths apply.
+	RedactPaths []string
+
+	// Strict makes jq matchers fail with an error when an expression does
+	// not evaluate to a boolean.
+	Strict bool
+
+	// Converters are tried in order, before the built-in conversions, to
+	// convert the values jq expressions are evaluated against.
+	Converters []jq.Converter
+
+	// PreserveComments keeps the comments found before the first node of a
+	// document attached to it when evaluating yq expressions.
+	PreserveComments bool
+}
+
+// SetDefaultConfig sets the Config returned by DefaultConfig, i.e. from
+// TestMain or the suite setup to configure a whole test binary. The jq and yq
+// functions of this package, i.e. MatchJQ, use the default Config, while the
+// package level functions of the jq and yq packages, i.e. jq.Match, only
+// depend on the process wide settings of these packages.
+func SetDefaultConfig(c Config) {
+	defaults.Store(&defaultMatchers{
+		config: c,
+		jq:     c.JQ(),
+		yq:     c.YQ(),
+	})
+}
+
+// DefaultConfig returns the Config set with SetDefaultConfig, or an empty one.
+func DefaultConfig() *Config {
+	c := loadDefaults().config
+
+	return &c
+}
+
+func loadDefaults() *defaultMatchers {
+	if d := defaults.Load(); d != nil {
+		return d
+	}
+
+	// the zero Config does not override any process wide setting
+	d := &defaultMatchers{
+		jq: jq.New(),
+		yq: yq.New(),
+	}
+
+	if defaults.CompareAndSwap(nil, d) {
+		return d
+	}
+
+	return defaults.Load()
+}
+
+// JQ creates a jq.Matcher configured with the settings of the Config,
+// followed by the given options.
+func (c *Config) JQ(opts ...jq.Option) *jq.Matcher {
+	options := []jq.Option{
+		jq.WithConverters(c.Converters...),
+	}
+
+	// zero values must not override the process wide settings, which would
+	// i.e. disable the redaction of Secrets
+	if c.MaxOutputLength != 0 {
+		options = append(options, jq.WithMaxOutputLength(c.MaxOutputLength))
+	}
+
+	if len(c.RedactPaths) > 0 {
+		options = append(options, jq.WithRedactPaths(c.RedactPaths...))
+	}
+
+	if c.Strict {
+		options = append(options, jq.Strict())
+	}
+
+	return jq.New(append(options, opts...)...)
+}
+
+// YQ creates a yq.Matcher configured with the settings of the Config,
+// followed by the given options.
+func (c *Config) YQ(opts ...yq.Option) *yq.Matcher {
+	var options []yq.Option
+
+	if c.PreserveComments {
+		options = append(options, yq.PreserveComments())
+	}
+
+	return yq.New(append(options, opts...)...)
+}
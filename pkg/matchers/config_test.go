@@ -0,0 +1,84 @@
+package matchers_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestConfig(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	in := `{"kind":"Secret","data":{"password":"czNjcjN0"}}`
+
+	strict := matchers.Config{Strict: true, RedactPaths: []string{".data[]?"}}
+
+	_, err := strict.JQ().Match(`.kind`).Match(in)
+	g.Expect(err).Should(MatchError(ContainSubstring("a boolean is required")))
+
+	m := strict.JQ().Match(`.kind == "ConfigMap"`)
+	g.Expect(m.Match(in)).Should(BeFalse())
+	g.Expect(m.FailureMessage(in)).ShouldNot(ContainSubstring("czNjcjN0"))
+
+	lenient := matchers.Config{}
+
+	g.Expect(lenient.JQ().Match(`.kind`).Match(in)).Should(BeFalse())
+
+	m = lenient.JQ().Match(`.kind == "ConfigMap"`)
+	g.Expect(m.Match(in)).Should(BeFalse())
+	g.Expect(m.FailureMessage(in)).Should(ContainSubstring("czNjcjN0"))
+
+	comments := matchers.Config{PreserveComments: true}
+	g.Expect("# license\n\nfoo: bar\n").Should(comments.YQ().Match(`head_comment == "license"`))
+	g.Expect("# license\n\nfoo: bar\n").ShouldNot(lenient.YQ().Match(`head_comment == "license"`))
+}
+
+//nolint:paralleltest
+func TestDefaultConfig(t *testing.T) {
+	// the default Config is global, hence this test must not run in
+	// parallel with the others
+	g := NewWithT(t)
+
+	g.Expect(matchers.DefaultConfig()).Should(Equal(&matchers.Config{}))
+
+	matchers.SetDefaultConfig(matchers.Config{Strict: true})
+	defer matchers.SetDefaultConfig(matchers.Config{})
+
+	_, err := matchers.DefaultConfig().JQ().Match(`.kind`).Match(`{"kind":"Secret"}`)
+	g.Expect(err).Should(HaveOccurred())
+
+	// the facade functions use the default Config
+	_, err = matchers.MatchJQ(`.kind`).Match(`{"kind":"Secret"}`)
+	g.Expect(err).Should(MatchError(ContainSubstring("a boolean is required")))
+
+	matchers.SetDefaultConfig(matchers.Config{})
+
+	g.Expect(matchers.MatchJQ(`.kind`).Match(`{"kind":"Secret"}`)).Should(BeFalse())
+}
+
+//nolint:paralleltest
+func TestConfigKeepsProcessWideSettings(t *testing.T) {
+	// the jq settings are global, hence this test must not run in parallel
+	// with the others
+	g := NewWithT(t)
+
+	jq.RedactPaths(".data[]?")
+	defer jq.RedactPaths()
+
+	in := `{"kind":"Secret","data":{"password":"czNjcjN0"}}`
+
+	for _, cfg := range []matchers.Config{{}, {Strict: true}, {MaxOutputLength: 1024}} {
+		m := cfg.JQ().Match(`.kind == "ConfigMap"`)
+		g.Expect(m.Match(in)).Should(BeFalse())
+		g.Expect(m.FailureMessage(in)).ShouldNot(ContainSubstring("czNjcjN0"), "%+v", cfg)
+	}
+
+	m := matchers.MatchJQ(`.kind == "ConfigMap"`)
+	g.Expect(m.Match(in)).Should(BeFalse())
+	g.Expect(m.FailureMessage(in)).ShouldNot(ContainSubstring("czNjcjN0"))
+}
@@ -0,0 +1,134 @@
+package jq_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const benchmarkItems = 5000
+
+func benchmarkList() *unstructured.UnstructuredList {
+	list := unstructured.UnstructuredList{Object: map[string]any{"apiVersion": "v1", "kind": "List"}}
+
+	for i := range benchmarkItems {
+		list.Items = append(list.Items, unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]any{
+				"name":      fmt.Sprintf("pod-%d", i),
+				"namespace": "ns",
+				"labels":    map[string]any{"app": "app"},
+			},
+			"spec": map[string]any{
+				"containers": []any{
+					map[string]any{"name": "app", "image": "quay.io/app:latest"},
+				},
+			},
+			"status": map[string]any{
+				"phase":        "Running",
+				"restartCount": int64(i % 3),
+			},
+		}})
+	}
+
+	return &list
+}
+
+func BenchmarkMatchUnstructured(b *testing.B) {
+	list := benchmarkList()
+	m := jq.Match(`.items | all(.status.phase == "Running")`)
+
+	b.ResetTimer()
+
+	for range b.N {
+		if ok, err := m.Match(list); err != nil || !ok {
+			b.Fatalf("unexpected result: %v, %v", ok, err)
+		}
+	}
+}
+
+func BenchmarkMatchString(b *testing.B) {
+	data, err := json.Marshal(benchmarkList().UnstructuredContent())
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	doc := string(data)
+	m := jq.Match(`.items | all(.status.phase == "Running")`)
+
+	b.ResetTimer()
+
+	for range b.N {
+		if ok, err := m.Match(doc); err != nil || !ok {
+			b.Fatalf("unexpected result: %v, %v", ok, err)
+		}
+	}
+}
+
+func BenchmarkMatchPreconverted(b *testing.B) {
+	data, err := json.Marshal(benchmarkList().UnstructuredContent())
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	doc, err := jq.Preconvert(string(data))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	m := jq.Match(`.items | all(.status.phase == "Running")`)
+
+	b.ResetTimer()
+
+	for range b.N {
+		if ok, err := m.Match(doc); err != nil || !ok {
+			b.Fatalf("unexpected result: %v, %v", ok, err)
+		}
+	}
+}
+
+func BenchmarkExtract(b *testing.B) {
+	list := benchmarkList()
+	extract := jq.Extract(`[.items[] | select(.status.restartCount > 0)] | length`)
+
+	b.ResetTimer()
+
+	for range b.N {
+		if _, err := extract(list); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+const benchmarkPod = `{
+  "apiVersion": "v1",
+  "kind": "Pod",
+  "metadata": {"name": "pod", "namespace": "ns", "labels": {"app": "app"}},
+  "status": {"phase": "Running"}
+}`
+
+func BenchmarkMatchSmall(b *testing.B) {
+	m := jq.Match(`.status.phase == "Running"`)
+
+	b.ResetTimer()
+
+	for range b.N {
+		if ok, err := m.Match(benchmarkPod); err != nil || !ok {
+			b.Fatalf("unexpected result: %v, %v", ok, err)
+		}
+	}
+}
+
+// BenchmarkMatchSmallNewMatcher creates the matcher on every iteration, as
+// happens when assertions are made within the function given to Eventually.
+func BenchmarkMatchSmallNewMatcher(b *testing.B) {
+	for range b.N {
+		if ok, err := jq.Match(`.status.phase == "Running"`).Match(benchmarkPod); err != nil || !ok {
+			b.Fatalf("unexpected result: %v, %v", ok, err)
+		}
+	}
+}
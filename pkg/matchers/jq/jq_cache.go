@@ -0,0 +1,48 @@
+package jq
+
+import (
+	"sync"
+
+	"github.com/itchyny/gojq"
+)
+
+// maxCachedExpressions bounds the number of compiled expressions kept by a
+// Matcher, as expressions built with format arguments can be unbounded.
+const maxCachedExpressions = 512
+
+type codeCache struct {
+	lock  sync.RWMutex
+	codes map[string]*gojq.Code
+}
+
+func newCodeCache() *codeCache {
+	return &codeCache{
+		codes: make(map[string]*gojq.Code),
+	}
+}
+
+func (c *codeCache) get(expression string) (*gojq.Code, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	code, ok := c.codes[expression]
+
+	return code, ok
+}
+
+func (c *codeCache) put(expression string, code *gojq.Code) {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if len(c.codes) < maxCachedExpressions {
+		c.codes[expression] = code
+	}
+}
@@ -0,0 +1,38 @@
+package jq
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// FromTOML returns a transform that decodes a TOML document into a
+// map[string]any that can then be consumed by Match and Extract.
+func FromTOML() func(in any) (any, error) {
+	return func(in any) (any, error) {
+		data, err := toBytes(in)
+		if err != nil {
+			return nil, err
+		}
+
+		content := make(map[string]any)
+		if err := toml.Unmarshal(data, &content); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal TOML document, %w", err)
+		}
+
+		return normalize(content)
+	}
+}
+
+// normalize round-trips the given value through JSON so that it only
+// contains types gojq knows how to deal with (i.e. TOML integers and
+// datetimes are turned into float64 and RFC3339 strings respectively).
+func normalize(in any) (any, error) {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal result, %w", err)
+	}
+
+	return byteToType(b)
+}
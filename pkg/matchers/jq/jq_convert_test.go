@@ -0,0 +1,61 @@
+package jq_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+
+	. "github.com/onsi/gomega"
+)
+
+const tomlDocument = `
+title = "example"
+
+[server]
+host = "localhost"
+port = 8080
+enabled = true
+started = 2024-01-02T03:04:05Z
+
+[[inputs]]
+name = "cpu"
+
+[[inputs]]
+name = "mem"
+`
+
+func TestFromTOML(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	g.Expect(tomlDocument).Should(
+		WithTransform(jq.FromTOML(),
+			And(
+				jq.Match(`.title == "example"`),
+				jq.Match(`.server.port == 8080`),
+				jq.Match(`.server.enabled`),
+				jq.Match(`.server.started == "2024-01-02T03:04:05Z"`),
+				jq.Match(`.inputs | map(.name) == ["cpu", "mem"]`),
+			),
+		),
+	)
+
+	g.Expect([]byte(tomlDocument)).Should(
+		WithTransform(jq.FromTOML(),
+			WithTransform(jq.Extract(`.server.host`), Equal("localhost")),
+		),
+	)
+}
+
+func TestFromTOMLInvalid(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	_, err := jq.FromTOML()(`[server`)
+	g.Expect(err).Should(HaveOccurred())
+
+	_, err = jq.FromTOML()(1)
+	g.Expect(err).Should(HaveOccurred())
+}
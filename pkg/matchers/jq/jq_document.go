@@ -0,0 +1,33 @@
+package jq
+
+// Document holds a value already converted to the form jq expressions are
+// evaluated against, see Preconvert.
+type Document struct {
+	data any
+}
+
+// Preconvert converts the given value, i.e. a JSON string, once, so that the
+// returned Document can be fed to multiple matchers, or repeatedly evaluated
+// by Consistently, without being parsed every time:
+//
+//	doc, err := jq.Preconvert(output)
+//	Expect(err).ShouldNot(HaveOccurred())
+//
+//	Expect(doc).Should(And(jq.Match(`.a == 1`), jq.Match(`.b == 2`)))
+func Preconvert(actual any) (*Document, error) {
+	data, err := toType(actual)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Document{data: data}, nil
+}
+
+// Value returns the converted value.
+func (d *Document) Value() any {
+	return d.data
+}
+
+func (d *Document) String() string {
+	return toJSON(d.data)
+}
@@ -0,0 +1,41 @@
+package jq_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestPreconvert(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	doc, err := jq.Preconvert(`{"a":1,"b":{"c":"foo"}}`)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(doc.Value()).Should(HaveKey("a"))
+
+	g.Expect(doc).Should(And(
+		jq.Match(`.a == 1`),
+		jq.Match(`.b.c == "foo"`),
+		WithTransform(jq.Extract(`.b`), jq.Match(`.c == "foo"`)),
+	))
+
+	g.Consistently(doc).Should(jq.Match(`.a == 1`))
+
+	m := jq.Match(`.a == 2`)
+	g.Expect(m.Match(doc)).Should(BeFalse())
+	g.Expect(m.FailureMessage(doc)).Should(ContainSubstring(`{"a":1,"b":{"c":"foo"}}`))
+
+	_, err = jq.Preconvert(`foo`)
+	g.Expect(err).Should(HaveOccurred())
+
+	// string documents are decoded from pooled buffers, which must not be
+	// referenced by the values of previous documents
+	other, err := jq.Preconvert(`{"a":9,"b":{"c":"bar"}}`)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(other).Should(jq.Match(`.b.c == "bar"`))
+	g.Expect(doc).Should(jq.Match(`.b.c == "foo"`))
+}
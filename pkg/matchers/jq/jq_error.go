@@ -0,0 +1,117 @@
+package jq
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// MatchErrorJSON succeeds if the expression evaluates to true against the
+// JSON document carried by the actual error, rather than matching substrings
+// of its message, i.e.:
+//
+//	Expect(cli.Create(ctx, obj)).Should(jq.MatchErrorJSON(`.reason == "Forbidden" and .details.kind == "pods"`))
+//
+// The document is the Status of a Kubernetes API error, found anywhere in
+// the chain of wrapped errors, the result of marshalling an error
+// implementing json.Marshaler, or else the first JSON object or array
+// embedded in the error message. A nil error, or one carrying no JSON
+// document, is reported as an error.
+func MatchErrorJSON(format string, args ...any) types.GomegaMatcher {
+	return std.MatchErrorJSON(format, args...)
+}
+
+// MatchErrorJSON succeeds if the expression evaluates to true against the
+// JSON document carried by the actual error.
+func (m *Matcher) MatchErrorJSON(format string, args ...any) types.GomegaMatcher {
+	return &errorJSONMatcher{
+		matcher: m.Match(format, args...),
+	}
+}
+
+var _ types.GomegaMatcher = &errorJSONMatcher{}
+
+type errorJSONMatcher struct {
+	matcher types.GomegaMatcher
+	data    json.RawMessage
+}
+
+func (matcher *errorJSONMatcher) Match(actual interface{}) (bool, error) {
+	if actual == nil {
+		return false, errors.New("expected an error, got nil")
+	}
+
+	err, ok := actual.(error)
+	if !ok {
+		return false, fmt.Errorf("expected an error, got:\n%s", format.Object(actual, 1))
+	}
+
+	data, err := errorJSON(err)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.data = data
+
+	return matcher.matcher.Match(data)
+}
+
+func (matcher *errorJSONMatcher) FailureMessage(_ interface{}) string {
+	return matcher.matcher.FailureMessage(matcher.data)
+}
+
+func (matcher *errorJSONMatcher) NegatedFailureMessage(_ interface{}) string {
+	return matcher.matcher.NegatedFailureMessage(matcher.data)
+}
+
+// errorJSON returns the JSON document carried by the given error.
+func errorJSON(err error) (json.RawMessage, error) {
+	var status apierrors.APIStatus
+	if errors.As(err, &status) {
+		s := status.Status()
+
+		data, merr := json.Marshal(&s)
+		if merr != nil {
+			return nil, fmt.Errorf("unable to marshal status, %w", merr)
+		}
+
+		return data, nil
+	}
+
+	var marshaler json.Marshaler
+	if errors.As(err, &marshaler) {
+		data, merr := marshaler.MarshalJSON()
+		if merr != nil {
+			return nil, fmt.Errorf("unable to marshal error, %w", merr)
+		}
+
+		return data, nil
+	}
+
+	msg := err.Error()
+
+	// the first position a whole document can be decoded from wins, as the
+	// message may contain braces before the document, i.e. in a format verb
+	for i := strings.IndexAny(msg, "{["); i >= 0; {
+		var data json.RawMessage
+
+		if json.NewDecoder(strings.NewReader(msg[i:])).Decode(&data) == nil {
+			return bytes.TrimSpace(data), nil
+		}
+
+		next := strings.IndexAny(msg[i+1:], "{[")
+		if next < 0 {
+			break
+		}
+
+		i += next + 1
+	}
+
+	return nil, fmt.Errorf("no JSON document found in error: %s", msg)
+}
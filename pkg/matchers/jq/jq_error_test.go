@@ -0,0 +1,72 @@
+package jq_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	. "github.com/onsi/gomega"
+)
+
+type jsonError struct {
+	Code int `json:"code"`
+}
+
+func (e *jsonError) Error() string {
+	return fmt.Sprintf("failed with code %d", e.Code)
+}
+
+func (e *jsonError) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`{"code":%d}`, e.Code)), nil
+}
+
+func TestMatchErrorJSON(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "app", errors.New("not allowed"))
+
+	g.Expect(forbidden).Should(jq.MatchErrorJSON(`.reason == "Forbidden" and .details.kind == "pods"`))
+	g.Expect(forbidden).Should(jq.MatchErrorJSON(`.code == %d`, 403))
+	g.Expect(forbidden).ShouldNot(jq.MatchErrorJSON(`.reason == "NotFound"`))
+
+	// the status is found in the chain of wrapped errors
+	g.Expect(fmt.Errorf("unable to create pod: %w", forbidden)).Should(jq.MatchErrorJSON(`.reason == "Forbidden"`))
+
+	g.Expect(&jsonError{Code: 42}).Should(jq.MatchErrorJSON(`.code == 42`))
+
+	g.Expect(errors.New(`webhook denied the request: {"field": "spec.replicas", "reason": "must be positive"} (retry {later})`)).
+		Should(jq.MatchErrorJSON(`.field == "spec.replicas"`))
+	g.Expect(errors.New(`unexpected {response}: ["a", "b"]`)).
+		Should(jq.MatchErrorJSON(`. == ["a", "b"]`))
+
+	_, err := jq.MatchErrorJSON(`.reason == "Forbidden"`).Match(errors.New("plain {text}"))
+	g.Expect(err).Should(MatchError("no JSON document found in error: plain {text}"))
+
+	_, err = jq.MatchErrorJSON(`.reason == "Forbidden"`).Match(nil)
+	g.Expect(err).Should(MatchError("expected an error, got nil"))
+
+	_, err = jq.MatchErrorJSON(`.reason == "Forbidden"`).Match(`{"reason": "Forbidden"}`)
+	g.Expect(err).Should(MatchError(ContainSubstring("expected an error, got")))
+}
+
+func TestMatchErrorJSONFailureMessage(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	err := apierrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, "app", errors.New("modified"))
+
+	m := jq.MatchErrorJSON(`.reason == "Forbidden"`)
+	g.Expect(m.Match(err)).Should(BeFalse())
+	g.Expect(m.FailureMessage(err)).Should(And(
+		ContainSubstring(`"reason":"Conflict"`),
+		ContainSubstring(`to match expression`),
+		ContainSubstring(`.reason == "Forbidden"`),
+	))
+}
@@ -0,0 +1,76 @@
+package jq
+
+import (
+	"fmt"
+
+	"github.com/itchyny/gojq"
+	"github.com/onsi/gomega/types"
+)
+
+// Field succeeds if the value found at the given path, i.e. .status.replicas,
+// matches the given matcher, so that jq navigation can be combined with any
+// Gomega matcher without nesting WithTransform:
+//
+//	Expect(deployment).Should(jq.Field(".status.replicas", BeNumerically(">=", 3)))
+//
+// A path not producing any value yields nil.
+func Field(path string, matcher types.GomegaMatcher) types.GomegaMatcher {
+	return std.Field(path, matcher)
+}
+
+// Field succeeds if the value found at the given path matches the given
+// matcher.
+func (m *Matcher) Field(path string, matcher types.GomegaMatcher) types.GomegaMatcher {
+	return &fieldMatcher{
+		Path:    path,
+		config:  m,
+		matcher: matcher,
+	}
+}
+
+var _ types.GomegaMatcher = &fieldMatcher{}
+
+type fieldMatcher struct {
+	Path    string
+	config  *Matcher
+	matcher types.GomegaMatcher
+	code    *gojq.Code
+	value   any
+}
+
+func (matcher *fieldMatcher) Match(actual interface{}) (bool, error) {
+	if matcher.code == nil {
+		code, err := matcher.config.compile(matcher.Path)
+		if err != nil {
+			return false, err
+		}
+
+		matcher.code = code
+	}
+
+	data, err := matcher.config.toType(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.value = nil
+
+	v, ok := matcher.code.Run(data).Next()
+	if ok {
+		if err, ok := v.(error); ok {
+			return false, fmt.Errorf("unable to extract %s: %w", matcher.Path, err)
+		}
+
+		matcher.value = v
+	}
+
+	return matcher.matcher.Match(matcher.value)
+}
+
+func (matcher *fieldMatcher) FailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected field %s to match:\n%s", matcher.Path, matcher.matcher.FailureMessage(matcher.value))
+}
+
+func (matcher *fieldMatcher) NegatedFailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected field %s not to match:\n%s", matcher.Path, matcher.matcher.NegatedFailureMessage(matcher.value))
+}
@@ -0,0 +1,56 @@
+package jq_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestField(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	u := unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{
+			"name":   "app",
+			"labels": map[string]any{"app": "app", "tier": "web"},
+		},
+		"status": map[string]any{
+			"replicas": int64(3),
+		},
+	}}
+
+	g.Expect(&u).Should(jq.Field(".status.replicas", BeNumerically(">=", 3)))
+	g.Expect(&u).Should(jq.Field(".metadata.labels", HaveKeyWithValue("tier", "web")))
+	g.Expect(&u).Should(jq.Field(".metadata.name", HavePrefix("ap")))
+	g.Expect(&u).Should(jq.Field(".status.missing", BeNil()))
+	g.Expect(`{"items":[1,2,3]}`).Should(jq.Field(".items", HaveLen(3)))
+
+	g.Expect(&u).ShouldNot(jq.Field(".status.replicas", BeNumerically(">", 3)))
+
+	_, err := jq.Field(".status.replicas | error(\"boom\")", BeNil()).Match(&u)
+	g.Expect(err).Should(MatchError(ContainSubstring("unable to extract")))
+
+	_, err = jq.Field(".status[", BeNil()).Match(&u)
+	g.Expect(err).Should(HaveOccurred())
+}
+
+func TestFieldFailureMessage(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	m := jq.Field(".status.replicas", BeNumerically(">=", 5))
+
+	ok, err := m.Match(`{"status":{"replicas":3}}`)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ok).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(And(
+		HavePrefix("Expected field .status.replicas to match:\n"),
+		ContainSubstring("to be >="),
+	))
+}
@@ -0,0 +1,108 @@
+package jq
+
+import (
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/itchyny/gojq"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// builtins returns the compiler options registering the functions available
+// to all expressions:
+//
+//   - quantity, quantity($q): parses a Kubernetes resource quantity (i.e.
+//     "500Mi", "250m") into a number, so that memory and CPU values can be
+//     compared numerically, i.e. quantity("500Mi") <= (.limits.memory | quantity)
+//   - k8stime, k8stime($t): parses an RFC3339 timestamp, as found in object
+//     metadata and conditions, into seconds since the Unix epoch
+//   - ageSeconds, ageSeconds($t): returns the number of seconds elapsed since
+//     an RFC3339 timestamp, i.e. .metadata.creationTimestamp | ageSeconds < 300
+//   - b64d, b64d($s): decodes a base64 encoded string, i.e. Secret data
+func builtins() []gojq.CompilerOption {
+	return []gojq.CompilerOption{
+		gojq.WithFunction("quantity", 0, 1, quantity),
+		gojq.WithFunction("k8stime", 0, 1, k8stime),
+		gojq.WithFunction("ageSeconds", 0, 1, ageSeconds),
+		gojq.WithFunction("b64d", 0, 1, b64d),
+	}
+}
+
+func quantity(in any, args []any) any {
+	if len(args) == 1 {
+		in = args[0]
+	}
+
+	var value string
+
+	switch v := in.(type) {
+	case string:
+		value = v
+	case int, float64:
+		value = fmt.Sprint(v)
+	default:
+		return fmt.Errorf("quantity cannot be applied to %s: %s", gojq.TypeOf(in), toJSON(in))
+	}
+
+	q, err := resource.ParseQuantity(value)
+	if err != nil {
+		return fmt.Errorf("quantity cannot parse %q: %w", value, err)
+	}
+
+	return q.AsApproximateFloat64()
+}
+
+func k8stime(in any, args []any) any {
+	t, err := parseTime("k8stime", in, args)
+	if err != nil {
+		return err
+	}
+
+	return float64(t.UnixNano()) / float64(time.Second)
+}
+
+func ageSeconds(in any, args []any) any {
+	t, err := parseTime("ageSeconds", in, args)
+	if err != nil {
+		return err
+	}
+
+	return time.Since(t).Seconds()
+}
+
+func parseTime(name string, in any, args []any) (time.Time, error) {
+	if len(args) == 1 {
+		in = args[0]
+	}
+
+	value, ok := in.(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("%s cannot be applied to %s: %s", name, gojq.TypeOf(in), toJSON(in))
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s cannot parse %q: %w", name, value, err)
+	}
+
+	return t, nil
+}
+
+func b64d(in any, args []any) any {
+	if len(args) == 1 {
+		in = args[0]
+	}
+
+	value, ok := in.(string)
+	if !ok {
+		return fmt.Errorf("b64d cannot be applied to %s: %s", gojq.TypeOf(in), toJSON(in))
+	}
+
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return fmt.Errorf("b64d cannot decode %q: %w", value, err)
+	}
+
+	return string(data)
+}
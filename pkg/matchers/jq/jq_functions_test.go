@@ -0,0 +1,81 @@
+package jq_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestQuantity(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	in := `{"limits":{"memory":"1Gi","cpu":"1500m"},"requests":{"memory":"536870912","cpu":1}}`
+
+	g.Expect(in).Should(
+		And(
+			jq.Match(`quantity("500Mi") <= quantity(.limits.memory)`),
+			jq.Match(`(.limits.memory | quantity) == quantity("1024Mi")`),
+			jq.Match(`quantity(.requests.memory) == quantity("512Mi")`),
+			jq.Match(`quantity(.limits.cpu) == 1.5`),
+			jq.Match(`quantity(.requests.cpu) == quantity("1000m")`),
+		),
+	)
+
+	g.Expect(in).Should(
+		Not(jq.Match(`quantity(.limits.memory) < quantity("1G")`)),
+	)
+
+	_, err := jq.Match(`quantity("foo") > 0`).Match(in)
+	g.Expect(err).Should(MatchError(ContainSubstring(`quantity cannot parse "foo"`)))
+
+	_, err = jq.Match(`quantity(.limits) > 0`).Match(in)
+	g.Expect(err).Should(MatchError(ContainSubstring(`quantity cannot be applied to object`)))
+}
+
+func TestTime(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	created := time.Now().Add(-2 * time.Minute).UTC().Format(time.RFC3339)
+	in := fmt.Sprintf(`{"metadata":{"creationTimestamp":%q},"status":{"lastTransitionTime":"2024-01-01T00:00:00Z"}}`, created)
+
+	g.Expect(in).Should(
+		And(
+			jq.Match(`.metadata.creationTimestamp | ageSeconds < 300`),
+			jq.Match(`ageSeconds(.metadata.creationTimestamp) >= 110`),
+			jq.Match(`(.status.lastTransitionTime | k8stime) == 1704067200`),
+			jq.Match(`k8stime(.status.lastTransitionTime) < k8stime(.metadata.creationTimestamp)`),
+		),
+	)
+
+	_, err := jq.Match(`.metadata | ageSeconds > 0`).Match(in)
+	g.Expect(err).Should(MatchError(ContainSubstring(`ageSeconds cannot be applied to object`)))
+
+	_, err = jq.Match(`"yesterday" | k8stime > 0`).Match(in)
+	g.Expect(err).Should(MatchError(ContainSubstring(`k8stime cannot parse "yesterday"`)))
+}
+
+func TestB64d(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	in := `{"kind":"Secret","data":{"password":"czNjcjN0"}}`
+
+	g.Expect(in).Should(
+		And(
+			jq.Match(`.data.password | b64d == "s3cr3t"`),
+			jq.Match(`b64d(.data.password) | startswith("s3")`),
+		),
+	)
+
+	_, err := jq.Match(`.data.password | b64d | b64d == ""`).Match(in)
+	g.Expect(err).Should(MatchError(ContainSubstring(`b64d cannot decode "s3cr3t"`)))
+}
@@ -9,33 +9,50 @@ import (
 )
 
 func Match(format string, args ...any) types.GomegaMatcher {
-	return &jqMatcher{
-		Expression: fmt.Sprintf(format, args...),
-	}
+	return std.Match(format, args...)
 }
 
 var _ types.GomegaMatcher = &jqMatcher{}
 
 type jqMatcher struct {
 	Expression       string
+	config           *Matcher
+	code             *gojq.Code
+	data             any
 	firstFailurePath []interface{}
 }
 
 func (matcher *jqMatcher) Match(actual interface{}) (bool, error) {
-	query, err := gojq.Parse(matcher.Expression)
-	if err != nil {
-		return false, fmt.Errorf("unable to parse expression %s, %w", matcher.Expression, err)
+	// the matcher is evaluated repeatedly by Eventually/Consistently, hence
+	// the expression is compiled once
+	if matcher.code == nil {
+		code, err := matcher.config.compile(matcher.Expression)
+		if err != nil {
+			return false, err
+		}
+
+		matcher.code = code
 	}
 
-	data, err := toType(actual)
+	// the state of a previous evaluation must not leak into the failure
+	// messages when the actual cannot be converted
+	matcher.data = nil
+
+	data, err := matcher.config.toType(actual)
 	if err != nil {
 		return false, err
 	}
 
-	it := query.Run(data)
+	matcher.data = data
+
+	it := matcher.code.Run(data)
 
 	v, ok := it.Next()
 	if !ok {
+		if matcher.config.strict {
+			return false, fmt.Errorf("expression %s did not produce any result, a boolean is required", matcher.Expression)
+		}
+
 		return false, nil
 	}
 
@@ -47,13 +64,70 @@ func (matcher *jqMatcher) Match(actual interface{}) (bool, error) {
 		return match, nil
 	}
 
+	if matcher.config.strict {
+		return false, fmt.Errorf("expression %s evaluated to %s %s, a boolean is required", matcher.Expression, gojq.TypeOf(v), toJSON(v))
+	}
+
 	return false, nil
 }
 
 func (matcher *jqMatcher) FailureMessage(actual interface{}) string {
-	return formattedMessage(format.Message(fmt.Sprintf("%v", actual), "to match expression", matcher.Expression), matcher.firstFailurePath)
+	return formattedMessage(format.Message(matcher.config.render(actual, matcher.data), "to match expression", matcher.Expression), matcher.firstFailurePath) + matcher.outcome()
 }
 
 func (matcher *jqMatcher) NegatedFailureMessage(actual interface{}) string {
-	return formattedMessage(format.Message(fmt.Sprintf("%v", actual), "not to match expression", matcher.Expression), matcher.firstFailurePath)
+	return formattedMessage(format.Message(matcher.config.render(actual, matcher.data), "not to match expression", matcher.Expression), matcher.firstFailurePath) + matcher.outcome()
+}
+
+// outcome describes what the expression produced on the last evaluated
+// input, so that an expression selecting nothing, i.e. .items[] | select(..),
+// can be told apart from one evaluating to false. The expression is run again
+// only when a failure message is requested, hence Match only ever consumes the
+// first result.
+func (matcher *jqMatcher) outcome() string {
+	if matcher.code == nil {
+		return ""
+	}
+
+	var first any
+
+	count := 0
+	it := matcher.code.Run(matcher.data)
+
+	for {
+		v, ok := it.Next()
+		if !ok {
+			break
+		}
+
+		if _, ok := v.(error); ok {
+			break
+		}
+
+		if count == 0 {
+			first = v
+		}
+
+		count++
+	}
+
+	switch count {
+	case 0:
+		return "\n\nexpression produced no output"
+	case 1:
+		return "\n\nexpression produced " + describeResult(first)
+	default:
+		return fmt.Sprintf("\n\nexpression produced %d results, the first being %s", count, describeResult(first))
+	}
+}
+
+// describeResult renders booleans and null as they are and other values by
+// their type only, as they are not subject to redaction.
+func describeResult(v any) string {
+	switch v.(type) {
+	case bool, nil:
+		return toJSON(v)
+	default:
+		return "a non boolean " + gojq.TypeOf(v)
+	}
 }
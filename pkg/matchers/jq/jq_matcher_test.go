@@ -87,3 +87,31 @@ func TestMatcherWithType(t *testing.T) {
 			WithTransform(json.Marshal, jq.Match(`.a == 1`)),
 		)
 }
+
+func TestMatcherOutcome(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	in := `{"items":[{"name":"a"},{"name":"b"}]}`
+
+	m := jq.Match(`.items[] | select(.name == "c") | .name == "c"`)
+	g.Expect(m.Match(in)).Should(BeFalse())
+	g.Expect(m.FailureMessage(in)).Should(HaveSuffix("expression produced no output"))
+
+	m = jq.Match(`.items[0].name == "c"`)
+	g.Expect(m.Match(in)).Should(BeFalse())
+	g.Expect(m.FailureMessage(in)).Should(HaveSuffix("expression produced false"))
+
+	m = jq.Match(`.items[] | .name == "b"`)
+	g.Expect(m.Match(in)).Should(BeFalse())
+	g.Expect(m.FailureMessage(in)).Should(HaveSuffix("expression produced 2 results, the first being false"))
+
+	m = jq.Match(`.items[0].name`)
+	g.Expect(m.Match(in)).Should(BeFalse())
+	g.Expect(m.FailureMessage(in)).Should(HaveSuffix("expression produced a non boolean string"))
+
+	m = jq.Match(`.items[] | .name == "a"`)
+	g.Expect(m.Match(in)).Should(BeTrue())
+	g.Expect(m.NegatedFailureMessage(in)).Should(HaveSuffix("expression produced 2 results, the first being true"))
+}
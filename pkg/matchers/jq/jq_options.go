@@ -0,0 +1,170 @@
+package jq
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/itchyny/gojq"
+	"github.com/onsi/gomega/types"
+)
+
+// Option configures a Matcher.
+type Option func(*Matcher)
+
+// Strict makes Match fail with an error, rather than silently not matching,
+// when the expression does not evaluate to a boolean, i.e. to catch mistakes
+// such as jq.Match(`.status.phase`).
+func Strict() Option {
+	return func(m *Matcher) {
+		m.strict = true
+	}
+}
+
+// WithFunctions makes the given jq function definitions, i.e.
+// `def ready: .status.conditions[] | select(.type == "Ready") | .status == "True";`
+// available to all the expressions evaluated by the Matcher.
+func WithFunctions(defs ...string) Option {
+	return func(m *Matcher) {
+		m.functions = append(m.functions, defs...)
+	}
+}
+
+// WithModuleLoader sets the loader used to resolve import and include
+// directives, i.e. gojq.NewModuleLoader(paths) to load jq modules from disk.
+func WithModuleLoader(loader gojq.ModuleLoader) Option {
+	return func(m *Matcher) {
+		m.compilerOptions = append(m.compilerOptions, gojq.WithModuleLoader(loader))
+	}
+}
+
+// WithCompilerOptions passes the given options, i.e. gojq.WithFunction to
+// register functions implemented in Go, to the jq compiler.
+func WithCompilerOptions(opts ...gojq.CompilerOption) Option {
+	return func(m *Matcher) {
+		m.compilerOptions = append(m.compilerOptions, opts...)
+	}
+}
+
+// Converter converts values of the types it knows about, i.e. a custom
+// document type, to a form jq expressions can be evaluated against. It
+// returns false if the value is not handled.
+type Converter func(in any) (any, bool, error)
+
+// WithConverters registers converters that are tried in order, before the
+// built-in conversions, on the actual values of Match and on the inputs of
+// Extract.
+func WithConverters(converters ...Converter) Option {
+	return func(m *Matcher) {
+		m.converters = append(m.converters, converters...)
+	}
+}
+
+// Matcher creates jq matchers and transforms sharing the same configuration,
+// so that options do not need to be repeated for every expression in a suite.
+// The package level Match and Extract functions use the default
+// configuration.
+type Matcher struct {
+	strict          bool
+	functions       []string
+	compilerOptions []gojq.CompilerOption
+	converters      []Converter
+	maxOutputLength *int
+	redact          *[]*gojq.Code
+	codes           *codeCache
+}
+
+// std is the Matcher used by the package level functions, sharing it lets
+// them reuse compiled expressions.
+//
+//nolint:gochecknoglobals
+var std = New()
+
+// New creates a Matcher with the given options.
+func New(opts ...Option) *Matcher {
+	m := Matcher{
+		codes: newCodeCache(),
+	}
+
+	for _, opt := range opts {
+		opt(&m)
+	}
+
+	return &m
+}
+
+// Match succeeds if the expression evaluates to true against the actual
+// value.
+func (m *Matcher) Match(format string, args ...any) types.GomegaMatcher {
+	return &jqMatcher{
+		Expression: fmt.Sprintf(format, args...),
+		config:     m,
+	}
+}
+
+// Extract returns a transform evaluating the expression against its input.
+func (m *Matcher) Extract(expression string) func(in any) (any, error) {
+	return extract(m, expression)
+}
+
+// Patch returns a transform applying the mutating program to its input.
+func (m *Matcher) Patch(expression string) func(in any) (any, error) {
+	return patch(m, expression)
+}
+
+// compile compiles the given expression, reusing the code compiled for the
+// same expression by the Matcher, if any, since matchers are often created
+// anew on every poll, i.e. within the function given to Eventually.
+func (m *Matcher) compile(expression string) (*gojq.Code, error) {
+	if code, ok := m.codes.get(expression); ok {
+		return code, nil
+	}
+
+	code, err := m.compileExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	m.codes.put(expression, code)
+
+	return code, nil
+}
+
+func (m *Matcher) compileExpression(expression string) (*gojq.Code, error) {
+	query, err := gojq.Parse(expression)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse expression %s, %w", expression, err)
+	}
+
+	if len(m.functions) > 0 {
+		// function definitions are parsed on their own, so that errors in
+		// the expression are reported at the right position
+		defs, err := gojq.Parse(strings.Join(m.functions, "\n") + " .")
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse function definitions, %w", err)
+		}
+
+		query.FuncDefs = append(defs.FuncDefs, query.FuncDefs...)
+	}
+
+	code, err := gojq.Compile(query, append(builtins(), m.compilerOptions...)...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compile expression %s, %w", expression, err)
+	}
+
+	return code, nil
+}
+
+func (m *Matcher) toType(in any) (any, error) {
+	for _, c := range m.converters {
+		out, ok, err := c(in)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			return out, nil
+		}
+	}
+
+	return toType(in)
+}
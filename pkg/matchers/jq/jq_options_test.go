@@ -0,0 +1,136 @@
+package jq_test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/itchyny/gojq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestStrict(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	// by default, non boolean results do not match
+	g.Expect(`{"status":{"phase":"Running"}}`).Should(
+		Not(jq.Match(`.status.phase`)),
+	)
+
+	j := jq.New(jq.Strict())
+
+	g.Expect(`{"status":{"phase":"Running"}}`).Should(
+		j.Match(`.status.phase == "Running"`),
+	)
+
+	g.Expect(`{"status":{"phase":"Running"}}`).Should(
+		Not(j.Match(`.status.phase == "Pending"`)),
+	)
+
+	_, err := j.Match(`.status.phase`).Match(`{"status":{"phase":"Running"}}`)
+	g.Expect(err).Should(MatchError(`expression .status.phase evaluated to string "Running", a boolean is required`))
+
+	_, err = j.Match(`.status.replicas`).Match(`{"status":{"replicas":3}}`)
+	g.Expect(err).Should(MatchError(`expression .status.replicas evaluated to number 3, a boolean is required`))
+
+	_, err = j.Match(`.items[]`).Match(`{"items":[]}`)
+	g.Expect(err).Should(MatchError(`expression .items[] did not produce any result, a boolean is required`))
+}
+
+func TestWithFunctions(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	j := jq.New(
+		jq.WithFunctions(
+			`def condition($type): .status.conditions[] | select(.type == $type);`,
+			`def ready: condition("Ready") | .status == "True";`,
+		),
+		jq.WithCompilerOptions(
+			gojq.WithFunction("upper", 0, 0, func(in any, _ []any) any {
+				if s, ok := in.(string); ok {
+					return strings.ToUpper(s)
+				}
+
+				return in
+			}),
+		),
+	)
+
+	in := `{"status":{"phase":"running","conditions":[{"type":"Ready","status":"True"}]}}`
+
+	g.Expect(in).Should(j.Match(`ready`))
+	g.Expect(in).Should(j.Match(`condition("Ready") | .status == "True"`))
+	g.Expect(in).Should(j.Match(`.status.phase | upper == "RUNNING"`))
+	g.Expect(in).Should(WithTransform(j.Extract(`condition("Ready") | .type`), Equal("Ready")))
+
+	g.Expect(j.Validate(`ready and (.status.phase | upper) == "RUNNING"`)).Should(Succeed())
+	g.Expect(jq.Validate(`ready`)).ShouldNot(Succeed())
+
+	_, err := jq.New(jq.WithFunctions(`def broken: `)).Match(`.a`).Match(in)
+	g.Expect(err).Should(MatchError(ContainSubstring("unable to parse function definitions")))
+}
+
+type document struct {
+	Name string
+}
+
+func TestWithConverters(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	m := jq.New(jq.WithConverters(func(in any) (any, bool, error) {
+		d, ok := in.(document)
+		if !ok {
+			return nil, false, nil
+		}
+
+		return map[string]any{"name": d.Name}, true, nil
+	}))
+
+	g.Expect(document{Name: "foo"}).Should(m.Match(`.name == "foo"`))
+	g.Expect(`{"name":"bar"}`).Should(m.Match(`.name == "bar"`))
+	g.Expect(m.Extract(`.name`)(document{Name: "foo"})).Should(Equal("foo"))
+
+	_, err := jq.Match(`.name == "foo"`).Match(document{Name: "foo"})
+	g.Expect(err).Should(HaveOccurred())
+}
+
+func TestConcurrentMatch(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	m := jq.New()
+
+	var wg sync.WaitGroup
+
+	results := make([]bool, 16)
+	errs := make([]error, 16)
+
+	for i := range results {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			// matchers for the same expression share the compiled code
+			in := fmt.Sprintf(`{"a":%d}`, i%2)
+			results[i], errs[i] = m.Match(`.a == 1`).Match(in)
+		}()
+	}
+
+	wg.Wait()
+
+	for i := range results {
+		g.Expect(errs[i]).ShouldNot(HaveOccurred())
+		g.Expect(results[i]).Should(Equal(i%2 == 1))
+	}
+}
@@ -0,0 +1,194 @@
+package jq
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/itchyny/gojq"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+// ContainElementsInOrder succeeds if the actual array contains the given
+// elements in the same relative order, other elements being allowed in
+// between, i.e. to assert initContainers, args or env ordering:
+//
+//	Expect(pod).Should(jq.Field(".spec.initContainers", jq.ContainElementsInOrder(
+//	    jq.Match(`.name == "migrate"`),
+//	    jq.Match(`.name == "seed"`),
+//	)))
+//
+//	Expect(pod).Should(jq.Field(".spec.containers[0].args", jq.ContainElementsInOrder("--config", "/etc/app.yaml")))
+//
+// Elements are Gomega matchers or values compared for equality once converted
+// to JSON types, so that i.e. 3 and int64(3) are equal.
+func ContainElementsInOrder(elements ...any) types.GomegaMatcher {
+	return std.ContainElementsInOrder(elements...)
+}
+
+// ContainElementsInOrder succeeds if the actual array contains the given
+// elements in the same relative order.
+func (m *Matcher) ContainElementsInOrder(elements ...any) types.GomegaMatcher {
+	return &inOrderMatcher{
+		Elements: elements,
+		config:   m,
+	}
+}
+
+var _ types.GomegaMatcher = &inOrderMatcher{}
+
+type inOrderMatcher struct {
+	Elements []any
+	config   *Matcher
+	data     any
+	missing  int
+	after    int
+	at       []int
+}
+
+func (matcher *inOrderMatcher) Match(actual interface{}) (bool, error) {
+	matcher.data = nil
+
+	data, err := matcher.config.toType(actual)
+	if err != nil {
+		return false, err
+	}
+
+	data, err = normalize(data)
+	if err != nil {
+		return false, err
+	}
+
+	items, ok := data.([]any)
+	if !ok {
+		return false, fmt.Errorf("an array is required, got %s", gojq.TypeOf(data))
+	}
+
+	matcher.data = data
+	matcher.missing = -1
+	matcher.after = -1
+	matcher.at = make([]int, 0, len(matcher.Elements))
+
+	next := 0
+
+	for i, e := range matcher.Elements {
+		pos, err := indexOf(items, next, e)
+		if err != nil {
+			return false, err
+		}
+
+		if pos == -1 {
+			matcher.missing = i
+			matcher.after = next - 1
+
+			return false, nil
+		}
+
+		matcher.at = append(matcher.at, pos)
+		next = pos + 1
+	}
+
+	return true, nil
+}
+
+func (matcher *inOrderMatcher) FailureMessage(actual interface{}) string {
+	msg := format.Message(matcher.config.render(actual, matcher.data), "to contain elements in order", matcher.describe())
+
+	if matcher.missing == -1 {
+		return msg
+	}
+
+	msg += fmt.Sprintf("\n\nelement #%d %s", matcher.missing, describeElement(matcher.Elements[matcher.missing]))
+
+	if matcher.after == -1 {
+		return msg + " not found"
+	}
+
+	msg += fmt.Sprintf(" not found after index %d", matcher.after)
+
+	if items, ok := matcher.data.([]any); ok {
+		// the element is there, but before the previous ones
+		if pos, err := indexOf(items, 0, matcher.Elements[matcher.missing]); err == nil && pos != -1 {
+			msg += fmt.Sprintf(", it is found at index %d", pos)
+		}
+	}
+
+	return msg
+}
+
+func (matcher *inOrderMatcher) NegatedFailureMessage(actual interface{}) string {
+	positions := make([]string, len(matcher.at))
+	for i, p := range matcher.at {
+		positions[i] = fmt.Sprint(p)
+	}
+
+	return format.Message(matcher.config.render(actual, matcher.data), "not to contain elements in order", matcher.describe()) +
+		"\n\nelements found at indexes " + strings.Join(positions, ", ")
+}
+
+func (matcher *inOrderMatcher) describe() string {
+	elements := make([]string, len(matcher.Elements))
+	for i, e := range matcher.Elements {
+		elements[i] = describeElement(e)
+	}
+
+	return strings.Join(elements, ", ")
+}
+
+// indexOf returns the index of the first item matching the given element,
+// starting from the given index, or -1 if there is none. Matchers failing on
+// an item, i.e. jq.Match on a string, are considered not to match it.
+func indexOf(items []any, from int, element any) (int, error) {
+	matcher, ok := element.(types.GomegaMatcher)
+	if !ok {
+		v, err := toJSONValue(element)
+		if err != nil {
+			return -1, err
+		}
+
+		for i := from; i < len(items); i++ {
+			if equalJSON(items[i], v) {
+				return i, nil
+			}
+		}
+
+		return -1, nil
+	}
+
+	for i := from; i < len(items); i++ {
+		if match, err := matcher.Match(items[i]); err == nil && match {
+			return i, nil
+		}
+	}
+
+	return -1, nil
+}
+
+// toJSONValue round-trips the given value through JSON, like normalize, but
+// accepting scalars as well.
+func toJSONValue(in any) (any, error) {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal element, %w", err)
+	}
+
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal element, %w", err)
+	}
+
+	return v, nil
+}
+
+func equalJSON(a any, b any) bool {
+	return gojq.Compare(a, b) == 0
+}
+
+func describeElement(e any) string {
+	if _, ok := e.(types.GomegaMatcher); ok {
+		return fmt.Sprintf("<%T>", e)
+	}
+
+	return toJSON(e)
+}
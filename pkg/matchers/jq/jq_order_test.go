@@ -0,0 +1,80 @@
+package jq_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+
+	. "github.com/onsi/gomega"
+)
+
+const orderDocument = `{
+  "spec": {
+    "initContainers": [{"name": "wait"}, {"name": "migrate"}, {"name": "seed"}],
+    "containers": [{"name": "app", "args": ["--verbose", "--config", "/etc/app.yaml", "--port", 8080]}]
+  }
+}`
+
+func TestContainElementsInOrder(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	g.Expect(orderDocument).Should(jq.Field(".spec.initContainers", jq.ContainElementsInOrder(
+		jq.Match(`.name == "migrate"`),
+		jq.Match(`.name == "seed"`),
+	)))
+	g.Expect(orderDocument).Should(jq.Field(".spec.initContainers", jq.ContainElementsInOrder(
+		map[string]any{"name": "wait"},
+		jq.ContainSubset(`{"name":"seed"}`),
+	)))
+	g.Expect(orderDocument).Should(jq.Field(".spec.containers[0].args", jq.ContainElementsInOrder("--config", "/etc/app.yaml")))
+	g.Expect(orderDocument).Should(jq.Field(".spec.containers[0].args", jq.ContainElementsInOrder("--verbose", HavePrefix("--p"), int64(8080))))
+	g.Expect([]string{"a", "b", "c"}).Should(jq.ContainElementsInOrder("a", "c"))
+	g.Expect([]string{"a", "b", "c"}).Should(jq.ContainElementsInOrder())
+
+	g.Expect(orderDocument).ShouldNot(jq.Field(".spec.initContainers", jq.ContainElementsInOrder(
+		jq.Match(`.name == "seed"`),
+		jq.Match(`.name == "migrate"`),
+	)))
+	g.Expect([]string{"a", "b", "c"}).ShouldNot(jq.ContainElementsInOrder("a", "d"))
+	g.Expect([]string{"a", "b", "a"}).Should(jq.ContainElementsInOrder("b", "a"))
+
+	_, err := jq.ContainElementsInOrder("a").Match(`{"a":1}`)
+	g.Expect(err).Should(MatchError("an array is required, got object"))
+}
+
+func TestContainElementsInOrderFailureMessage(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	in := []string{"--verbose", "--config", "/etc/app.yaml"}
+
+	m := jq.ContainElementsInOrder("/etc/app.yaml", "--config")
+
+	ok, err := m.Match(in)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ok).Should(BeFalse())
+	g.Expect(m.FailureMessage(in)).Should(And(
+		ContainSubstring(`to contain elements in order`),
+		ContainSubstring(`element #1 "--config" not found after index 2, it is found at index 1`),
+	))
+
+	m = jq.ContainElementsInOrder("--debug")
+
+	ok, err = m.Match(in)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ok).Should(BeFalse())
+	g.Expect(m.FailureMessage(in)).Should(ContainSubstring(`element #0 "--debug" not found`))
+
+	m = jq.ContainElementsInOrder("--verbose", HaveSuffix(".yaml"))
+
+	ok, err = m.Match(in)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ok).Should(BeTrue())
+	g.Expect(m.NegatedFailureMessage(in)).Should(And(
+		ContainSubstring(`"--verbose", <*matchers.HaveSuffixMatcher>`),
+		ContainSubstring("elements found at indexes 0, 2"),
+	))
+}
@@ -0,0 +1,146 @@
+package jq
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/itchyny/gojq"
+)
+
+const redacted = "[REDACTED]"
+
+//nolint:gochecknoglobals
+var output = struct {
+	lock      sync.RWMutex
+	maxLength int
+	redact    []*gojq.Code
+}{}
+
+// SetMaxOutputLength bounds the length of the actual value rendered in the
+// failure messages of Match, longer values are truncated. Zero, the default,
+// means no limit.
+func SetMaxOutputLength(n int) {
+	output.lock.Lock()
+	defer output.lock.Unlock()
+
+	output.maxLength = n
+}
+
+// RedactPaths replaces the values found at the given paths, i.e. ".data" or
+// ".data[]?" to keep keys visible, with a placeholder in the actual value
+// rendered in the failure messages of Match, so that Secrets do not leak into
+// CI logs. Calling RedactPaths again replaces the paths set previously,
+// calling it with no paths disables redaction. It panics if a path is not a
+// valid jq path expression.
+func RedactPaths(paths ...string) {
+	codes := compileRedactions(paths)
+
+	output.lock.Lock()
+	defer output.lock.Unlock()
+
+	output.redact = codes
+}
+
+// WithMaxOutputLength is like SetMaxOutputLength, but only applies to the
+// matchers created by the Matcher, overriding the process wide setting.
+func WithMaxOutputLength(n int) Option {
+	return func(m *Matcher) {
+		m.maxOutputLength = &n
+	}
+}
+
+// WithRedactPaths is like RedactPaths, but only applies to the matchers
+// created by the Matcher, overriding the process wide setting.
+func WithRedactPaths(paths ...string) Option {
+	codes := compileRedactions(paths)
+
+	return func(m *Matcher) {
+		m.redact = &codes
+	}
+}
+
+func compileRedactions(paths []string) []*gojq.Code {
+	codes := make([]*gojq.Code, 0, len(paths))
+
+	for _, p := range paths {
+		expression := fmt.Sprintf(`reduce path(%s) as $p (.; if getpath($p) == null then . else setpath($p; %q) end)`, p, redacted)
+
+		query, err := gojq.Parse(expression)
+		if err != nil {
+			panic(fmt.Errorf("invalid redaction path %s: %w", p, err))
+		}
+
+		code, err := gojq.Compile(query)
+		if err != nil {
+			panic(fmt.Errorf("invalid redaction path %s: %w", p, err))
+		}
+
+		codes = append(codes, code)
+	}
+
+	return codes
+}
+
+// render returns the representation of the actual value to be used in
+// failure messages, with the configured redactions and length limit
+// applied. data is the actual value as converted by toType, if available.
+func (m *Matcher) render(actual any, data any) string {
+	output.lock.RLock()
+	maxLength := output.maxLength
+	output.lock.RUnlock()
+
+	if m.maxOutputLength != nil {
+		maxLength = *m.maxOutputLength
+	}
+
+	redact := m.redactions()
+
+	result := fmt.Sprintf("%v", actual)
+
+	if len(redact) > 0 {
+		if data == nil {
+			// the actual could not be converted, hence it cannot be
+			// redacted either and must not be shown
+			result = redacted
+		} else {
+			result = toJSON(redactData(data, redact))
+		}
+	}
+
+	if maxLength > 0 && len(result) > maxLength {
+		result = fmt.Sprintf("%s... (%d more bytes truncated)", result[:maxLength], len(result)-maxLength)
+	}
+
+	return result
+}
+
+// redactions returns the redactions applying to the matchers created by m.
+func (m *Matcher) redactions() []*gojq.Code {
+	if m.redact != nil {
+		return *m.redact
+	}
+
+	output.lock.RLock()
+	defer output.lock.RUnlock()
+
+	return output.redact
+}
+
+func redactData(data any, codes []*gojq.Code) any {
+	for _, code := range codes {
+		v, ok := code.Run(data).Next()
+		if !ok {
+			continue
+		}
+
+		// i.e. a path that does not apply to the shape of data, in which
+		// case nothing is shown rather than risking a leak
+		if _, ok := v.(error); ok {
+			return redacted
+		}
+
+		data = v
+	}
+
+	return data
+}
@@ -0,0 +1,108 @@
+package jq_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+
+	. "github.com/onsi/gomega"
+)
+
+//nolint:paralleltest
+func TestRedactPaths(t *testing.T) {
+	// output settings are global, hence this test must not run in parallel
+	// with the others
+	jq.RedactPaths(".data[]?", ".stringData")
+	defer jq.RedactPaths()
+
+	g := NewWithT(t)
+
+	in := `{"kind":"Secret","data":{"password":"czNjcjN0"},"stringData":{"user":"admin"}}`
+
+	m := jq.Match(`.data.password == "foo"`)
+	g.Expect(m.Match(in)).Should(BeFalse())
+
+	msg := m.FailureMessage(in)
+	g.Expect(msg).Should(ContainSubstring(`"password":"[REDACTED]"`))
+	g.Expect(msg).Should(ContainSubstring(`"stringData":"[REDACTED]"`))
+	g.Expect(msg).ShouldNot(ContainSubstring("czNjcjN0"))
+	g.Expect(msg).ShouldNot(ContainSubstring("admin"))
+
+	// missing paths are left alone
+	m = jq.Match(`.kind == "ConfigMap"`)
+	g.Expect(m.Match(`{"kind":"Secret"}`)).Should(BeFalse())
+	g.Expect(m.NegatedFailureMessage(`{"kind":"Secret"}`)).Should(ContainSubstring(`{"kind":"Secret"}`))
+
+	// paths not applying to the actual redact it entirely
+	m = jq.Match(`length == 0`)
+	g.Expect(m.Match(`["czNjcjN0"]`)).Should(BeFalse())
+	g.Expect(m.FailureMessage(`["czNjcjN0"]`)).ShouldNot(ContainSubstring("czNjcjN0"))
+
+	g.Expect(func() { jq.RedactPaths(".data[") }).Should(Panic())
+}
+
+//nolint:paralleltest
+func TestSetMaxOutputLength(t *testing.T) {
+	// output settings are global, hence this test must not run in parallel
+	// with the others
+	jq.SetMaxOutputLength(10)
+	defer jq.SetMaxOutputLength(0)
+
+	g := NewWithT(t)
+
+	in := `{"a":"0123456789abcdef"}`
+
+	m := jq.Match(`.a == ""`)
+	g.Expect(m.Match(in)).Should(BeFalse())
+	g.Expect(m.FailureMessage(in)).Should(ContainSubstring(`{"a":"0123... (14 more bytes truncated)`))
+}
+
+func TestOutputOptions(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	in := `{"kind":"Secret","data":{"password":"czNjcjN0"}}`
+
+	m := jq.New(jq.WithRedactPaths(".data[]?")).Match(`.kind == "ConfigMap"`)
+	g.Expect(m.Match(in)).Should(BeFalse())
+	g.Expect(m.FailureMessage(in)).Should(And(
+		ContainSubstring(`"password":"[REDACTED]"`),
+		Not(ContainSubstring("czNjcjN0")),
+	))
+
+	m = jq.New(jq.WithMaxOutputLength(10)).Match(`.kind == "ConfigMap"`)
+	g.Expect(m.Match(in)).Should(BeFalse())
+	g.Expect(m.FailureMessage(in)).Should(ContainSubstring(`{"kind":"S... (38 more bytes truncated)`))
+
+	m = jq.Match(`.kind == "ConfigMap"`)
+	g.Expect(m.Match(in)).Should(BeFalse())
+	g.Expect(m.FailureMessage(in)).Should(ContainSubstring("czNjcjN0"))
+
+	g.Expect(func() { jq.WithRedactPaths(".data[") }).Should(Panic())
+}
+
+func TestOutputReset(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	// a matcher is evaluated repeatedly by Eventually, the failure message
+	// must describe the last actual rather than a previous one
+	m := jq.New(jq.WithRedactPaths(".data[]?")).Match(`.kind == "ConfigMap"`)
+	g.Expect(m.Match(`{"kind":"Secret","data":{"password":"czNjcjN0"}}`)).Should(BeFalse())
+
+	_, err := m.Match(`not json`)
+	g.Expect(err).Should(HaveOccurred())
+	g.Expect(m.FailureMessage(`not json`)).Should(And(
+		ContainSubstring("[REDACTED]"),
+		Not(ContainSubstring("Secret")),
+	))
+
+	s := jq.New(jq.WithRedactPaths(".data[]?")).ContainSubset(`{"kind":"ConfigMap"}`)
+	g.Expect(s.Match(`{"kind":"Secret","data":{"password":"czNjcjN0"}}`)).Should(BeFalse())
+
+	_, err = s.Match(`not json`)
+	g.Expect(err).Should(HaveOccurred())
+	g.Expect(s.FailureMessage(`not json`)).ShouldNot(ContainSubstring("Secret"))
+}
@@ -0,0 +1,92 @@
+package jq
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PipelineBuilder builds a jq program out of stages, so that complex
+// extractions read as a chain of steps rather than as a single expression
+// assembled by string concatenation:
+//
+//	ready := jq.Pipeline().
+//	    Extract(`.status`).
+//	    Select(`.conditions[]`).
+//	    Where(`.type == "Ready"`).
+//	    First()
+//
+//	Expect(obj).Should(WithTransform(ready, jq.Match(`.status == "True"`)))
+//
+// The stages are compiled to a single program, i.e.
+// first(.status | .conditions[] | select(.type == "Ready")).
+type PipelineBuilder struct {
+	matcher *Matcher
+	stages  []string
+}
+
+// Pipeline creates a PipelineBuilder using the default configuration.
+func Pipeline() *PipelineBuilder {
+	return std.Pipeline()
+}
+
+// Pipeline creates a PipelineBuilder using the configuration of the
+// Matcher.
+func (m *Matcher) Pipeline() *PipelineBuilder {
+	return &PipelineBuilder{
+		matcher: m,
+	}
+}
+
+// Extract pipes the current values into the given expression.
+func (p *PipelineBuilder) Extract(expression string) *PipelineBuilder {
+	return p.with("(" + expression + ")")
+}
+
+// Select streams the values produced by the given expression, i.e.
+// `.conditions[]`, so that the following stages apply to each of them.
+func (p *PipelineBuilder) Select(expression string) *PipelineBuilder {
+	return p.with("(" + expression + ")")
+}
+
+// Where keeps the current values for which the given condition is true.
+func (p *PipelineBuilder) Where(condition string) *PipelineBuilder {
+	return p.with("select(" + condition + ")")
+}
+
+// First returns a transform producing the first value of the pipeline.
+func (p *PipelineBuilder) First() func(in any) (any, error) {
+	return extract(p.matcher, fmt.Sprintf("first(%s)", p.String()))
+}
+
+// Last returns a transform producing the last value of the pipeline.
+func (p *PipelineBuilder) Last() func(in any) (any, error) {
+	return extract(p.matcher, fmt.Sprintf("last(%s)", p.String()))
+}
+
+// All returns a transform producing an array holding all the values of the
+// pipeline.
+func (p *PipelineBuilder) All() func(in any) (any, error) {
+	return extract(p.matcher, fmt.Sprintf("[%s]", p.String()))
+}
+
+// Count returns a transform producing the number of values of the pipeline.
+func (p *PipelineBuilder) Count() func(in any) (any, error) {
+	return extract(p.matcher, fmt.Sprintf("[%s] | length", p.String()))
+}
+
+// String returns the program the stages compile to, before any of the
+// terminal operations is applied.
+func (p *PipelineBuilder) String() string {
+	if len(p.stages) == 0 {
+		return "."
+	}
+
+	return strings.Join(p.stages, " | ")
+}
+
+func (p *PipelineBuilder) with(stage string) *PipelineBuilder {
+	return &PipelineBuilder{
+		matcher: p.matcher,
+		stages:  append(p.stages[:len(p.stages):len(p.stages)], stage),
+	}
+}
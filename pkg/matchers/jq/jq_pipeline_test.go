@@ -0,0 +1,50 @@
+package jq_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+
+	. "github.com/onsi/gomega"
+)
+
+const conditions = `{
+  "status": {
+    "conditions": [
+      {"type": "Available", "status": "True"},
+      {"type": "Ready", "status": "False", "reason": "Pending"},
+      {"type": "Ready", "status": "True"}
+    ]
+  }
+}`
+
+func TestPipeline(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	ready := jq.Pipeline().
+		Extract(`.status`).
+		Select(`.conditions[]`).
+		Where(`.type == "Ready"`)
+
+	g.Expect(ready.String()).Should(Equal(`(.status) | (.conditions[]) | select(.type == "Ready")`))
+
+	g.Expect(conditions).Should(WithTransform(ready.First(), jq.Match(`.reason == "Pending"`)))
+	g.Expect(conditions).Should(WithTransform(ready.Last(), jq.Match(`.status == "True"`)))
+	g.Expect(conditions).Should(WithTransform(ready.All(), HaveLen(2)))
+	g.Expect(conditions).Should(WithTransform(ready.Count(), Equal(2)))
+
+	// stages do not alter the pipeline they are appended to
+	available := ready.Where(`.status == "True"`)
+	g.Expect(conditions).Should(WithTransform(available.Count(), Equal(1)))
+	g.Expect(conditions).Should(WithTransform(ready.Count(), Equal(2)))
+
+	g.Expect(conditions).Should(WithTransform(jq.Pipeline().Count(), Equal(1)))
+
+	custom := jq.New(jq.WithFunctions(`def ready: .type == "Ready";`))
+	g.Expect(conditions).Should(WithTransform(custom.Pipeline().Select(`.status.conditions[]`).Where(`ready`).Count(), Equal(2)))
+
+	_, err := jq.Pipeline().Where(`.type ==`).First()(conditions)
+	g.Expect(err).Should(HaveOccurred())
+}
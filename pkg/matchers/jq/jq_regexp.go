@@ -0,0 +1,67 @@
+package jq
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/itchyny/gojq"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+// MatchRegexp succeeds if the string found at the given path matches the
+// given Go regular expression, i.e.:
+//
+//	Expect(pod).Should(jq.MatchRegexp(".spec.containers[0].image", `^registry\.example\.com/.+@sha256:`))
+//
+// It is meant to be used instead of the jq test function, whose Oniguruma
+// syntax differs from the RE2 one of Go in subtle ways. Paths not producing a
+// string, as well as invalid expressions, are reported as errors.
+func MatchRegexp(path string, pattern string) types.GomegaMatcher {
+	return std.MatchRegexp(path, pattern)
+}
+
+// MatchRegexp succeeds if the string found at the given path matches the
+// given Go regular expression.
+func (m *Matcher) MatchRegexp(path string, pattern string) types.GomegaMatcher {
+	return m.Field(path, &regexpMatcher{
+		Path:    path,
+		Pattern: pattern,
+	})
+}
+
+var _ types.GomegaMatcher = &regexpMatcher{}
+
+type regexpMatcher struct {
+	Path    string
+	Pattern string
+	re      *regexp.Regexp
+}
+
+func (matcher *regexpMatcher) Match(actual interface{}) (bool, error) {
+	if matcher.re == nil {
+		re, err := regexp.Compile(matcher.Pattern)
+		if err != nil {
+			return false, fmt.Errorf("unable to compile regular expression %s: %w", matcher.Pattern, err)
+		}
+
+		matcher.re = re
+	}
+
+	switch v := actual.(type) {
+	case nil:
+		return false, fmt.Errorf("path %s did not produce any value, a string is required", matcher.Path)
+	case string:
+		return matcher.re.MatchString(v), nil
+	default:
+		return false, fmt.Errorf("path %s evaluated to %s, a string is required", matcher.Path, gojq.TypeOf(v))
+	}
+}
+
+func (matcher *regexpMatcher) FailureMessage(actual interface{}) string {
+	return format.Message(actual, "to match regular expression", matcher.Pattern)
+}
+
+func (matcher *regexpMatcher) NegatedFailureMessage(actual interface{}) string {
+	return format.Message(actual, "not to match regular expression", matcher.Pattern)
+}
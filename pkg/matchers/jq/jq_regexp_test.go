@@ -0,0 +1,49 @@
+package jq_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestMatchRegexp(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	in := `{"metadata":{"name":"app-7d4b9"},"spec":{"replicas":3,"image":"registry.example.com/app@sha256:0123"}}`
+
+	g.Expect(in).Should(jq.MatchRegexp(".metadata.name", `^app-[a-z0-9]{5}$`))
+	g.Expect(in).Should(jq.MatchRegexp(".spec.image", `^registry\.example\.com/.+@sha256:`))
+	g.Expect(in).ShouldNot(jq.MatchRegexp(".metadata.name", `^web-`))
+
+	_, err := jq.MatchRegexp(".metadata.missing", `.*`).Match(in)
+	g.Expect(err).Should(MatchError("path .metadata.missing did not produce any value, a string is required"))
+
+	_, err = jq.MatchRegexp(".spec.replicas", `.*`).Match(in)
+	g.Expect(err).Should(MatchError("path .spec.replicas evaluated to number, a string is required"))
+
+	_, err = jq.MatchRegexp(".metadata.name", `(?<name>app)`).Match(in)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	_, err = jq.MatchRegexp(".metadata.name", `(app`).Match(in)
+	g.Expect(err).Should(MatchError(ContainSubstring("unable to compile regular expression (app")))
+}
+
+func TestMatchRegexpFailureMessage(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	m := jq.MatchRegexp(".metadata.name", `^web-`)
+
+	g.Expect(m.Match(`{"metadata":{"name":"app"}}`)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(And(
+		HavePrefix("Expected field .metadata.name to match:\n"),
+		ContainSubstring(`<string>: app`),
+		ContainSubstring("to match regular expression"),
+		ContainSubstring(`<string>: ^web-`),
+	))
+}
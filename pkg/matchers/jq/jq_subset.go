@@ -0,0 +1,310 @@
+package jq
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/itchyny/gojq"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+//nolint:gochecknoglobals
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ContainSubset succeeds if the actual document contains all the keys and
+// values of the expected fragment, given as a JSON document or as a Go map or
+// slice, i.e.:
+//
+//	Expect(deployment).Should(jq.ContainSubset(`{"spec":{"replicas":3}}`))
+//
+// Objects must hold every expected key, with nested objects compared the same
+// way, and arrays must hold an element containing each expected element,
+// regardless of the order. The failure message lists the missing and
+// mismatching paths.
+func ContainSubset(expected any) *SubsetMatcher {
+	return std.ContainSubset(expected)
+}
+
+// ContainSubset succeeds if the actual document contains all the keys and
+// values of the expected fragment.
+func (m *Matcher) ContainSubset(expected any) *SubsetMatcher {
+	return &SubsetMatcher{
+		Expected: expected,
+		config:   m,
+	}
+}
+
+var _ types.GomegaMatcher = &SubsetMatcher{}
+
+// SubsetMatcher is the matcher returned by ContainSubset.
+type SubsetMatcher struct {
+	Expected any
+	config   *Matcher
+	anywhere bool
+	expected any
+	data     any
+	found    string
+}
+
+// Anywhere makes the matcher succeed if any object or array of the actual
+// document, and not only its root, contains the expected fragment, i.e. to
+// look for a container regardless of its position:
+//
+//	Expect(pod).Should(jq.ContainSubset(`{"name":"app","image":"app:1.0"}`).Anywhere())
+func (matcher *SubsetMatcher) Anywhere() *SubsetMatcher {
+	matcher.anywhere = true
+
+	return matcher
+}
+
+func (matcher *SubsetMatcher) Match(actual interface{}) (bool, error) {
+	if matcher.expected == nil {
+		expected, err := matcher.config.toType(matcher.Expected)
+		if err != nil {
+			return false, fmt.Errorf("unable to convert expected subset, %w", err)
+		}
+
+		expected, err = normalize(expected)
+		if err != nil {
+			return false, fmt.Errorf("unable to convert expected subset, %w", err)
+		}
+
+		matcher.expected = expected
+	}
+
+	matcher.data = nil
+	matcher.found = ""
+
+	data, err := matcher.config.toType(actual)
+	if err != nil {
+		return false, err
+	}
+
+	// numbers are compared as decoded from JSON, regardless of the Go type
+	// they had in the actual value (i.e. int64 for unstructured objects)
+	data, err = normalize(data)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.data = data
+
+	if !matcher.anywhere {
+		if len(subsetDiff("", matcher.expected, data)) > 0 {
+			return false, nil
+		}
+
+		matcher.found = "."
+
+		return true, nil
+	}
+
+	for _, c := range candidates("", data) {
+		if len(subsetDiff(c.path, matcher.expected, c.value)) == 0 {
+			matcher.found = displayPath(c.path)
+
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (matcher *SubsetMatcher) FailureMessage(actual interface{}) string {
+	return format.Message(matcher.config.render(actual, matcher.data), matcher.message("to contain subset"), toJSON(matcher.expected)) + matcher.mismatches()
+}
+
+func (matcher *SubsetMatcher) NegatedFailureMessage(actual interface{}) string {
+	return format.Message(matcher.config.render(actual, matcher.data), matcher.message("not to contain subset"), toJSON(matcher.expected)) + "\n\nsubset found at " + matcher.found
+}
+
+func (matcher *SubsetMatcher) message(message string) string {
+	if matcher.anywhere {
+		return message + " anywhere"
+	}
+
+	return message
+}
+
+// mismatches describes why the last evaluated document does not contain the
+// expected subset. Values are taken from the document with the configured
+// redactions applied, so that they do not leak through the differences.
+func (matcher *SubsetMatcher) mismatches() string {
+	if matcher.data == nil {
+		return ""
+	}
+
+	data := matcher.data
+	if redact := matcher.config.redactions(); len(redact) > 0 {
+		data = redactData(data, redact)
+		if data == redacted {
+			return ""
+		}
+	}
+
+	if !matcher.anywhere {
+		return "\n\nmismatches:\n  " + strings.Join(subsetDiff("", matcher.expected, data), "\n  ")
+	}
+
+	// the closest candidate is the one lacking the fewest expected keys,
+	// then the one with the fewest differences, the outermost one being
+	// preferred on ties
+	var closest []string
+
+	at := ""
+	missing := -1
+
+	for _, c := range candidates("", data) {
+		diff := subsetDiff(c.path, matcher.expected, c.value)
+		n := missingKeys(matcher.expected, c.value)
+
+		if missing == -1 || n < missing || (n == missing && len(diff) < len(closest)) {
+			closest = diff
+			at = c.path
+			missing = n
+		}
+	}
+
+	return fmt.Sprintf("\n\nclosest match at %s:\n  %s", displayPath(at), strings.Join(closest, "\n  "))
+}
+
+type candidate struct {
+	path  string
+	value any
+}
+
+// candidates returns the given value followed by all the objects and arrays
+// nested into it, depth first.
+func candidates(path string, value any) []candidate {
+	var result []candidate
+
+	switch v := value.(type) {
+	case map[string]any:
+		result = append(result, candidate{path: path, value: v})
+
+		for _, k := range sortedKeys(v) {
+			result = append(result, candidates(path+keyPath(k), v[k])...)
+		}
+	case []any:
+		result = append(result, candidate{path: path, value: v})
+
+		for i, e := range v {
+			result = append(result, candidates(fmt.Sprintf("%s[%d]", path, i), e)...)
+		}
+	}
+
+	return result
+}
+
+// subsetDiff returns the paths at which actual does not contain expected,
+// along with the reason.
+func subsetDiff(path string, expected any, actual any) []string {
+	switch e := expected.(type) {
+	case map[string]any:
+		a, ok := actual.(map[string]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected an object, got %s", displayPath(path), gojq.TypeOf(actual))}
+		}
+
+		var diff []string
+
+		for _, k := range sortedKeys(e) {
+			v, ok := a[k]
+			if !ok {
+				diff = append(diff, displayPath(path+keyPath(k))+": missing")
+
+				continue
+			}
+
+			diff = append(diff, subsetDiff(path+keyPath(k), e[k], v)...)
+		}
+
+		return diff
+	case []any:
+		a, ok := actual.([]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected an array, got %s", displayPath(path), gojq.TypeOf(actual))}
+		}
+
+		var diff []string
+
+		for _, ee := range e {
+			found := slices.ContainsFunc(a, func(ae any) bool {
+				return len(subsetDiff(path, ee, ae)) == 0
+			})
+
+			if !found {
+				diff = append(diff, fmt.Sprintf("%s: no element containing %s", displayPath(path), toJSON(ee)))
+			}
+		}
+
+		return diff
+	default:
+		switch actual.(type) {
+		case map[string]any, []any:
+			return []string{fmt.Sprintf("%s: expected %s, got %s", displayPath(path), toJSON(expected), gojq.TypeOf(actual))}
+		}
+
+		if !reflect.DeepEqual(expected, actual) {
+			return []string{fmt.Sprintf("%s: expected %s, got %s", displayPath(path), toJSON(expected), toJSON(actual))}
+		}
+
+		return nil
+	}
+}
+
+// missingKeys returns how many of the keys of the expected object are not
+// found in actual.
+func missingKeys(expected any, actual any) int {
+	e, ok := expected.(map[string]any)
+	if !ok {
+		return 0
+	}
+
+	a, ok := actual.(map[string]any)
+	if !ok {
+		return len(e)
+	}
+
+	n := 0
+
+	for k := range e {
+		if _, ok := a[k]; !ok {
+			n++
+		}
+	}
+
+	return n
+}
+
+func sortedKeys(in map[string]any) []string {
+	keys := make([]string, 0, len(in))
+	for k := range in {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+// keyPath renders the given key as a jq path segment, i.e. .foo or ["foo.bar"].
+func keyPath(key string) string {
+	if identifier.MatchString(key) {
+		return "." + key
+	}
+
+	return "[" + toJSON(key) + "]"
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "."
+	}
+
+	return path
+}
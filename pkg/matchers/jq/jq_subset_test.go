@@ -0,0 +1,131 @@
+package jq_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	. "github.com/onsi/gomega"
+)
+
+const subsetDocument = `{
+  "metadata": {"name": "app", "labels": {"tier": "web", "app.kubernetes.io/name": "app"}},
+  "spec": {
+    "replicas": 3,
+    "template": {"spec": {"containers": [
+      {"name": "sidecar", "image": "proxy:2.0"},
+      {"name": "app", "image": "app:1.0", "ports": [{"containerPort": 8080}]}
+    ]}}
+  }
+}`
+
+func TestContainSubset(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	g.Expect(subsetDocument).Should(jq.ContainSubset(`{"spec":{"replicas":3}}`))
+	g.Expect(subsetDocument).Should(jq.ContainSubset(`{"metadata":{"labels":{"app.kubernetes.io/name":"app"}}}`))
+	g.Expect(subsetDocument).Should(jq.ContainSubset(map[string]any{
+		"spec": map[string]any{
+			"template": map[string]any{
+				"spec": map[string]any{
+					"containers": []any{
+						map[string]any{"name": "app", "ports": []any{map[string]any{"containerPort": 8080}}},
+						map[string]any{"name": "sidecar"},
+					},
+				},
+			},
+		},
+	}))
+
+	g.Expect(subsetDocument).ShouldNot(jq.ContainSubset(`{"spec":{"replicas":2}}`))
+	g.Expect(subsetDocument).ShouldNot(jq.ContainSubset(`{"spec":{"paused":false}}`))
+	g.Expect(subsetDocument).ShouldNot(jq.ContainSubset(`{"name":"app","image":"app:1.0"}`))
+
+	g.Expect(subsetDocument).Should(jq.ContainSubset(`{"name":"app","image":"app:1.0"}`).Anywhere())
+	g.Expect(subsetDocument).Should(jq.ContainSubset(`{"tier":"web"}`).Anywhere())
+	g.Expect(subsetDocument).ShouldNot(jq.ContainSubset(`{"name":"app","image":"app:2.0"}`).Anywhere())
+
+	_, err := jq.ContainSubset(`{"spec"`).Match(subsetDocument)
+	g.Expect(err).Should(MatchError(ContainSubstring("unable to convert expected subset")))
+}
+
+func TestContainSubsetUnstructured(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	u := unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"replicas": int64(3),
+		},
+	}}
+
+	g.Expect(u).Should(jq.ContainSubset(`{"spec":{"replicas":3}}`))
+	g.Expect(u.Object).Should(HaveKeyWithValue("spec", HaveKeyWithValue("replicas", int64(3))))
+}
+
+func TestContainSubsetFailureMessage(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	m := jq.ContainSubset(`{"metadata":{"labels":{"app.kubernetes.io/name":"web","env":"prod"}},"spec":{"replicas":2,"template":"x"}}`)
+
+	ok, err := m.Match(subsetDocument)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ok).Should(BeFalse())
+	g.Expect(m.FailureMessage(subsetDocument)).Should(And(
+		ContainSubstring("to contain subset"),
+		ContainSubstring("mismatches:\n"),
+		ContainSubstring(`.metadata.labels["app.kubernetes.io/name"]: expected "web", got "app"`),
+		ContainSubstring(".metadata.labels.env: missing"),
+		ContainSubstring(".spec.replicas: expected 2, got 3"),
+		ContainSubstring(`.spec.template: expected "x", got object`),
+	))
+
+	m = jq.ContainSubset(`{"containers":[{"name":"web"}]}`)
+
+	ok, err = m.Match(subsetDocument)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ok).Should(BeFalse())
+	g.Expect(m.FailureMessage(subsetDocument)).Should(ContainSubstring(`.containers: missing`))
+
+	m = jq.ContainSubset(`{"containers":[{"name":"web"}]}`).Anywhere()
+
+	ok, err = m.Match(subsetDocument)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ok).Should(BeFalse())
+	g.Expect(m.FailureMessage(subsetDocument)).Should(And(
+		ContainSubstring("to contain subset anywhere"),
+		ContainSubstring(`closest match at .spec.template.spec:`),
+		ContainSubstring(`.spec.template.spec.containers: no element containing {"name":"web"}`),
+	))
+
+	m = jq.ContainSubset(`{"image":"app:1.0"}`).Anywhere()
+
+	ok, err = m.Match(subsetDocument)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ok).Should(BeTrue())
+	g.Expect(m.NegatedFailureMessage(subsetDocument)).Should(ContainSubstring("subset found at .spec.template.spec.containers[1]"))
+}
+
+func TestContainSubsetRedaction(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	m := jq.New(jq.WithRedactPaths(".data[]?")).ContainSubset(`{"data":{"password":"guess"}}`)
+
+	in := `{"data":{"password":"s3cr3t"}}`
+
+	ok, err := m.Match(in)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ok).Should(BeFalse())
+
+	msg := m.FailureMessage(in)
+	g.Expect(msg).ShouldNot(ContainSubstring("s3cr3t"))
+	g.Expect(msg).Should(ContainSubstring(`.data.password: expected "guess", got "[REDACTED]"`))
+}
@@ -1,12 +1,14 @@
 package jq
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"reflect"
 	"strings"
+	"sync"
 
 	"github.com/onsi/gomega/gbytes"
 
@@ -14,6 +16,21 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
+// maxPooledBufferSize bounds the size of the buffers kept by documentBuffers,
+// so that a single huge document does not pin its memory for good.
+const maxPooledBufferSize = 4 << 20
+
+// documentBuffers holds the buffers string documents are copied to before
+// being decoded, as Eventually converts the same kind of document over and
+// over.
+//
+//nolint:gochecknoglobals
+var documentBuffers = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
 func formattedMessage(comparisonMessage string, failurePath []interface{}) string {
 	diffMessage := ""
 
@@ -48,15 +65,12 @@ func formattedFailurePath(failurePath []interface{}) string {
 //nolint:cyclop,exhaustive
 func toType(in any) (any, error) {
 	switch v := in.(type) {
+	case *Document:
+		return v.data, nil
 	case string:
-		d, err := byteToType([]byte(v))
-		if err != nil {
-			return nil, err
-		}
-
-		return d, nil
+		return stringToType(v)
 	case []byte:
-		d, err := byteToType(v)
+		d, err := documentToType(v)
 		if err != nil {
 			return nil, err
 		}
@@ -77,21 +91,15 @@ func toType(in any) (any, error) {
 
 		return d, nil
 	case io.Reader:
-		data, err := io.ReadAll(v)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read from reader: %w", err)
-		}
-
-		d, err := byteToType(data)
-		if err != nil {
-			return nil, err
-		}
-
-		return d, nil
+		return readerToType(v)
 	case unstructured.Unstructured:
 		return v.Object, nil
 	case *unstructured.Unstructured:
 		return v.Object, nil
+	case unstructured.UnstructuredList:
+		return v.UnstructuredContent(), nil
+	case *unstructured.UnstructuredList:
+		return v.UnstructuredContent(), nil
 	}
 
 	switch reflect.TypeOf(in).Kind() {
@@ -104,6 +112,45 @@ func toType(in any) (any, error) {
 	}
 }
 
+// stringToType decodes the given document from a pooled copy of it, the
+// decoded values do not reference the copy, hence it can be reused.
+func stringToType(in string) (any, error) {
+	buf, _ := documentBuffers.Get().(*bytes.Buffer)
+
+	defer func() {
+		if buf.Cap() <= maxPooledBufferSize {
+			documentBuffers.Put(buf)
+		}
+	}()
+
+	buf.Reset()
+	buf.WriteString(in)
+
+	return documentToType(buf.Bytes())
+}
+
+func toBytes(in any) ([]byte, error) {
+	switch v := in.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case json.RawMessage:
+		return v, nil
+	case *gbytes.Buffer:
+		return v.Contents(), nil
+	case io.Reader:
+		data, err := io.ReadAll(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read from reader: %w", err)
+		}
+
+		return data, nil
+	default:
+		return nil, fmt.Errorf("unsuported type:\n%s", format.Object(in, 1))
+	}
+}
+
 func byteToType(in []byte) (any, error) {
 	if len(in) == 0 {
 		return nil, errors.New("a valid Json document is expected")
@@ -128,3 +175,33 @@ func byteToType(in []byte) (any, error) {
 		return nil, errors.New("a Json Array or Object is required")
 	}
 }
+
+// readerToType decodes the document read from the given reader without
+// buffering it as a whole first, which matters for large documents.
+func readerToType(in io.Reader) (any, error) {
+	var data any
+
+	if err := json.NewDecoder(in).Decode(&data); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("a valid Json document is expected")
+		}
+
+		return nil, fmt.Errorf("unable to unmarshal result, %w", err)
+	}
+
+	switch data.(type) {
+	case map[string]any, []any:
+		return data, nil
+	default:
+		return nil, errors.New("a Json Array or Object is required")
+	}
+}
+
+func toJSON(in any) string {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Sprintf("%v", in)
+	}
+
+	return string(data)
+}
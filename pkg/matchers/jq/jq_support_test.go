@@ -5,23 +5,23 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
-
-	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 func TestToType(t *testing.T) {
 	t.Parallel()
 
 	typeTestData := []byte(`{ "foo": "bar" }`)
-	g := NewWithT(t)
+	g := gomega.NewWithT(t)
 
 	items := map[string]func() any{
 		"gbytes": func() any {
 			b := gbytes.NewBuffer()
 
 			_, err := b.Write(typeTestData)
-			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(err).ShouldNot(gomega.HaveOccurred())
 
 			return b
 		},
@@ -42,10 +42,26 @@ func TestToType(t *testing.T) {
 
 			tt, err := toType(fn())
 
-			g.Expect(err).ShouldNot(HaveOccurred())
-			g.Expect(tt).Should(Satisfy(func(in any) bool {
+			g.Expect(err).ShouldNot(gomega.HaveOccurred())
+			g.Expect(tt).Should(gomega.Satisfy(func(in any) bool {
 				return reflect.TypeOf(in).Kind() == reflect.Map
 			}))
 		})
 	}
 }
+
+func TestToTypeUnstructured(t *testing.T) {
+	t.Parallel()
+
+	g := gomega.NewWithT(t)
+
+	u := unstructured.Unstructured{Object: map[string]any{"kind": "ConfigMap"}}
+	l := unstructured.UnstructuredList{Object: map[string]any{"kind": "List"}, Items: []unstructured.Unstructured{u}}
+
+	for _, in := range []any{u, &u, l, &l} {
+		tt, err := toType(in)
+
+		g.Expect(err).ShouldNot(gomega.HaveOccurred())
+		g.Expect(tt).Should(gomega.BeAssignableToTypeOf(map[string]any{}))
+	}
+}
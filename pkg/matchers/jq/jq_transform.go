@@ -2,23 +2,34 @@ package jq
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/itchyny/gojq"
 )
 
 func Extract(expression string) func(in any) (any, error) {
+	return std.Extract(expression)
+}
+
+func extract(m *Matcher, expression string) func(in any) (any, error) {
+	// the transform is applied repeatedly by Eventually/Consistently, hence
+	// the expression is compiled once, on first use
+	compile := sync.OnceValues(func() (*gojq.Code, error) {
+		return m.compile(expression)
+	})
+
 	return func(in any) (any, error) {
-		query, err := gojq.Parse(expression)
+		code, err := compile()
 		if err != nil {
-			return nil, fmt.Errorf("unable to parse expression %s, %w", expression, err)
+			return nil, err
 		}
 
-		data, err := toType(in)
+		data, err := m.toType(in)
 		if err != nil {
 			return false, err
 		}
 
-		it := query.Run(data)
+		it := code.Run(data)
 
 		v, ok := it.Next()
 		if !ok {
@@ -32,3 +43,28 @@ func Extract(expression string) func(in any) (any, error) {
 		return v, nil
 	}
 }
+
+// Patch returns a transform applying the given mutating program, i.e.
+// `.spec.replicas = 5` or `del(.metadata.annotations)`, to its input and
+// returning the resulting object or array. The input is left untouched.
+func Patch(expression string) func(in any) (any, error) {
+	return std.Patch(expression)
+}
+
+func patch(m *Matcher, expression string) func(in any) (any, error) {
+	apply := extract(m, expression)
+
+	return func(in any) (any, error) {
+		v, err := apply(in)
+		if err != nil {
+			return nil, err
+		}
+
+		switch v.(type) {
+		case map[string]any, []any:
+			return v, nil
+		default:
+			return nil, fmt.Errorf("expression %s evaluated to %s %s, an object or an array is required", expression, gojq.TypeOf(v), toJSON(v))
+		}
+	}
+}
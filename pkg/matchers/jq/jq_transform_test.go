@@ -29,3 +29,31 @@ func TestExtract(t *testing.T) {
 		),
 	)
 }
+
+func TestPatch(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	in := map[string]any{
+		"metadata": map[string]any{"name": "app", "annotations": map[string]any{"foo": "bar"}},
+		"spec":     map[string]any{"replicas": 1},
+	}
+
+	g.Expect(in).Should(
+		WithTransform(jq.Patch(`.spec.replicas = 5 | del(.metadata.annotations)`),
+			And(
+				jq.Match(`.spec.replicas == 5`),
+				jq.Match(`.metadata | has("annotations") | not`),
+			),
+		),
+	)
+
+	// the input is left untouched
+	g.Expect(in).Should(jq.Match(`.spec.replicas == 1 and .metadata.annotations.foo == "bar"`))
+
+	g.Expect(`[1,2,3]`).Should(WithTransform(jq.Patch(`map(. * 2)`), Equal([]any{2.0, 4.0, 6.0})))
+
+	_, err := jq.Patch(`.spec.replicas`)(in)
+	g.Expect(err).Should(MatchError(ContainSubstring("an object or an array is required")))
+}
@@ -0,0 +1,103 @@
+package jq
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/itchyny/gojq"
+)
+
+// ExpressionError describes an invalid expression, including the position
+// at which parsing failed when known.
+type ExpressionError struct {
+	Expression string
+	// Line and Column are 1-based, zero when the position is unknown
+	// (i.e. for compilation errors such as undefined functions).
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *ExpressionError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("invalid expression %s: %v", e.Expression, e.Err)
+	}
+
+	line := strings.Split(e.Expression, "\n")[e.Line-1]
+
+	return fmt.Sprintf("invalid expression: %v at %d:%d\n\t%s\n\t%s^",
+		e.Err,
+		e.Line,
+		e.Column,
+		line,
+		strings.Repeat(" ", e.Column-1),
+	)
+}
+
+func (e *ExpressionError) Unwrap() error {
+	return e.Err
+}
+
+// Validate parses and compiles the given expression, returning an
+// *ExpressionError describing the problem if it is not valid.
+func Validate(expression string) error {
+	return std.Validate(expression)
+}
+
+// MustValidate is like Validate but panics if the expression is not valid.
+// It returns the expression so that it can be used to initialize variables
+// holding shared expressions, making malformed ones fail fast.
+func MustValidate(expression string) string {
+	return std.MustValidate(expression)
+}
+
+// Validate parses and compiles the given expression with the configuration
+// of the Matcher, returning an *ExpressionError describing the problem if it
+// is not valid.
+func (m *Matcher) Validate(expression string) error {
+	_, err := gojq.Parse(expression)
+	if err != nil {
+		verr := ExpressionError{
+			Expression: expression,
+			Err:        err,
+		}
+
+		var perr *gojq.ParseError
+		if errors.As(err, &perr) {
+			verr.Line, verr.Column = position(expression, perr.Offset-len(perr.Token))
+		}
+
+		return &verr
+	}
+
+	if _, err := m.compile(expression); err != nil {
+		return &ExpressionError{
+			Expression: expression,
+			Err:        errors.Unwrap(err),
+		}
+	}
+
+	return nil
+}
+
+// MustValidate is like Validate but panics if the expression is not valid.
+func (m *Matcher) MustValidate(expression string) string {
+	if err := m.Validate(expression); err != nil {
+		panic(err)
+	}
+
+	return expression
+}
+
+// position converts a byte offset in the given string to a 1-based line and
+// column pair.
+func position(in string, offset int) (int, int) {
+	offset = max(0, min(offset, len(in)))
+
+	before := in[:offset]
+	line := strings.Count(before, "\n") + 1
+	column := offset - strings.LastIndex(before, "\n")
+
+	return line, column
+}
@@ -0,0 +1,61 @@
+package jq_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		expression string
+		line       int
+		column     int
+		message    string
+	}{
+		{name: "valid", expression: `.status.phase == "Running"`},
+		{name: "unexpected token", expression: `.foo | }`, line: 1, column: 8, message: "invalid expression: unexpected token \"}\" at 1:8\n\t.foo | }\n\t       ^"},
+		{name: "multiline", expression: ".foo\n| .bar ==", line: 2, column: 10},
+		{name: "unterminated string", expression: `.foo == "bar`, line: 1, column: 13},
+		{name: "undefined function", expression: `.foo | ready`, message: "invalid expression .foo | ready: function not defined: ready/0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			g := NewWithT(t)
+
+			err := jq.Validate(tt.expression)
+			if tt.line == 0 && tt.message == "" {
+				g.Expect(err).ShouldNot(HaveOccurred())
+
+				return
+			}
+
+			var verr *jq.ExpressionError
+			g.Expect(errors.As(err, &verr)).Should(BeTrue())
+			g.Expect(verr.Line).Should(Equal(tt.line))
+			g.Expect(verr.Column).Should(Equal(tt.column))
+
+			if tt.message != "" {
+				g.Expect(err).Should(MatchError(tt.message))
+			}
+		})
+	}
+}
+
+func TestMustValidate(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	g.Expect(jq.MustValidate(`.a == 1`)).Should(Equal(`.a == 1`))
+	g.Expect(func() { jq.MustValidate(`.a ==`) }).Should(Panic())
+}
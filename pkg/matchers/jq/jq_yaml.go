@@ -0,0 +1,51 @@
+package jq
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/yaml"
+)
+
+// FromYAML makes the Matcher decode all the string and []byte values as YAML
+// documents, including those starting like JSON ones, i.e. flow mappings
+// such as `{replicas: 3}`. Without it, only the values that do not start
+// with { or [ are decoded as YAML:
+//
+//	Expect("spec:\n  replicas: 3\n").Should(jq.Match(`.spec.replicas == 3`))
+//	Expect("{spec: {replicas: 3}}").Should(jq.New(jq.FromYAML()).Match(`.spec.replicas == 3`))
+func FromYAML() Option {
+	return WithConverters(func(in any) (any, bool, error) {
+		switch v := in.(type) {
+		case string:
+			d, err := yamlToType([]byte(v))
+
+			return d, true, err
+		case []byte:
+			d, err := yamlToType(v)
+
+			return d, true, err
+		default:
+			return nil, false, nil
+		}
+	})
+}
+
+// documentToType decodes the given document as JSON, or as YAML if it does
+// not start like a JSON object or array does, so that YAML fixtures can be
+// asserted with jq expressions.
+func documentToType(in []byte) (any, error) {
+	if len(in) == 0 || in[0] == '{' || in[0] == '[' {
+		return byteToType(in)
+	}
+
+	return yamlToType(in)
+}
+
+func yamlToType(in []byte) (any, error) {
+	data, err := yaml.YAMLToJSON(in)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert YAML document, %w", err)
+	}
+
+	return byteToType(data)
+}
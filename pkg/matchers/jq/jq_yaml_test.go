@@ -0,0 +1,61 @@
+package jq_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+
+	. "github.com/onsi/gomega"
+)
+
+const yamlDocument = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+        - name: app
+          image: app:1.0
+`
+
+func TestYAML(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	g.Expect(yamlDocument).Should(jq.Match(`.spec.replicas == 3`))
+	g.Expect([]byte(yamlDocument)).Should(jq.Match(`.metadata.name == "app"`))
+	g.Expect(yamlDocument).Should(jq.Field(".spec.template.spec.containers[0].image", Equal("app:1.0")))
+	g.Expect(yamlDocument).Should(WithTransform(jq.Extract(`.spec.template.spec.containers | map(.name)`), Equal([]any{"app"})))
+	g.Expect("- a\n- b\n").Should(jq.Match(`. == ["a", "b"]`))
+
+	// JSON documents are still decoded as such
+	g.Expect(`{"a":1}`).Should(jq.Match(`.a == 1`))
+
+	_, err := jq.Match(`.a == 1`).Match("a: [")
+	g.Expect(err).Should(MatchError(ContainSubstring("unable to convert YAML document")))
+
+	_, err = jq.Match(`. == "a"`).Match("a")
+	g.Expect(err).Should(MatchError("a Json Array or Object is required"))
+}
+
+func TestFromYAML(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	j := jq.New(jq.FromYAML())
+
+	g.Expect("{spec: {replicas: 3}}").Should(j.Match(`.spec.replicas == 3`))
+	g.Expect([]byte("[a, b]")).Should(j.Match(`. == ["a", "b"]`))
+	g.Expect(`{"a":1}`).Should(j.Match(`.a == 1`))
+	g.Expect(yamlDocument).Should(j.Match(`.kind == "Deployment"`))
+	g.Expect(map[string]any{"a": 1}).Should(j.Match(`.a == 1`))
+
+	_, err := jq.Match(`.spec.replicas == 3`).Match("{spec: {replicas: 3}}")
+	g.Expect(err).Should(HaveOccurred())
+}
@@ -0,0 +1,174 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+// Operation is an RFC 6902 JSON Patch operation. When used as an expected
+// operation, Value can be a matcher, i.e. HaveKeyWithValue("app", "foo").
+type Operation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	From  string `json:"from,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+func (o Operation) String() string {
+	switch {
+	case o.From != "":
+		return fmt.Sprintf("%s %s -> %s", o.Op, o.From, o.Path)
+	case o.Op == "remove":
+		return fmt.Sprintf("%s %s", o.Op, o.Path)
+	default:
+		return fmt.Sprintf("%s %s = %s", o.Op, o.Path, format.Object(o.Value, 0))
+	}
+}
+
+// MatchPatch succeeds if the actual JSON patch contains all the expected
+// operations, in any order. Operations are compared by op, path and from,
+// and by value, which can be a matcher. The actual can be a JSON patch as
+// bytes or string, a list of Operations, or an admission response or review,
+// i.e. the one returned by a mutating webhook:
+//
+//	Expect(review).Should(jsonpatch.MatchPatch(
+//	    jsonpatch.Operation{Op: "add", Path: "/spec/replicas", Value: 3},
+//	    jsonpatch.Operation{Op: "add", Path: "/metadata/labels", Value: HaveKey("team")},
+//	))
+func MatchPatch(expected ...Operation) types.GomegaMatcher {
+	return &patchMatcher{
+		expected: expected,
+	}
+}
+
+var _ types.GomegaMatcher = &patchMatcher{}
+
+type patchMatcher struct {
+	expected []Operation
+	actual   []Operation
+	missing  []Operation
+}
+
+func (matcher *patchMatcher) Match(actual interface{}) (bool, error) {
+	ops, err := toOperations(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.actual = ops
+	matcher.missing = nil
+
+	for _, e := range matcher.expected {
+		found, err := containsOperation(ops, e)
+		if err != nil {
+			return false, err
+		}
+
+		if !found {
+			matcher.missing = append(matcher.missing, e)
+		}
+	}
+
+	return len(matcher.missing) == 0, nil
+}
+
+func (matcher *patchMatcher) FailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected patch\n%s\nto contain operations\n%s",
+		formatOperations(matcher.actual), formatOperations(matcher.missing))
+}
+
+func (matcher *patchMatcher) NegatedFailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected patch\n%s\nnot to contain operations\n%s",
+		formatOperations(matcher.actual), formatOperations(matcher.expected))
+}
+
+func containsOperation(ops []Operation, expected Operation) (bool, error) {
+	m, err := toMatcher(expected.Value)
+	if err != nil {
+		return false, err
+	}
+
+	for _, op := range ops {
+		if op.Op != expected.Op || op.Path != expected.Path || op.From != expected.From {
+			continue
+		}
+
+		if expected.Op == "remove" || expected.Op == "move" || expected.Op == "copy" {
+			return true, nil
+		}
+
+		ok, err := m.Match(op.Value)
+		if err != nil {
+			return false, err
+		}
+
+		if ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// ApplyAndMatch applies the given JSON patch, in any of the forms accepted by
+// MatchPatch, to the actual JSON document and succeeds if the patched
+// document, decoded as a map or slice, matches the inner matcher, i.e.:
+//
+//	Expect(pod).Should(jsonpatch.ApplyAndMatch(review, jq.Match(`.spec.containers | length == 2`)))
+//
+// The actual can be JSON bytes or string, or any value that can be
+// marshaled to JSON.
+func ApplyAndMatch(patch any, inner types.GomegaMatcher) types.GomegaMatcher {
+	return &applyMatcher{
+		patch: patch,
+		inner: inner,
+	}
+}
+
+var _ types.GomegaMatcher = &applyMatcher{}
+
+type applyMatcher struct {
+	patch   any
+	inner   types.GomegaMatcher
+	patched any
+}
+
+func (matcher *applyMatcher) Match(actual interface{}) (bool, error) {
+	doc, err := toDocumentBytes(actual)
+	if err != nil {
+		return false, err
+	}
+
+	data, err := toPatchBytes(matcher.patch)
+	if err != nil {
+		return false, err
+	}
+
+	p, err := jsonpatch.DecodePatch(data)
+	if err != nil {
+		return false, fmt.Errorf("unable to decode patch, %w", err)
+	}
+
+	out, err := p.Apply(doc)
+	if err != nil {
+		return false, fmt.Errorf("unable to apply patch, %w", err)
+	}
+
+	if err := json.Unmarshal(out, &matcher.patched); err != nil {
+		return false, fmt.Errorf("unable to unmarshal patched document, %w", err)
+	}
+
+	return matcher.inner.Match(matcher.patched)
+}
+
+func (matcher *applyMatcher) FailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected patched document to match:\n%s", matcher.inner.FailureMessage(matcher.patched))
+}
+
+func (matcher *applyMatcher) NegatedFailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected patched document not to match:\n%s", matcher.inner.NegatedFailureMessage(matcher.patched))
+}
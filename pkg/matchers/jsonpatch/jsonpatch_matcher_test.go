@@ -0,0 +1,116 @@
+package jsonpatch_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jsonpatch"
+	admissionv1 "k8s.io/api/admission/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+const patch = `[
+  {"op": "add", "path": "/spec/replicas", "value": 3},
+  {"op": "add", "path": "/metadata/labels", "value": {"app": "foo", "team": "bar"}},
+  {"op": "remove", "path": "/metadata/annotations"}
+]`
+
+const pod = `{
+  "metadata": {"name": "foo", "annotations": {"a": "b"}},
+  "spec": {"replicas": 1}
+}`
+
+func TestMatchPatch(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	g.Expect(patch).Should(
+		jsonpatch.MatchPatch(
+			jsonpatch.Operation{Op: "remove", Path: "/metadata/annotations"},
+			jsonpatch.Operation{Op: "add", Path: "/spec/replicas", Value: 3},
+			jsonpatch.Operation{Op: "add", Path: "/metadata/labels", Value: HaveKeyWithValue("team", "bar")},
+		),
+	)
+
+	g.Expect(patch).ShouldNot(
+		jsonpatch.MatchPatch(
+			jsonpatch.Operation{Op: "add", Path: "/spec/replicas", Value: 2},
+		),
+	)
+
+	g.Expect(patch).ShouldNot(
+		jsonpatch.MatchPatch(
+			jsonpatch.Operation{Op: "replace", Path: "/spec/replicas", Value: 3},
+		),
+	)
+}
+
+func TestMatchPatchAdmission(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	pt := admissionv1.PatchTypeJSONPatch
+	review := admissionv1.AdmissionReview{
+		Response: &admissionv1.AdmissionResponse{
+			Allowed:   true,
+			PatchType: &pt,
+			Patch:     []byte(patch),
+		},
+	}
+
+	g.Expect(&review).Should(
+		jsonpatch.MatchPatch(jsonpatch.Operation{Op: "add", Path: "/spec/replicas", Value: 3}),
+	)
+	g.Expect(review.Response).Should(
+		jsonpatch.MatchPatch(jsonpatch.Operation{Op: "remove", Path: "/metadata/annotations"}),
+	)
+	g.Expect(&admissionv1.AdmissionResponse{Allowed: true}).Should(
+		jsonpatch.MatchPatch(),
+	)
+
+	_, err := jsonpatch.MatchPatch().Match(&admissionv1.AdmissionReview{})
+	g.Expect(err).Should(HaveOccurred())
+}
+
+func TestMatchPatchFailureMessage(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	m := jsonpatch.MatchPatch(jsonpatch.Operation{Op: "add", Path: "/spec/replicas", Value: 2})
+
+	ok, err := m.Match(patch)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ok).Should(BeFalse())
+	g.Expect(m.FailureMessage(patch)).Should(And(
+		ContainSubstring("remove /metadata/annotations"),
+		ContainSubstring("to contain operations\n  add /spec/replicas = <int>: 2"),
+	))
+}
+
+func TestApplyAndMatch(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	g.Expect(pod).Should(
+		jsonpatch.ApplyAndMatch(patch, And(
+			jq.Match(`.spec.replicas == 3`),
+			jq.Match(`.metadata.labels.app == "foo"`),
+			jq.Match(`.metadata | has("annotations") | not`),
+		)),
+	)
+
+	g.Expect(map[string]any{"spec": map[string]any{}}).Should(
+		jsonpatch.ApplyAndMatch(
+			[]jsonpatch.Operation{{Op: "add", Path: "/spec/replicas", Value: 3}},
+			HaveKeyWithValue("spec", HaveKeyWithValue("replicas", BeNumerically("==", 3))),
+		),
+	)
+
+	_, err := jsonpatch.ApplyAndMatch(patch, Succeed()).Match(`{}`)
+	g.Expect(err).Should(MatchError(ContainSubstring("unable to apply patch")))
+}
@@ -0,0 +1,120 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	admissionv1 "k8s.io/api/admission/v1"
+)
+
+// toPatchBytes returns the JSON representation of the given patch, which can
+// be JSON bytes or string, a list of Operations or an admission response or
+// review carrying a patch.
+func toPatchBytes(in any) ([]byte, error) {
+	switch v := in.(type) {
+	case nil:
+		return nil, errors.New("a JSON patch is expected, got nil")
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case json.RawMessage:
+		return v, nil
+	case *admissionv1.AdmissionReview:
+		if v.Response == nil {
+			return nil, errors.New("the admission review has no response")
+		}
+
+		return toPatchBytes(v.Response)
+	case admissionv1.AdmissionReview:
+		return toPatchBytes(&v)
+	case *admissionv1.AdmissionResponse:
+		if len(v.Patch) == 0 {
+			return []byte("[]"), nil
+		}
+
+		return v.Patch, nil
+	case admissionv1.AdmissionResponse:
+		return toPatchBytes(&v)
+	case []Operation:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal patch, %w", err)
+		}
+
+		return data, nil
+	default:
+		return nil, fmt.Errorf("unsupported type:\n%s", format.Object(in, 1))
+	}
+}
+
+// toOperations decodes the given patch.
+func toOperations(in any) ([]Operation, error) {
+	data, err := toPatchBytes(in)
+	if err != nil {
+		return nil, err
+	}
+
+	var ops []Operation
+	if err := json.Unmarshal(data, &ops); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal patch, %w", err)
+	}
+
+	return ops, nil
+}
+
+// toDocumentBytes returns the JSON representation of the given document.
+func toDocumentBytes(in any) ([]byte, error) {
+	switch v := in.(type) {
+	case nil:
+		return nil, errors.New("a JSON document is expected, got nil")
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case json.RawMessage:
+		return v, nil
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal document, %w", err)
+		}
+
+		return data, nil
+	}
+}
+
+// toMatcher returns the given value if it is a matcher, otherwise a matcher
+// comparing values by their JSON representation, so that i.e. 5 matches the
+// float64 decoded from a patch.
+func toMatcher(expected any) (types.GomegaMatcher, error) {
+	if m, ok := expected.(types.GomegaMatcher); ok {
+		return m, nil
+	}
+
+	data, err := json.Marshal(expected)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal expected value, %w", err)
+	}
+
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal expected value, %w", err)
+	}
+
+	return gomega.Equal(v), nil
+}
+
+func formatOperations(ops []Operation) string {
+	items := make([]string, 0, len(ops))
+	for _, op := range ops {
+		items = append(items, "  "+op.String())
+	}
+
+	return strings.Join(items, "\n")
+}
@@ -0,0 +1,67 @@
+package envtest
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+)
+
+// Options configures the envtest environment started by Start.
+type Options struct {
+	// CRDDirectoryPaths is a list of paths containing CRD manifests to be
+	// installed once the environment is up.
+	CRDDirectoryPaths []string
+
+	// ErrorIfCRDPathMissing makes Start fail if any of the CRDDirectoryPaths
+	// does not exist.
+	ErrorIfCRDPathMissing bool
+
+	// BinaryAssetsDirectory is the directory containing the control plane
+	// binaries, when empty the KUBEBUILDER_ASSETS environment variable is
+	// used.
+	BinaryAssetsDirectory string
+
+	// AddToScheme registers additional types in the scheme used by the
+	// returned Matcher.
+	AddToScheme []func(*runtime.Scheme) error
+
+	// MatcherOptions are additional options for the returned Matcher.
+	MatcherOptions []k8s.Option
+}
+
+// Start boots a controller-runtime envtest environment, installs the CRDs
+// found in the configured paths and returns a Matcher connected to it. The
+// environment is stopped when the test and all its subtests complete.
+func Start(t testing.TB, opts Options) *k8s.Matcher {
+	t.Helper()
+
+	env := envtest.Environment{
+		CRDDirectoryPaths:     opts.CRDDirectoryPaths,
+		ErrorIfCRDPathMissing: opts.ErrorIfCRDPathMissing,
+		BinaryAssetsDirectory: opts.BinaryAssetsDirectory,
+	}
+
+	cfg, err := env.Start()
+	if err != nil {
+		t.Fatalf("unable to start envtest environment: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err := env.Stop(); err != nil {
+			t.Errorf("unable to stop envtest environment: %v", err)
+		}
+	})
+
+	matcherOptions := make([]k8s.Option, 0, len(opts.MatcherOptions)+1)
+	matcherOptions = append(matcherOptions, k8s.WithAddToScheme(opts.AddToScheme...))
+	matcherOptions = append(matcherOptions, opts.MatcherOptions...)
+
+	m, err := k8s.NewFromConfig(cfg, matcherOptions...)
+	if err != nil {
+		t.Fatalf("unable to create matcher: %v", err)
+	}
+
+	return m.WithContext(t.Context())
+}
@@ -0,0 +1,68 @@
+package envtest_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s/envtest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestStart(t *testing.T) {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		t.Skip("KUBEBUILDER_ASSETS is not set")
+	}
+
+	g := NewWithT(t)
+
+	k := envtest.Start(t, envtest.Options{})
+
+	cm := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "default"},
+		Data:       map[string]string{"foo": "bar"},
+	}
+
+	g.Expect(k.Client().Create(t.Context(), &cm)).Should(Succeed())
+
+	g.Eventually(k.Unstructured().Get("v1/ConfigMap", client.ObjectKeyFromObject(&cm))).
+		WithContext(t.Context()).
+		Should(jq.Match(`.data.foo == "bar"`))
+
+	g.Eventually(k.Unstructured().Get("cm", client.ObjectKeyFromObject(&cm))).
+		WithContext(t.Context()).
+		Should(jq.Match(`.data.foo == "bar"`))
+}
+
+func TestStartWithCache(t *testing.T) {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		t.Skip("KUBEBUILDER_ASSETS is not set")
+	}
+
+	g := NewWithT(t)
+
+	k := envtest.Start(t, envtest.Options{
+		MatcherOptions: []k8s.Option{
+			k8s.WithCache(t.Context()),
+		},
+	})
+
+	cm := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "default"},
+		Data:       map[string]string{"foo": "bar"},
+	}
+
+	g.Expect(k.Live().Client().Create(t.Context(), &cm)).Should(Succeed())
+
+	g.Eventually(k.Unstructured().Get("v1/ConfigMap", client.ObjectKeyFromObject(&cm))).
+		WithContext(t.Context()).
+		Should(jq.Match(`.data.foo == "bar"`))
+
+	g.Expect(k.Live().Unstructured().Get("v1/ConfigMap", client.ObjectKeyFromObject(&cm))(t.Context())).
+		Should(jq.Match(`.data.foo == "bar"`))
+}
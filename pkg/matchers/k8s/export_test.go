@@ -0,0 +1,7 @@
+package k8s
+
+// ResolvePortForwardTarget exposes resolvePortForwardTarget to the tests,
+// as it cannot be exercised through PortForward without an API server.
+//
+//nolint:gochecknoglobals
+var ResolvePortForwardTarget = resolvePortForwardTarget
@@ -0,0 +1,102 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// BeAdmitted succeeds if a server-side dry-run of the actual object is
+// accepted by the API server, including validating webhooks and CEL rules.
+// Objects without a resourceVersion are dry-run created, others are dry-run
+// updated.
+func (m *Matcher) BeAdmitted() types.GomegaMatcher {
+	return &admissionMatcher{
+		matcher: m,
+	}
+}
+
+// BeRejectedWith succeeds if a server-side dry-run of the actual object is
+// rejected by the API server with a message containing the given substring.
+func (m *Matcher) BeRejectedWith(substr string) types.GomegaMatcher {
+	return &admissionMatcher{
+		matcher:  m,
+		rejected: true,
+		substr:   substr,
+	}
+}
+
+var _ types.GomegaMatcher = &admissionMatcher{}
+
+type admissionMatcher struct {
+	matcher  *Matcher
+	rejected bool
+	substr   string
+	admitted bool
+	reason   string
+}
+
+func (matcher *admissionMatcher) Match(actual interface{}) (bool, error) {
+	obj, ok := actual.(client.Object)
+	if !ok {
+		return false, fmt.Errorf("expected a client.Object, got:\n%s", format.Object(actual, 1))
+	}
+
+	// the dry run request may mutate the object (i.e. defaulting), hence
+	// work on a copy so that the actual is left untouched
+	obj, ok = obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return false, fmt.Errorf("unable to copy object:\n%s", format.Object(actual, 1))
+	}
+
+	var err error
+
+	if obj.GetResourceVersion() == "" {
+		err = matcher.matcher.client.Create(matcher.matcher.ctx, obj, client.DryRunAll)
+	} else {
+		err = matcher.matcher.client.Update(matcher.matcher.ctx, obj, client.DryRunAll)
+	}
+
+	var status apierrors.APIStatus
+
+	switch {
+	case err == nil:
+		matcher.reason = ""
+	case errors.As(err, &status):
+		matcher.reason = status.Status().Message
+	default:
+		return false, fmt.Errorf("unable to perform dry run request: %w", err)
+	}
+
+	matcher.admitted = err == nil
+
+	if !matcher.rejected {
+		return matcher.admitted, nil
+	}
+
+	return !matcher.admitted && strings.Contains(matcher.reason, matcher.substr), nil
+}
+
+func (matcher *admissionMatcher) FailureMessage(actual interface{}) string {
+	switch {
+	case !matcher.rejected:
+		return matcher.matcher.annotate(format.Message(actual, "to be admitted, but it was rejected with:\n"+matcher.reason))
+	case matcher.admitted:
+		return matcher.matcher.annotate(format.Message(actual, fmt.Sprintf("to be rejected with a message containing %q, but it was admitted", matcher.substr)))
+	default:
+		return matcher.matcher.annotate(format.Message(actual, fmt.Sprintf("to be rejected with a message containing %q, but it was rejected with:\n%s", matcher.substr, matcher.reason)))
+	}
+}
+
+func (matcher *admissionMatcher) NegatedFailureMessage(actual interface{}) string {
+	if !matcher.rejected {
+		return matcher.matcher.annotate(format.Message(actual, "not to be admitted"))
+	}
+
+	return matcher.matcher.annotate(format.Message(actual, fmt.Sprintf("not to be rejected with a message containing %q, but it was rejected with:\n%s", matcher.substr, matcher.reason)))
+}
@@ -0,0 +1,96 @@
+package k8s_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestAdmission(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	reject := func(obj client.Object) error {
+		if obj.GetLabels()["valid"] == "true" {
+			return nil
+		}
+
+		return apierrors.NewForbidden(
+			schema.GroupResource{Resource: "configmaps"},
+			obj.GetName(),
+			errors.New(`admission webhook "validate.example.com" denied the request: missing valid label`),
+		)
+	}
+
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "ns", Labels: map[string]string{"valid": "true"}},
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(existing).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if err := reject(obj); err != nil {
+					return err
+				}
+
+				return c.Create(ctx, obj, opts...)
+			},
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if err := reject(obj); err != nil {
+					return err
+				}
+
+				return c.Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	k := k8s.New(cli, scheme).WithContext(t.Context())
+
+	valid := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "valid", Namespace: "ns", Labels: map[string]string{"valid": "true"}},
+	}
+
+	invalid := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "invalid", Namespace: "ns"},
+	}
+
+	g.Expect(valid).Should(k.BeAdmitted())
+	g.Expect(invalid).ShouldNot(k.BeAdmitted())
+	g.Expect(invalid).Should(k.BeRejectedWith("missing valid label"))
+	g.Expect(invalid).ShouldNot(k.BeRejectedWith("something else"))
+	g.Expect(valid).ShouldNot(k.BeRejectedWith("missing valid label"))
+
+	// dry run requests must not persist anything
+	g.Expect(cli.Get(t.Context(), client.ObjectKeyFromObject(valid), &corev1.ConfigMap{})).
+		Should(Satisfy(apierrors.IsNotFound))
+
+	current := &corev1.ConfigMap{}
+	g.Expect(cli.Get(t.Context(), client.ObjectKeyFromObject(existing), current)).Should(Succeed())
+	g.Expect(current).Should(k.BeAdmitted())
+
+	current.Labels = nil
+	g.Expect(current).Should(k.BeRejectedWith("denied the request"))
+
+	_, err := k.BeAdmitted().Match("not an object")
+	g.Expect(err).Should(HaveOccurred())
+}
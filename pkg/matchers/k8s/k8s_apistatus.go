@@ -0,0 +1,172 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/onsi/gomega/types"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// BeNotFound succeeds if the actual error, possibly wrapped, is a Kubernetes
+// API error with reason NotFound. Unlike apierrors.IsNotFound, a failure
+// reports the Status carried by the error:
+//
+//	Expect(cli.Get(ctx, key, &cm)).Should(k8s.BeNotFound())
+func BeNotFound() types.GomegaMatcher {
+	return &apiStatusMatcher{
+		reason: metav1.StatusReasonNotFound,
+	}
+}
+
+// BeConflict is like BeNotFound, for errors with reason Conflict, i.e. an
+// update with a stale resourceVersion.
+func BeConflict() types.GomegaMatcher {
+	return &apiStatusMatcher{
+		reason: metav1.StatusReasonConflict,
+	}
+}
+
+// BeForbidden is like BeNotFound, for errors with reason Forbidden, i.e. a
+// request denied by RBAC or by an admission plugin.
+func BeForbidden() types.GomegaMatcher {
+	return &apiStatusMatcher{
+		reason: metav1.StatusReasonForbidden,
+	}
+}
+
+// BeInvalidWithCauses is like BeNotFound, for errors with reason Invalid,
+// additionally requiring the Status to report a cause for each of the given
+// field paths, so that a request rejected for another field is not
+// mistaken for the expected one:
+//
+//	Expect(cli.Create(ctx, &deployment)).Should(k8s.BeInvalidWithCauses("spec.replicas", "spec.template.metadata.labels"))
+func BeInvalidWithCauses(fieldPaths ...string) types.GomegaMatcher {
+	return &apiStatusMatcher{
+		reason: metav1.StatusReasonInvalid,
+		fields: fieldPaths,
+	}
+}
+
+var _ types.GomegaMatcher = &apiStatusMatcher{}
+
+type apiStatusMatcher struct {
+	reason metav1.StatusReason
+	fields []string
+
+	err     error
+	status  *metav1.Status
+	missing []string
+}
+
+func (matcher *apiStatusMatcher) Match(actual interface{}) (bool, error) {
+	matcher.err = nil
+	matcher.status = nil
+	matcher.missing = nil
+
+	if actual == nil {
+		return false, nil
+	}
+
+	err, ok := actual.(error)
+	if !ok {
+		return false, fmt.Errorf("an error is expected, got %T", actual)
+	}
+
+	matcher.err = err
+
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) {
+		return false, nil
+	}
+
+	s := status.Status()
+	matcher.status = &s
+
+	if s.Reason != matcher.reason {
+		return false, nil
+	}
+
+	for _, f := range matcher.fields {
+		if !hasCause(s.Details, f) {
+			matcher.missing = append(matcher.missing, f)
+		}
+	}
+
+	return len(matcher.missing) == 0, nil
+}
+
+func (matcher *apiStatusMatcher) FailureMessage(_ interface{}) string {
+	expected := "Expected " + matcher.describe()
+
+	switch {
+	case matcher.err == nil:
+		return expected + ", but there is no error"
+	case matcher.status == nil:
+		return fmt.Sprintf("%s, but got an error without status: %v", expected, matcher.err)
+	case matcher.status.Reason != matcher.reason:
+		return fmt.Sprintf("%s, but got a %s error:\n%s", expected, reasonOf(matcher.status), dumpStatus(matcher.status))
+	default:
+		return fmt.Sprintf("%s, but there is no cause for %s:\n%s", expected, strings.Join(matcher.missing, ", "), dumpStatus(matcher.status))
+	}
+}
+
+func (matcher *apiStatusMatcher) NegatedFailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected not %s, but got:\n%s", matcher.describe(), dumpStatus(matcher.status))
+}
+
+// describe describes the expected error, i.e. "an Invalid error with causes
+// for spec.replicas".
+func (matcher *apiStatusMatcher) describe() string {
+	article := "a"
+	if strings.ContainsAny(string(matcher.reason[:1]), "AEIOU") {
+		article = "an"
+	}
+
+	msg := fmt.Sprintf("%s %s error", article, matcher.reason)
+
+	if len(matcher.fields) > 0 {
+		msg += " with causes for " + strings.Join(matcher.fields, ", ")
+	}
+
+	return msg
+}
+
+func hasCause(details *metav1.StatusDetails, field string) bool {
+	if details == nil {
+		return false
+	}
+
+	return slices.ContainsFunc(details.Causes, func(c metav1.StatusCause) bool {
+		return c.Field == field
+	})
+}
+
+func reasonOf(status *metav1.Status) string {
+	if status.Reason == metav1.StatusReasonUnknown {
+		return fmt.Sprintf("%d", status.Code)
+	}
+
+	return string(status.Reason)
+}
+
+// dumpStatus renders the given Status as indented YAML, for failure
+// messages.
+func dumpStatus(status *metav1.Status) string {
+	if status == nil {
+		return ""
+	}
+
+	data, err := yaml.Marshal(status)
+	if err != nil {
+		return fmt.Sprintf("%+v", *status)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+
+	return "    " + strings.Join(lines, "\n    ")
+}
@@ -0,0 +1,89 @@
+package k8s_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestAPIStatusMatchers(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	gr := schema.GroupResource{Group: "apps", Resource: "deployments"}
+
+	notFound := apierrors.NewNotFound(gr, "app")
+	conflict := apierrors.NewConflict(gr, "app", errors.New("the object has been modified"))
+	forbidden := apierrors.NewForbidden(gr, "app", errors.New("denied"))
+	invalid := apierrors.NewInvalid(schema.GroupKind{Group: "apps", Kind: "Deployment"}, "app", field.ErrorList{
+		field.Invalid(field.NewPath("spec", "replicas"), -1, "must be greater than or equal to 0"),
+		field.Required(field.NewPath("spec", "selector"), ""),
+	})
+
+	g.Expect(notFound).Should(k8s.BeNotFound())
+	g.Expect(fmt.Errorf("unable to get: %w", notFound)).Should(k8s.BeNotFound())
+	g.Expect(conflict).Should(k8s.BeConflict())
+	g.Expect(forbidden).Should(k8s.BeForbidden())
+	g.Expect(invalid).Should(k8s.BeInvalidWithCauses())
+	g.Expect(invalid).Should(k8s.BeInvalidWithCauses("spec.replicas"))
+	g.Expect(invalid).Should(k8s.BeInvalidWithCauses("spec.replicas", "spec.selector"))
+
+	g.Expect(notFound).ShouldNot(k8s.BeConflict())
+	g.Expect(forbidden).ShouldNot(k8s.BeNotFound())
+	g.Expect(invalid).ShouldNot(k8s.BeInvalidWithCauses("spec.template"))
+	g.Expect(nil).ShouldNot(k8s.BeNotFound())
+	g.Expect(errors.New("not found")).ShouldNot(k8s.BeNotFound())
+
+	// errors returned by the Matcher keep the Status of the API error
+	k := k8s.NewFake().WithContext(t.Context())
+	g.Expect(k.Object(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "missing", Namespace: "ns"}})()).Error().Should(k8s.BeNotFound())
+
+	_, err := k8s.BeNotFound().Match("not found")
+	g.Expect(err).Should(MatchError("an error is expected, got string"))
+}
+
+func TestAPIStatusMatchersFailureMessage(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "app", errors.New("denied"))
+
+	m := k8s.BeNotFound()
+	g.Expect(m.Match(forbidden)).Should(BeFalse())
+	g.Expect(m.FailureMessage(forbidden)).Should(And(
+		HavePrefix("Expected a NotFound error, but got a Forbidden error:\n"),
+		ContainSubstring("    code: 403\n"),
+		ContainSubstring(`    message: 'pods "app" is forbidden: denied'`),
+	))
+	g.Expect(m.Match(nil)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(Equal("Expected a NotFound error, but there is no error"))
+
+	g.Expect(m.Match(errors.New("boom"))).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(Equal("Expected a NotFound error, but got an error without status: boom"))
+
+	invalid := apierrors.NewInvalid(schema.GroupKind{Kind: "Pod"}, "app", field.ErrorList{
+		field.Required(field.NewPath("spec", "containers"), ""),
+	})
+
+	m = k8s.BeInvalidWithCauses("spec.containers", "metadata.name")
+	g.Expect(m.Match(invalid)).Should(BeFalse())
+	g.Expect(m.FailureMessage(invalid)).Should(And(
+		HavePrefix("Expected an Invalid error with causes for spec.containers, metadata.name, but there is no cause for metadata.name:\n"),
+		ContainSubstring("field: spec.containers"),
+	))
+
+	m = k8s.BeForbidden()
+	g.Expect(m.Match(forbidden)).Should(BeTrue())
+	g.Expect(m.NegatedFailureMessage(forbidden)).Should(HavePrefix("Expected not a Forbidden error, but got:\n    code: 403"))
+}
@@ -0,0 +1,159 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/onsi/gomega/types"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ApplyAs applies the given object with server-side apply as the given field
+// manager, taking over the ownership of conflicting fields if force is set.
+// On success, obj is updated with the object returned by the API server. It
+// is meant to simulate several actors applying the same object:
+//
+//	Expect(k.ApplyAs(ctx, desired, "my-controller", false)).To(Succeed())
+//	Expect(k.ApplyAs(ctx, patched, "other-actor", false)).To(k8s.ConflictWith("my-controller"))
+//	Expect(k.ApplyAs(ctx, patched, "other-actor", true)).To(Succeed())
+//
+// Only the fields set on obj are applied, hence typed objects are best
+// created from scratch rather than fetched from the API server. Objects
+// without a name are named by TestName if WithTestNames is set, and deleted
+// at the end of the test if the Matcher has been created by Tracked.
+func (m *Matcher) ApplyAs(ctx context.Context, obj client.Object, fieldManager string, force bool) error {
+	if m.test != nil && obj.GetName() == "" {
+		gvk, err := gvkFor(m.client, obj)
+		if err != nil {
+			return err
+		}
+
+		m.ensureName(obj, gvk.Kind)
+	}
+
+	u, err := m.toUnstructured(obj)
+	if err != nil {
+		return err
+	}
+
+	// fields managed by the API server cannot be applied
+	u.SetManagedFields(nil)
+	u.SetResourceVersion("")
+	unstructured.RemoveNestedField(u.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(u.Object, "status")
+
+	opts := []client.ApplyOption{client.FieldOwner(fieldManager)}
+	if force {
+		opts = append(opts, client.ForceOwnership)
+	}
+
+	if err := m.client.Apply(ctx, client.ApplyConfigurationFromUnstructured(u), opts...); err != nil {
+		return newOpError("apply", u.GroupVersionKind(), client.ObjectKeyFromObject(u), err)
+	}
+
+	m.track(u, u.GroupVersionKind())
+
+	if uo, ok := obj.(*unstructured.Unstructured); ok {
+		uo.Object = u.Object
+
+		return nil
+	}
+
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, obj); err != nil {
+		return &ConversionError{From: "unstructured", To: fmt.Sprintf("%T", obj), Err: err}
+	}
+
+	return nil
+}
+
+// ConflictWith succeeds if the actual error, i.e. the one returned by
+// ApplyAs, reports a server-side apply conflict with the given field manager.
+func ConflictWith(manager string) types.GomegaMatcher {
+	return &conflictMatcher{
+		manager: manager,
+	}
+}
+
+var _ types.GomegaMatcher = &conflictMatcher{}
+
+type conflictMatcher struct {
+	manager string
+	err     error
+	fields  []string
+}
+
+func (matcher *conflictMatcher) Match(actual interface{}) (bool, error) {
+	matcher.err = nil
+	matcher.fields = nil
+
+	if actual == nil {
+		return false, nil
+	}
+
+	err, ok := actual.(error)
+	if !ok {
+		return false, fmt.Errorf("an error is expected, got %T", actual)
+	}
+
+	matcher.err = err
+	matcher.fields = conflictingFields(err, matcher.manager)
+
+	return len(matcher.fields) > 0, nil
+}
+
+func (matcher *conflictMatcher) FailureMessage(_ interface{}) string {
+	if matcher.err == nil {
+		return fmt.Sprintf("Expected apply to conflict with %q, but it succeeded", matcher.manager)
+	}
+
+	return fmt.Sprintf("Expected apply to conflict with %q, but it failed with: %v", matcher.manager, matcher.err)
+}
+
+func (matcher *conflictMatcher) NegatedFailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected apply not to conflict with %q, but it conflicts on %s", matcher.manager, strings.Join(matcher.fields, ", "))
+}
+
+// conflictingFields returns the fields reported by a server-side apply
+// conflict error as owned by the given manager.
+func conflictingFields(err error, manager string) []string {
+	if !apierrors.IsConflict(err) {
+		return nil
+	}
+
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) {
+		return nil
+	}
+
+	details := status.Status().Details
+	if details == nil {
+		return nil
+	}
+
+	var fields []string
+
+	for _, c := range details.Causes {
+		if c.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+
+		// i.e. conflict with "my-controller" using apps/v1
+		if !strings.Contains(c.Message, "conflict with "+strconv.Quote(manager)) {
+			continue
+		}
+
+		if !slices.Contains(fields, c.Field) {
+			fields = append(fields, c.Field)
+		}
+	}
+
+	return fields
+}
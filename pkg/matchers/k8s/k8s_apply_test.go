@@ -0,0 +1,71 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestApplyAs(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	// managedFields are needed to assert the ownership of fields
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithReturnManagedFields().Build()
+	k := k8s.New(cli, scheme)
+
+	cm := func(value string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"},
+			Data:       map[string]string{"key": value},
+		}
+	}
+
+	owned := cm("a")
+	g.Expect(k.ApplyAs(t.Context(), owned, "my-controller", false)).Should(Succeed())
+	g.Expect(owned).Should(k8s.HaveFieldOwnedBy(`.data.key`, "my-controller"))
+
+	// applying the same value is not a conflict
+	g.Expect(k.ApplyAs(t.Context(), cm("a"), "other-actor", false)).Should(Succeed())
+
+	err := k.ApplyAs(t.Context(), cm("b"), "intruder", false)
+	g.Expect(err).Should(k8s.ConflictWith("my-controller"))
+	g.Expect(err).ShouldNot(k8s.ConflictWith("someone-else"))
+
+	m := k8s.ConflictWith("my-controller")
+	g.Expect(m.Match(err)).Should(BeTrue())
+	g.Expect(m.NegatedFailureMessage(err)).Should(Equal(`Expected apply not to conflict with "my-controller", but it conflicts on .data.key`))
+
+	forced := cm("b")
+	g.Expect(k.ApplyAs(t.Context(), forced, "intruder", true)).Should(Succeed())
+	g.Expect(forced.Data).Should(HaveKeyWithValue("key", "b"))
+	g.Expect(forced).Should(k8s.HaveFieldOwnedBy(`.data.key`, "intruder"))
+	g.Expect(forced).ShouldNot(k8s.HaveFieldOwnedBy(`.data.key`, "my-controller"))
+
+	g.Expect(k.ApplyAs(t.Context(), cm("c"), "my-controller", false)).Should(k8s.ConflictWith("intruder"))
+}
+
+func TestConflictWithFailureMessage(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	m := k8s.ConflictWith("my-controller")
+
+	g.Expect(m.Match(nil)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(Equal(`Expected apply to conflict with "my-controller", but it succeeded`))
+
+	_, err := m.Match("boom")
+	g.Expect(err).Should(MatchError("an error is expected, got string"))
+}
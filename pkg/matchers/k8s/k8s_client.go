@@ -0,0 +1,271 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/util/flowcontrol"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Metrics reports the number of API calls performed through a Matcher, keyed
+// by verb (and subresource, if any), i.e. "get", "list", "update/status".
+type Metrics map[string]int
+
+// Total returns the total number of API calls.
+func (m Metrics) Total() int {
+	total := 0
+	for _, v := range m {
+		total += v
+	}
+
+	return total
+}
+
+func (m Metrics) String() string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	items := make([]string, 0, len(keys))
+	for _, k := range keys {
+		items = append(items, fmt.Sprintf("%s=%d", k, m[k]))
+	}
+
+	return strings.Join(items, ", ")
+}
+
+// instrumentation is shared by all the copies of a Matcher and by the clients
+// they wrap, so that rate limits and metrics apply to the Matcher as a whole.
+type instrumentation struct {
+	limiter  flowcontrol.RateLimiter
+	verbose  bool
+	recorder *Recorder
+
+	lock  sync.Mutex
+	calls Metrics
+}
+
+func newInstrumentation() *instrumentation {
+	return &instrumentation{
+		calls: make(Metrics),
+	}
+}
+
+func (in *instrumentation) metrics() Metrics {
+	in.lock.Lock()
+	defer in.lock.Unlock()
+
+	return maps.Clone(in.calls)
+}
+
+func (in *instrumentation) do(ctx context.Context, op Operation, fn func() error) error {
+	if in.limiter != nil {
+		if err := in.limiter.Wait(ctx); err != nil {
+			return fmt.Errorf("rate limiter: %w", err)
+		}
+	}
+
+	in.lock.Lock()
+	in.calls[op.Verb]++
+	in.lock.Unlock()
+
+	start := time.Now()
+	err := fn()
+
+	if in.recorder != nil {
+		op.Time = start
+		op.Latency = time.Since(start)
+		op.Err = err
+
+		in.recorder.Record(op)
+	}
+
+	if err != nil && in.verbose {
+		return fmt.Errorf("%w [api calls: %s]", err, in.metrics())
+	}
+
+	return err
+}
+
+var _ client.Client = &instrumentedClient{}
+
+// instrumentedClient wraps a client.Client to enforce rate limits and keep
+// track of the API calls performed through it.
+type instrumentedClient struct {
+	client.Client
+
+	in      *instrumentation
+	timeout time.Duration
+}
+
+// do performs the given API call through the shared instrumentation, bounding
+// it by the per-call timeout set by Matcher.WithTimeout, if any.
+func (c *instrumentedClient) do(ctx context.Context, op Operation, fn func(ctx context.Context) error) error {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	return c.in.do(ctx, op, func() error {
+		return fn(ctx)
+	})
+}
+
+func (c *instrumentedClient) op(verb string, obj runtime.Object, key client.ObjectKey) Operation {
+	op := Operation{
+		Verb: verb,
+		Key:  key,
+	}
+
+	if obj != nil {
+		// the kind is only informative, hence failing to determine it must
+		// not prevent the call from being performed
+		if gvk, err := c.Client.GroupVersionKindFor(obj); err == nil {
+			op.GVK = gvk
+		}
+	}
+
+	return op
+}
+
+func (c *instrumentedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.do(ctx, c.op("get", obj, key), func(ctx context.Context) error {
+		return c.Client.Get(ctx, key, obj, opts...)
+	})
+}
+
+func (c *instrumentedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.do(ctx, c.op("list", list, client.ObjectKey{}), func(ctx context.Context) error {
+		return c.Client.List(ctx, list, opts...)
+	})
+}
+
+func (c *instrumentedClient) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+	return c.do(ctx, c.op("apply", nil, applyKey(obj)), func(ctx context.Context) error {
+		return c.Client.Apply(ctx, obj, opts...)
+	})
+}
+
+func (c *instrumentedClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	return c.do(ctx, c.op("create", obj, client.ObjectKeyFromObject(obj)), func(ctx context.Context) error {
+		return c.Client.Create(ctx, obj, opts...)
+	})
+}
+
+func (c *instrumentedClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	return c.do(ctx, c.op("delete", obj, client.ObjectKeyFromObject(obj)), func(ctx context.Context) error {
+		return c.Client.Delete(ctx, obj, opts...)
+	})
+}
+
+func (c *instrumentedClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return c.do(ctx, c.op("update", obj, client.ObjectKeyFromObject(obj)), func(ctx context.Context) error {
+		return c.Client.Update(ctx, obj, opts...)
+	})
+}
+
+func (c *instrumentedClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return c.do(ctx, c.op("patch", obj, client.ObjectKeyFromObject(obj)), func(ctx context.Context) error {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	})
+}
+
+func (c *instrumentedClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	return c.do(ctx, c.op("deletecollection", obj, client.ObjectKey{Namespace: obj.GetNamespace()}), func(ctx context.Context) error {
+		return c.Client.DeleteAllOf(ctx, obj, opts...)
+	})
+}
+
+func (c *instrumentedClient) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+func (c *instrumentedClient) SubResource(subResource string) client.SubResourceClient {
+	return &instrumentedSubResourceClient{
+		SubResourceClient: c.Client.SubResource(subResource),
+		parent:            c,
+		subResource:       subResource,
+	}
+}
+
+type instrumentedSubResourceClient struct {
+	client.SubResourceClient
+
+	parent      *instrumentedClient
+	subResource string
+}
+
+func (c *instrumentedSubResourceClient) op(verb string, obj client.Object) Operation {
+	return c.parent.op(verb+"/"+c.subResource, obj, client.ObjectKeyFromObject(obj))
+}
+
+func (c *instrumentedSubResourceClient) Get(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceGetOption) error {
+	return c.parent.do(ctx, c.op("get", obj), func(ctx context.Context) error {
+		return c.SubResourceClient.Get(ctx, obj, subResource, opts...)
+	})
+}
+
+func (c *instrumentedSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	return c.parent.do(ctx, c.op("create", obj), func(ctx context.Context) error {
+		return c.SubResourceClient.Create(ctx, obj, subResource, opts...)
+	})
+}
+
+func (c *instrumentedSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	return c.parent.do(ctx, c.op("update", obj), func(ctx context.Context) error {
+		return c.SubResourceClient.Update(ctx, obj, opts...)
+	})
+}
+
+func (c *instrumentedSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	return c.parent.do(ctx, c.op("patch", obj), func(ctx context.Context) error {
+		return c.SubResourceClient.Patch(ctx, obj, patch, opts...)
+	})
+}
+
+func applyKey(obj runtime.ApplyConfiguration) client.ObjectKey {
+	key := client.ObjectKey{}
+
+	if o, ok := obj.(interface{ GetName() *string }); ok && o.GetName() != nil {
+		key.Name = *o.GetName()
+	}
+
+	if o, ok := obj.(interface{ GetNamespace() *string }); ok && o.GetNamespace() != nil {
+		key.Namespace = *o.GetNamespace()
+	}
+
+	return key
+}
+
+// withWatch adapts the given client to the client.WithWatch interface
+// required by the interceptor package. Clients not supporting watches fail
+// on Watch.
+func withWatch(cli client.Client) client.WithWatch {
+	if w, ok := cli.(client.WithWatch); ok {
+		return w
+	}
+
+	return &noWatchClient{Client: cli}
+}
+
+type noWatchClient struct {
+	client.Client
+}
+
+func (c *noWatchClient) Watch(_ context.Context, _ client.ObjectList, _ ...client.ListOption) (watch.Interface, error) {
+	return nil, errors.New("watch is not supported by the client")
+}
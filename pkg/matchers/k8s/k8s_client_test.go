@@ -0,0 +1,141 @@
+package k8s_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"},
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(cm).
+		Build()
+
+	k := k8s.New(cli, scheme).WithContext(t.Context())
+
+	g.Expect(k.Client().Get(t.Context(), client.ObjectKeyFromObject(cm), &corev1.ConfigMap{})).Should(Succeed())
+	g.Expect(k.Client().Get(t.Context(), client.ObjectKeyFromObject(cm), &corev1.ConfigMap{})).Should(Succeed())
+	g.Expect(k.Client().List(t.Context(), &corev1.ConfigMapList{})).Should(Succeed())
+	g.Expect(k.Live().Client().Update(t.Context(), cm)).Should(Succeed())
+	g.Expect(k.Client().Status().Update(t.Context(), cm)).ShouldNot(Succeed())
+
+	g.Expect(k.Metrics()).Should(Equal(k8s.Metrics{
+		"get":           2,
+		"list":          1,
+		"update":        1,
+		"update/status": 1,
+	}))
+
+	g.Expect(k.Metrics().Total()).Should(Equal(5))
+	g.Expect(k.Metrics().String()).Should(Equal("get=2, list=1, update=1, update/status=1"))
+}
+
+func TestVerbose(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		Build()
+
+	k := k8s.New(cli, scheme, k8s.WithVerbose())
+
+	err := k.Client().Get(t.Context(), client.ObjectKey{Namespace: "ns", Name: "missing"}, &corev1.ConfigMap{})
+	g.Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+	g.Expect(err).Should(MatchError(ContainSubstring("[api calls: get=1]")))
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		Build()
+
+	k := k8s.New(cli, scheme, k8s.WithRateLimit(1, 1))
+
+	g.Expect(k.Client().List(t.Context(), &corev1.ConfigMapList{})).Should(Succeed())
+
+	// the burst has been consumed, so the next call has to wait for a token
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	g.Expect(k.WithContext(ctx).Client().List(ctx, &corev1.ConfigMapList{})).ShouldNot(Succeed())
+	g.Expect(k.Metrics()).Should(Equal(k8s.Metrics{"list": 1}))
+}
+
+func TestInterceptors(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"},
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(cm).
+		Build()
+
+	calls := atomic.Int32{}
+
+	k := k8s.New(cli, scheme, k8s.WithInterceptors(interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if calls.Add(1) <= 2 {
+				return apierrors.NewNotFound(corev1.Resource("configmaps"), key.Name)
+			}
+
+			return c.Get(ctx, key, obj, opts...)
+		},
+	}))
+
+	key := client.ObjectKeyFromObject(cm)
+
+	g.Expect(k.Client().Get(t.Context(), key, &corev1.ConfigMap{})).Should(Satisfy(apierrors.IsNotFound))
+
+	g.Eventually(k.Unstructured().Get("v1/ConfigMap", key)).
+		WithContext(t.Context()).
+		WithPolling(10 * time.Millisecond).
+		ShouldNot(BeNil())
+
+	g.Expect(calls.Load()).Should(BeEquivalentTo(3))
+	g.Expect(k.Metrics()["get"]).Should(Equal(3))
+}
@@ -0,0 +1,159 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HaveCondition succeeds if the .status.conditions of the actual object
+// include a condition of the given type, further constrained by the methods
+// of the returned ConditionMatcher, i.e.:
+//
+//	Eventually(u.Get("apps/v1/Deployment", key)).
+//	    WithContext(ctx).
+//	    Should(k8s.HaveCondition("Available").True().StableFor(30 * time.Second))
+func HaveCondition(conditionType string) *ConditionMatcher {
+	return &ConditionMatcher{
+		conditionType: conditionType,
+	}
+}
+
+var _ types.GomegaMatcher = &ConditionMatcher{}
+
+// ConditionMatcher is the matcher returned by HaveCondition.
+type ConditionMatcher struct {
+	conditionType string
+	status        string
+	reason        string
+	stableFor     time.Duration
+
+	kind    string
+	failure string
+}
+
+// True requires the condition to have status True.
+func (matcher *ConditionMatcher) True() *ConditionMatcher {
+	return matcher.WithStatus(metav1.ConditionTrue)
+}
+
+// False requires the condition to have status False.
+func (matcher *ConditionMatcher) False() *ConditionMatcher {
+	return matcher.WithStatus(metav1.ConditionFalse)
+}
+
+// Unknown requires the condition to have status Unknown.
+func (matcher *ConditionMatcher) Unknown() *ConditionMatcher {
+	return matcher.WithStatus(metav1.ConditionUnknown)
+}
+
+// WithStatus requires the condition to have the given status.
+func (matcher *ConditionMatcher) WithStatus(status metav1.ConditionStatus) *ConditionMatcher {
+	matcher.status = string(status)
+
+	return matcher
+}
+
+// WithReason requires the condition to have the given reason.
+func (matcher *ConditionMatcher) WithReason(reason string) *ConditionMatcher {
+	matcher.reason = reason
+
+	return matcher
+}
+
+// StableFor requires the lastTransitionTime of the condition to be at least
+// the given duration in the past, so that success is not declared on a
+// flapping controller. Conditions without a lastTransitionTime never match.
+func (matcher *ConditionMatcher) StableFor(d time.Duration) *ConditionMatcher {
+	matcher.stableFor = d
+
+	return matcher
+}
+
+func (matcher *ConditionMatcher) Match(actual interface{}) (bool, error) {
+	obj, err := toObject(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.kind = kindOf(obj)
+	matcher.failure = ""
+
+	c, ok := findCondition(obj, matcher.conditionType)
+	if !ok {
+		matcher.failure = fmt.Sprintf("Expected %s to have condition %q, but it has no such condition", matcher.kind, matcher.conditionType)
+
+		return false, nil
+	}
+
+	if matcher.status != "" && c["status"] != matcher.status {
+		matcher.failure = format.Message(c["status"], fmt.Sprintf("to be the status of condition %q of %s, equal to", matcher.conditionType, matcher.kind), matcher.status)
+
+		return false, nil
+	}
+
+	if matcher.reason != "" && c["reason"] != matcher.reason {
+		matcher.failure = format.Message(c["reason"], fmt.Sprintf("to be the reason of condition %q of %s, equal to", matcher.conditionType, matcher.kind), matcher.reason)
+
+		return false, nil
+	}
+
+	if matcher.stableFor <= 0 {
+		return true, nil
+	}
+
+	raw, ok := c["lastTransitionTime"].(string)
+	if !ok || raw == "" {
+		matcher.failure = fmt.Sprintf("Expected condition %q of %s to be stable for %s, but it has no lastTransitionTime", matcher.conditionType, matcher.kind, matcher.stableFor)
+
+		return false, nil
+	}
+
+	transition, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return false, fmt.Errorf("unable to parse the lastTransitionTime of condition %q: %w", matcher.conditionType, err)
+	}
+
+	if elapsed := time.Since(transition); elapsed < matcher.stableFor {
+		matcher.failure = fmt.Sprintf("Expected condition %q of %s to be stable for %s, but it transitioned %s ago, at %s",
+			matcher.conditionType, matcher.kind, matcher.stableFor, elapsed.Round(time.Second), raw)
+
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (matcher *ConditionMatcher) FailureMessage(_ interface{}) string {
+	return matcher.failure
+}
+
+func (matcher *ConditionMatcher) NegatedFailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected %s not to have condition %s", matcher.kind, matcher.describe())
+}
+
+func (matcher *ConditionMatcher) describe() string {
+	constraints := make([]string, 0, 3)
+
+	if matcher.status != "" {
+		constraints = append(constraints, "status "+matcher.status)
+	}
+
+	if matcher.reason != "" {
+		constraints = append(constraints, "reason "+matcher.reason)
+	}
+
+	if matcher.stableFor > 0 {
+		constraints = append(constraints, "stable for "+matcher.stableFor.String())
+	}
+
+	if len(constraints) == 0 {
+		return fmt.Sprintf("%q", matcher.conditionType)
+	}
+
+	return fmt.Sprintf("%q with %s", matcher.conditionType, strings.Join(constraints, ", "))
+}
@@ -0,0 +1,84 @@
+package k8s_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestHaveCondition(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	deploy := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		Status: appsv1.DeploymentStatus{
+			Conditions: []appsv1.DeploymentCondition{{
+				Type:               appsv1.DeploymentAvailable,
+				Status:             corev1.ConditionTrue,
+				Reason:             "MinimumReplicasAvailable",
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+			}, {
+				Type:               appsv1.DeploymentProgressing,
+				Status:             corev1.ConditionTrue,
+				Reason:             "NewReplicaSetAvailable",
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-5 * time.Second)),
+			}},
+		},
+	}
+
+	g.Expect(deploy).Should(k8s.HaveCondition("Available"))
+	g.Expect(deploy).Should(k8s.HaveCondition("Available").True())
+	g.Expect(deploy).Should(k8s.HaveCondition("Available").True().WithReason("MinimumReplicasAvailable"))
+	g.Expect(deploy).Should(k8s.HaveCondition("Available").True().StableFor(30 * time.Second))
+	g.Expect(deploy).ShouldNot(k8s.HaveCondition("Available").False())
+	g.Expect(deploy).ShouldNot(k8s.HaveCondition("Progressing").True().StableFor(30 * time.Second))
+	g.Expect(deploy).ShouldNot(k8s.HaveCondition("ReplicaFailure"))
+
+	g.Expect(`{"kind":"Foo","status":{"conditions":[{"type":"Ready","status":"Unknown"}]}}`).
+		Should(k8s.HaveCondition("Ready").Unknown())
+}
+
+func TestHaveConditionFailureMessage(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	in := `{"kind":"Foo","status":{"conditions":[{"type":"Ready","status":"True","reason":"Done","lastTransitionTime":"` +
+		time.Now().Add(-10*time.Second).UTC().Format(time.RFC3339) + `"}]}}`
+
+	m := k8s.HaveCondition("Synced")
+	g.Expect(m.Match(in)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(Equal(`Expected Foo to have condition "Synced", but it has no such condition`))
+
+	m = k8s.HaveCondition("Ready").False()
+	g.Expect(m.Match(in)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(ContainSubstring(`to be the status of condition "Ready" of Foo, equal to`))
+
+	m = k8s.HaveCondition("Ready").WithReason("Failed")
+	g.Expect(m.Match(in)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(ContainSubstring(`to be the reason of condition "Ready" of Foo, equal to`))
+
+	m = k8s.HaveCondition("Ready").True().StableFor(time.Minute)
+	g.Expect(m.Match(in)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(MatchRegexp(`^Expected condition "Ready" of Foo to be stable for 1m0s, but it transitioned 1\ds ago, at `))
+
+	m = k8s.HaveCondition("Ready").True().StableFor(time.Second)
+	g.Expect(m.Match(in)).Should(BeTrue())
+	g.Expect(m.NegatedFailureMessage(nil)).Should(Equal(`Expected Foo not to have condition "Ready" with status True, stable for 1s`))
+
+	m = k8s.HaveCondition("Ready").StableFor(time.Second)
+	g.Expect(m.Match(`{"kind":"Foo","status":{"conditions":[{"type":"Ready","status":"True"}]}}`)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(HaveSuffix("but it has no lastTransitionTime"))
+
+	_, err := k8s.HaveCondition("Ready").StableFor(time.Second).
+		Match(`{"status":{"conditions":[{"type":"Ready","status":"True","lastTransitionTime":"yesterday"}]}}`)
+	g.Expect(err).Should(MatchError(ContainSubstring("unable to parse the lastTransitionTime")))
+}
@@ -0,0 +1,226 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+// HaveContainer succeeds if the pod spec of the actual object contains a
+// container (or init container) with the given name. It works with Pods and
+// any kind embedding a PodTemplateSpec (i.e. Deployment, StatefulSet,
+// DaemonSet, Job, CronJob), the pod spec is located automatically. Further
+// expectations on the container can be added with WithImage and WithEnv.
+func HaveContainer(name string) *ContainerMatcher {
+	return &ContainerMatcher{
+		name: name,
+	}
+}
+
+var _ types.GomegaMatcher = &ContainerMatcher{}
+
+// ContainerMatcher is the matcher returned by HaveContainer.
+type ContainerMatcher struct {
+	name      string
+	image     types.GomegaMatcher
+	env       []envExpectation
+	resources []resourcesMatcher
+
+	names   []string
+	failure string
+}
+
+type envExpectation struct {
+	name  string
+	value types.GomegaMatcher
+}
+
+// WithImage requires the image of the container to match the given value,
+// which can be either a string or a matcher, i.e. MatchRegexp(`:v1\..*`).
+func (matcher *ContainerMatcher) WithImage(expected any) *ContainerMatcher {
+	matcher.image = toMatcher(expected)
+
+	return matcher
+}
+
+// WithEnv requires the container to define the given environment variable
+// with a value matching the given one, which can be either a string or a
+// matcher.
+func (matcher *ContainerMatcher) WithEnv(name string, expected any) *ContainerMatcher {
+	matcher.env = append(matcher.env, envExpectation{
+		name:  name,
+		value: toMatcher(expected),
+	})
+
+	return matcher
+}
+
+func (matcher *ContainerMatcher) Match(actual interface{}) (bool, error) {
+	obj, err := toObject(actual)
+	if err != nil {
+		return false, err
+	}
+
+	spec, err := podSpec(obj)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.names = nil
+	matcher.failure = ""
+
+	var container map[string]any
+
+	for _, field := range []string{"containers", "initContainers"} {
+		items, _ := spec[field].([]any)
+
+		for i := range items {
+			c, ok := items[i].(map[string]any)
+			if !ok {
+				continue
+			}
+
+			n, _ := c["name"].(string)
+			matcher.names = append(matcher.names, n)
+
+			if n == matcher.name && container == nil {
+				container = c
+			}
+		}
+	}
+
+	if container == nil {
+		matcher.failure = format.Message(matcher.names, "to contain container", matcher.name)
+
+		return false, nil
+	}
+
+	if matcher.image != nil {
+		ok, err := matcher.image.Match(container["image"])
+		if err != nil {
+			return false, err
+		}
+
+		if !ok {
+			matcher.failure = fmt.Sprintf("Expected image of container %s to match:\n%s", matcher.name, matcher.image.FailureMessage(container["image"]))
+
+			return false, nil
+		}
+	}
+
+	ok, err := matcher.matchEnv(container)
+	if err != nil || !ok {
+		return ok, err
+	}
+
+	return matcher.matchResources(container)
+}
+
+func (matcher *ContainerMatcher) matchResources(container map[string]any) (bool, error) {
+	for i := range matcher.resources {
+		r := &matcher.resources[i]
+
+		actual, err := containerResources(container, r.field)
+		if err != nil {
+			return false, err
+		}
+
+		r.actual = actual
+
+		if !r.matches() {
+			matcher.failure = fmt.Sprintf("Expected container %s to match:\n%s", matcher.name, r.FailureMessage(nil))
+
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (matcher *ContainerMatcher) matchEnv(container map[string]any) (bool, error) {
+	env, _ := container["env"].([]any)
+
+	for _, e := range matcher.env {
+		var value any
+
+		found := false
+
+		for i := range env {
+			v, ok := env[i].(map[string]any)
+			if ok && v["name"] == e.name {
+				value, found = v["value"], true
+
+				break
+			}
+		}
+
+		if !found {
+			matcher.failure = fmt.Sprintf("Expected container %s to define environment variable %s, but it defines:\n%s",
+				matcher.name, e.name, format.Object(envNames(env), 1))
+
+			return false, nil
+		}
+
+		ok, err := e.value.Match(value)
+		if err != nil {
+			return false, err
+		}
+
+		if !ok {
+			matcher.failure = fmt.Sprintf("Expected environment variable %s of container %s to match:\n%s",
+				e.name, matcher.name, e.value.FailureMessage(value))
+
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (matcher *ContainerMatcher) FailureMessage(_ interface{}) string {
+	return matcher.failure
+}
+
+func (matcher *ContainerMatcher) NegatedFailureMessage(_ interface{}) string {
+	expectations := []string{"container " + matcher.name}
+
+	if matcher.image != nil {
+		expectations = append(expectations, "a matching image")
+	}
+
+	for _, e := range matcher.env {
+		expectations = append(expectations, "a matching environment variable "+e.name)
+	}
+
+	for _, r := range matcher.resources {
+		expectations = append(expectations, "matching resource "+r.field)
+	}
+
+	return format.Message(matcher.names, "not to contain "+strings.Join(expectations, ", with "))
+}
+
+func envNames(env []any) []string {
+	names := make([]string, 0, len(env))
+
+	for i := range env {
+		if v, ok := env[i].(map[string]any); ok {
+			n, _ := v["name"].(string)
+			names = append(names, n)
+		}
+	}
+
+	return names
+}
+
+// toMatcher returns the given value if it is a matcher, an Equal matcher
+// otherwise, following the convention of the built-in Gomega matchers.
+func toMatcher(expected any) types.GomegaMatcher {
+	if m, ok := expected.(types.GomegaMatcher); ok {
+		return m
+	}
+
+	return gomega.Equal(expected)
+}
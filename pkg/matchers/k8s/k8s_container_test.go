@@ -0,0 +1,80 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestHaveContainer(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	spec := corev1.PodSpec{
+		InitContainers: []corev1.Container{
+			{Name: "init", Image: "busybox"},
+		},
+		Containers: []corev1.Container{
+			{
+				Name:  "manager",
+				Image: "quay.io/example/manager:v1.2.3",
+				Env: []corev1.EnvVar{
+					{Name: "LOG_LEVEL", Value: "debug"},
+				},
+			},
+		},
+	}
+
+	objects := map[string]any{
+		"pod":         &corev1.Pod{Spec: spec},
+		"deployment":  &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: spec}}},
+		"statefulset": &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{Template: corev1.PodTemplateSpec{Spec: spec}}},
+		"cronjob": &batchv1.CronJob{
+			TypeMeta: metav1.TypeMeta{APIVersion: "batch/v1", Kind: "CronJob"},
+			Spec: batchv1.CronJobSpec{
+				JobTemplate: batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: spec}}},
+			},
+		},
+	}
+
+	for name, obj := range objects {
+		g.Expect(obj).Should(k8s.HaveContainer("manager"), name)
+		g.Expect(obj).Should(k8s.HaveContainer("init"), name)
+		g.Expect(obj).ShouldNot(k8s.HaveContainer("sidecar"), name)
+
+		g.Expect(obj).Should(
+			k8s.HaveContainer("manager").
+				WithImage(MatchRegexp(`:v1\..*`)).
+				WithEnv("LOG_LEVEL", "debug"),
+			name,
+		)
+	}
+
+	pod := objects["pod"]
+
+	m := k8s.HaveContainer("sidecar")
+	g.Expect(m.Match(pod)).Should(BeFalse())
+	g.Expect(m.FailureMessage(pod)).Should(And(ContainSubstring("manager"), ContainSubstring("init")))
+
+	m = k8s.HaveContainer("manager").WithImage("quay.io/example/manager:v2")
+	g.Expect(m.Match(pod)).Should(BeFalse())
+	g.Expect(m.FailureMessage(pod)).Should(ContainSubstring("Expected image of container manager to match"))
+
+	m = k8s.HaveContainer("manager").WithEnv("LOG_LEVEL", "info")
+	g.Expect(m.Match(pod)).Should(BeFalse())
+	g.Expect(m.FailureMessage(pod)).Should(ContainSubstring("Expected environment variable LOG_LEVEL of container manager to match"))
+
+	m = k8s.HaveContainer("manager").WithEnv("NAMESPACE", "default")
+	g.Expect(m.Match(pod)).Should(BeFalse())
+	g.Expect(m.FailureMessage(pod)).Should(ContainSubstring("to define environment variable NAMESPACE"))
+
+	_, err := k8s.HaveContainer("manager").Match(&corev1.ConfigMap{})
+	g.Expect(err).Should(MatchError(ContainSubstring("unable to find a pod spec")))
+}
@@ -0,0 +1,108 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// ToUnstructured converts the given typed object to its unstructured
+// representation, filling in the apiVersion and kind that typed objects
+// returned by clients usually lack from the given scheme, so that objects not
+// fetched through a Matcher, i.e. from the fake client of a controller under
+// test, can be fed to jq.Match:
+//
+//	u, err := k8s.ToUnstructured(&deploy, scheme)
+func ToUnstructured(obj client.Object, scheme *runtime.Scheme) (*unstructured.Unstructured, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return u.DeepCopy(), nil
+	}
+
+	return toUnstructured(obj, schemeGVK(scheme))
+}
+
+// ToUnstructuredList is like ToUnstructured, but converts a typed list, whose
+// items are stamped with their apiVersion and kind as well.
+func ToUnstructuredList(list client.ObjectList, scheme *runtime.Scheme) (*unstructured.UnstructuredList, error) {
+	if u, ok := list.(*unstructured.UnstructuredList); ok {
+		return u.DeepCopy(), nil
+	}
+
+	u, err := toUnstructured(list, schemeGVK(scheme))
+	if err != nil {
+		return nil, err
+	}
+
+	items, _ := u.Object["items"].([]any)
+
+	result := unstructured.UnstructuredList{
+		Items: make([]unstructured.Unstructured, 0, len(items)),
+	}
+
+	for i := range items {
+		item, ok := items[i].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unable to convert item %d of %s: unexpected type %T", i, u.GetKind(), items[i])
+		}
+
+		result.Items = append(result.Items, unstructured.Unstructured{Object: item})
+	}
+
+	delete(u.Object, "items")
+	result.Object = u.Object
+
+	return &result, nil
+}
+
+func schemeGVK(scheme *runtime.Scheme) func(obj runtime.Object) (schema.GroupVersionKind, error) {
+	return func(obj runtime.Object) (schema.GroupVersionKind, error) {
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil {
+			return schema.GroupVersionKind{}, &NoGVKError{Resource: fmt.Sprintf("%T", obj), Err: err}
+		}
+
+		return gvk, nil
+	}
+}
+
+// toUnstructured converts the given typed object to its unstructured
+// representation, resolving its apiVersion and kind with gvkFn when missing.
+// The items of lists are stamped too.
+func toUnstructured(obj runtime.Object, gvkFn func(runtime.Object) (schema.GroupVersionKind, error)) (*unstructured.Unstructured, error) {
+	data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, &ConversionError{From: fmt.Sprintf("%T", obj), To: "unstructured", Err: err}
+	}
+
+	u := unstructured.Unstructured{Object: data}
+
+	if u.GetKind() == "" {
+		gvk, err := gvkFn(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		u.SetGroupVersionKind(gvk)
+	}
+
+	if items, ok := data["items"].([]any); ok {
+		gvk := u.GroupVersionKind()
+
+		for i := range items {
+			item, ok := items[i].(map[string]any)
+			if !ok || item["kind"] != nil {
+				continue
+			}
+
+			item["apiVersion"] = gvk.GroupVersion().String()
+			item["kind"] = strings.TrimSuffix(gvk.Kind, "List")
+		}
+	}
+
+	return &u, nil
+}
@@ -0,0 +1,86 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestToUnstructured(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	deploy := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptrTo[int32](3)},
+	}
+
+	u, err := k8s.ToUnstructured(&deploy, scheme)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(u.GetAPIVersion()).Should(Equal("apps/v1"))
+	g.Expect(u.GetKind()).Should(Equal("Deployment"))
+
+	// the original object is left alone
+	g.Expect(deploy.Kind).Should(BeEmpty())
+
+	same, err := k8s.ToUnstructured(u, scheme)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(same).Should(Equal(u))
+	g.Expect(same).ShouldNot(BeIdenticalTo(u))
+
+	g.Expect(u).Should(jq.Match(`.spec.replicas == 3`))
+
+	_, err = k8s.ToUnstructured(&deploy, runtime.NewScheme())
+	g.Expect(err).Should(MatchError(&k8s.NoGVKError{}))
+}
+
+func TestToUnstructuredList(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	list := corev1.ConfigMapList{
+		Items: []corev1.ConfigMap{
+			{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ns"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "ns"}},
+		},
+	}
+
+	u, err := k8s.ToUnstructuredList(&list, scheme)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(u.GetKind()).Should(Equal("ConfigMapList"))
+	g.Expect(u.Items).Should(HaveLen(2))
+	g.Expect(u.Items).Should(HaveEach(And(
+		HaveField("Object", HaveKeyWithValue("apiVersion", "v1")),
+		HaveField("Object", HaveKeyWithValue("kind", "ConfigMap")),
+	)))
+	g.Expect(u.Items[1].GetName()).Should(Equal("b"))
+
+	empty, err := k8s.ToUnstructuredList(&corev1.ConfigMapList{}, scheme)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(empty.Items).Should(BeEmpty())
+
+	same, err := k8s.ToUnstructuredList(u, scheme)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(same).Should(Equal(u))
+	g.Expect(same).ShouldNot(BeIdenticalTo(u))
+
+	_, err = k8s.ToUnstructuredList(&unstructured.UnstructuredList{}, scheme)
+	g.Expect(err).ShouldNot(HaveOccurred())
+}
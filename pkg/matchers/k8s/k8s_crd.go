@@ -0,0 +1,104 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	conditionEstablished   = "Established"
+	conditionNamesAccepted = "NamesAccepted"
+)
+
+//nolint:gochecknoglobals
+var crdGVK = schema.GroupVersionKind{
+	Group:   "apiextensions.k8s.io",
+	Version: "v1",
+	Kind:    "CustomResourceDefinition",
+}
+
+// CRD returns a pollable function fetching the CustomResourceDefinition with
+// the given name.
+func (m *Matcher) CRD(name string) func(ctx context.Context) (*unstructured.Unstructured, error) {
+	return func(ctx context.Context) (*unstructured.Unstructured, error) {
+		crd := unstructured.Unstructured{}
+		crd.SetGroupVersionKind(crdGVK)
+
+		if err := m.client.Get(ctx, client.ObjectKey{Name: name}, &crd); err != nil {
+			return nil, newOpError("get", crdGVK, client.ObjectKey{Name: name}, err)
+		}
+
+		return &crd, nil
+	}
+}
+
+// CRDEstablished returns a pollable function that succeeds once the
+// CustomResourceDefinition with the given name reports both the Established
+// and NamesAccepted conditions, so it can be used as:
+//
+//	Eventually(k.CRDEstablished("foos.example.com")).WithContext(ctx).Should(Succeed())
+func (m *Matcher) CRDEstablished(name string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		crd, err := m.CRD(name)(ctx)
+		if err != nil {
+			return err
+		}
+
+		matcher := BeEstablished()
+
+		ok, err := matcher.Match(crd)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return fmt.Errorf("CustomResourceDefinition %s is not established", name)
+		}
+
+		return nil
+	}
+}
+
+// BeEstablished succeeds if the actual CustomResourceDefinition reports both
+// the Established and NamesAccepted conditions with status True.
+func BeEstablished() types.GomegaMatcher {
+	return &establishedMatcher{}
+}
+
+var _ types.GomegaMatcher = &establishedMatcher{}
+
+type establishedMatcher struct {
+	conditions []any
+}
+
+func (matcher *establishedMatcher) Match(actual interface{}) (bool, error) {
+	obj, err := toObject(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.conditions, _, _ = unstructured.NestedSlice(obj, "status", "conditions")
+
+	for _, t := range []string{conditionEstablished, conditionNamesAccepted} {
+		c, ok := findCondition(obj, t)
+		if !ok || c["status"] != "True" {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (matcher *establishedMatcher) FailureMessage(_ interface{}) string {
+	return format.Message(matcher.conditions, "to contain the Established and NamesAccepted conditions with status True")
+}
+
+func (matcher *establishedMatcher) NegatedFailureMessage(_ interface{}) string {
+	return format.Message(matcher.conditions, "not to contain the Established and NamesAccepted conditions with status True")
+}
@@ -0,0 +1,61 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func newCRD(name string, conditions ...apiextensionsv1.CustomResourceDefinitionCondition) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{
+			Conditions: conditions,
+		},
+	}
+}
+
+func TestCRDEstablished(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(apiextensionsv1.AddToScheme(scheme)).Should(Succeed())
+
+	established := newCRD("foos.example.com",
+		apiextensionsv1.CustomResourceDefinitionCondition{Type: apiextensionsv1.Established, Status: apiextensionsv1.ConditionTrue},
+		apiextensionsv1.CustomResourceDefinitionCondition{Type: apiextensionsv1.NamesAccepted, Status: apiextensionsv1.ConditionTrue},
+	)
+
+	pending := newCRD("bars.example.com",
+		apiextensionsv1.CustomResourceDefinitionCondition{Type: apiextensionsv1.Established, Status: apiextensionsv1.ConditionFalse},
+		apiextensionsv1.CustomResourceDefinitionCondition{Type: apiextensionsv1.NamesAccepted, Status: apiextensionsv1.ConditionTrue},
+	)
+
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(established, pending).Build()
+	k := k8s.New(cli, scheme)
+
+	g.Expect(k.CRDEstablished("foos.example.com")(t.Context())).Should(Succeed())
+	g.Expect(k.CRDEstablished("bars.example.com")(t.Context())).ShouldNot(Succeed())
+	g.Expect(k.CRDEstablished("bazs.example.com")(t.Context())).ShouldNot(Succeed())
+
+	g.Eventually(k.CRD("foos.example.com")).
+		WithContext(t.Context()).
+		Should(k8s.BeEstablished())
+	g.Eventually(k.CRD("bars.example.com")).
+		WithContext(t.Context()).
+		ShouldNot(k8s.BeEstablished())
+
+	g.Expect(established).Should(k8s.BeEstablished())
+	g.Expect(pending).ShouldNot(k8s.BeEstablished())
+	g.Expect(newCRD("bazs.example.com")).ShouldNot(k8s.BeEstablished())
+}
@@ -0,0 +1,127 @@
+package k8s
+
+import (
+	"encoding/base64"
+	"fmt"
+	"slices"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	kindSecret    = "Secret"
+	kindConfigMap = "ConfigMap"
+)
+
+// HaveDataKey succeeds if the actual Secret or ConfigMap contains the given
+// key, with a value matching the expected one, which can be either a string
+// or a matcher. Secret data and ConfigMap binaryData are base64-decoded
+// before being matched, Secret stringData and ConfigMap data are matched as
+// they are.
+func HaveDataKey(key string, expected any) types.GomegaMatcher {
+	return &dataKeyMatcher{
+		key:      key,
+		expected: toMatcher(expected),
+	}
+}
+
+var _ types.GomegaMatcher = &dataKeyMatcher{}
+
+type dataKeyMatcher struct {
+	key      string
+	expected types.GomegaMatcher
+
+	keys  []string
+	found bool
+	value string
+}
+
+func (matcher *dataKeyMatcher) Match(actual interface{}) (bool, error) {
+	obj, err := toObject(actual)
+	if err != nil {
+		return false, err
+	}
+
+	kind := kindOf(obj)
+
+	// typed objects do not necessarily carry their kind
+	switch actual.(type) {
+	case *corev1.Secret:
+		kind = kindSecret
+	case *corev1.ConfigMap:
+		kind = kindConfigMap
+	}
+
+	fields := map[string]bool{
+		"data":       kind == kindSecret,
+		"stringData": false,
+		"binaryData": true,
+	}
+
+	matcher.keys = nil
+	matcher.found = false
+
+	for _, field := range []string{"data", "stringData", "binaryData"} {
+		values, _, err := unstructured.NestedMap(obj, field)
+		if err != nil {
+			return false, fmt.Errorf("unable to read .%s: %w", field, err)
+		}
+
+		for k, v := range values {
+			matcher.keys = append(matcher.keys, k)
+
+			if k != matcher.key || matcher.found {
+				continue
+			}
+
+			value, err := decodeData(v, fields[field])
+			if err != nil {
+				return false, fmt.Errorf("unable to decode .%s.%s: %w", field, k, err)
+			}
+
+			matcher.found = true
+			matcher.value = value
+		}
+	}
+
+	slices.Sort(matcher.keys)
+
+	if !matcher.found {
+		return false, nil
+	}
+
+	return matcher.expected.Match(matcher.value)
+}
+
+func (matcher *dataKeyMatcher) FailureMessage(_ interface{}) string {
+	if !matcher.found {
+		return format.Message(matcher.keys, "to contain key", matcher.key)
+	}
+
+	return fmt.Sprintf("Expected value of key %s to match:\n%s", matcher.key, matcher.expected.FailureMessage(matcher.value))
+}
+
+func (matcher *dataKeyMatcher) NegatedFailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected value of key %s not to match:\n%s", matcher.key, matcher.expected.NegatedFailureMessage(matcher.value))
+}
+
+func decodeData(in any, encoded bool) (string, error) {
+	value, ok := in.(string)
+	if !ok {
+		return "", fmt.Errorf("a string is expected, got:\n%s", format.Object(in, 1))
+	}
+
+	if !encoded {
+		return value, nil
+	}
+
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid base64 value: %w", err)
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,49 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestHaveDataKey(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	secret := corev1.Secret{
+		Data:       map[string][]byte{"password": []byte("s3cr3t")},
+		StringData: map[string]string{"user": "admin"},
+	}
+
+	g.Expect(&secret).Should(k8s.HaveDataKey("password", "s3cr3t"))
+	g.Expect(&secret).Should(k8s.HaveDataKey("password", HavePrefix("s3")))
+	g.Expect(&secret).Should(k8s.HaveDataKey("user", "admin"))
+	g.Expect(&secret).ShouldNot(k8s.HaveDataKey("password", "other"))
+	g.Expect(&secret).ShouldNot(k8s.HaveDataKey("token", "s3cr3t"))
+
+	// as returned by the API server
+	g.Expect(`{"kind":"Secret","data":{"password":"czNjcjN0"}}`).Should(k8s.HaveDataKey("password", "s3cr3t"))
+
+	cm := corev1.ConfigMap{
+		Data:       map[string]string{"config.yaml": "level: debug"},
+		BinaryData: map[string][]byte{"blob": []byte("binary")},
+	}
+
+	g.Expect(&cm).Should(k8s.HaveDataKey("config.yaml", ContainSubstring("debug")))
+	g.Expect(&cm).Should(k8s.HaveDataKey("blob", "binary"))
+
+	m := k8s.HaveDataKey("token", "foo")
+	g.Expect(m.Match(&secret)).Should(BeFalse())
+	g.Expect(m.FailureMessage(&secret)).Should(And(ContainSubstring("password"), ContainSubstring("user")))
+
+	m = k8s.HaveDataKey("password", "foo")
+	g.Expect(m.Match(&secret)).Should(BeFalse())
+	g.Expect(m.FailureMessage(&secret)).Should(ContainSubstring("Expected value of key password to match"))
+
+	_, err := k8s.HaveDataKey("password", "foo").Match(`{"kind":"Secret","data":{"password":"$$$"}}`)
+	g.Expect(err).Should(MatchError(ContainSubstring("unable to decode .data.password")))
+}
@@ -0,0 +1,75 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// As decodes the given unstructured object into a new instance of the typed
+// object T, which must be a pointer, i.e.:
+//
+//	deploy, err := k8s.As[*appsv1.Deployment](u)
+func As[T runtime.Object](u *unstructured.Unstructured) (T, error) {
+	if u == nil {
+		var zero T
+
+		return zero, errors.New("a Kubernetes object is expected, got nil")
+	}
+
+	return decode[T](u.Object)
+}
+
+// DecodeInto returns a transform decoding its input, an unstructured object
+// or any value accepted by the matchers of this package, into a new instance
+// of the typed object T, so that typed fields can be asserted after jq based
+// assertions, i.e.:
+//
+//	Eventually(k8s.Poll(u.Get("deploy", key), k8s.DecodeInto[*appsv1.Deployment]())).
+//	    WithContext(ctx).
+//	    Should(HaveField("Spec.Replicas", HaveValue(Equal(int32(3)))))
+func DecodeInto[T runtime.Object]() Transform {
+	return func(in any) (any, error) {
+		obj, err := toObject(in)
+		if err != nil {
+			return nil, err
+		}
+
+		return decode[T](obj)
+	}
+}
+
+func decode[T runtime.Object](obj map[string]any) (T, error) {
+	var zero T
+
+	out, err := newObject[T]()
+	if err != nil {
+		return zero, err
+	}
+
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, out); err != nil {
+		return zero, &ConversionError{From: "unstructured", To: fmt.Sprintf("%T", out), Err: err}
+	}
+
+	return out, nil
+}
+
+// newObject creates a new instance of the object T, which must be a pointer.
+func newObject[T runtime.Object]() (T, error) {
+	var zero T
+
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Pointer {
+		return zero, fmt.Errorf("a pointer type is expected, got %s", t)
+	}
+
+	out, ok := reflect.New(t.Elem()).Interface().(T)
+	if !ok {
+		return zero, fmt.Errorf("unable to create an instance of %s", t)
+	}
+
+	return out, nil
+}
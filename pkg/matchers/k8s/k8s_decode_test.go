@@ -0,0 +1,81 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestAs(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	u := unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": "app", "namespace": "ns"},
+		"spec":       map[string]any{"replicas": int64(3)},
+	}}
+
+	deploy, err := k8s.As[*appsv1.Deployment](&u)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(deploy.Name).Should(Equal("app"))
+	g.Expect(deploy.Spec.Replicas).Should(HaveValue(Equal(int32(3))))
+
+	_, err = k8s.As[*appsv1.Deployment](nil)
+	g.Expect(err).Should(HaveOccurred())
+
+	u.Object["spec"] = map[string]any{"replicas": "three"}
+
+	_, err = k8s.As[*appsv1.Deployment](&u)
+	g.Expect(err).Should(MatchError(ContainSubstring("unable to convert unstructured to *v1.Deployment")))
+	g.Expect(err).Should(MatchError(&k8s.ConversionError{}))
+}
+
+func TestDecodeInto(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"},
+			Data:       map[string]string{"foo": "bar"},
+		}).
+		Build()
+
+	u := k8s.New(cli, scheme).Unstructured()
+	key := client.ObjectKey{Namespace: "ns", Name: "cm"}
+
+	g.Eventually(k8s.Poll(u.Get("v1/ConfigMap", key), k8s.DecodeInto[*corev1.ConfigMap]())).
+		WithContext(t.Context()).
+		Should(And(
+			BeAssignableToTypeOf(&corev1.ConfigMap{}),
+			HaveField("Data", HaveKeyWithValue("foo", "bar")),
+		))
+
+	g.Expect(`{"data":{"foo":"bar"}}`).Should(
+		WithTransform(k8s.DecodeInto[*corev1.ConfigMap](), HaveField("Data", HaveLen(1))),
+	)
+
+	g.Expect(u.Get("v1/ConfigMap", key)(t.Context())).Should(And(
+		jq.Match(`.data.foo == "bar"`),
+		WithTransform(k8s.DecodeInto[*corev1.ConfigMap](), HaveField("Name", "cm")),
+	))
+}
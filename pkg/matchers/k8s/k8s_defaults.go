@@ -0,0 +1,173 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// EqualIgnoringDefaults succeeds if the actual object equals the expected
+// one once the fields whose value equals the default declared by the given
+// structural schema, i.e. the OpenAPIV3Schema of a CRD version, have been
+// removed from both, so that server-side defaulting does not trip "did the
+// controller change anything?" assertions:
+//
+//	spec := crd.Spec.Versions[0].Schema.OpenAPIV3Schema.Properties["spec"]
+//	Expect(actual).Should(WithTransform(jq.Extract(`.spec`), k8s.EqualIgnoringDefaults(expected, &spec)))
+//
+// Objects left empty once their defaulted fields have been removed are
+// removed too. Fields set by the API server regardless of the schema, i.e.
+// .metadata.resourceVersion, are not ignored, hence comparing .spec alone is
+// usually what is needed.
+func EqualIgnoringDefaults(expected any, schema *apiextensionsv1.JSONSchemaProps) types.GomegaMatcher {
+	return &defaultsMatcher{
+		expected: expected,
+		schema:   schema,
+	}
+}
+
+var _ types.GomegaMatcher = &defaultsMatcher{}
+
+type defaultsMatcher struct {
+	expected any
+	schema   *apiextensionsv1.JSONSchemaProps
+
+	actualStripped   any
+	expectedStripped any
+}
+
+func (matcher *defaultsMatcher) Match(actual interface{}) (bool, error) {
+	a, err := normalizeDefaulted(actual)
+	if err != nil {
+		return false, fmt.Errorf("unable to convert actual: %w", err)
+	}
+
+	e, err := normalizeDefaulted(matcher.expected)
+	if err != nil {
+		return false, fmt.Errorf("unable to convert expected: %w", err)
+	}
+
+	if matcher.actualStripped, err = stripDefaults(a, matcher.schema); err != nil {
+		return false, err
+	}
+
+	if matcher.expectedStripped, err = stripDefaults(e, matcher.schema); err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(matcher.actualStripped, matcher.expectedStripped), nil
+}
+
+func (matcher *defaultsMatcher) FailureMessage(_ interface{}) string {
+	return format.Message(matcher.actualStripped, "to equal, ignoring defaults,", matcher.expectedStripped)
+}
+
+func (matcher *defaultsMatcher) NegatedFailureMessage(_ interface{}) string {
+	return format.Message(matcher.actualStripped, "not to equal, ignoring defaults,", matcher.expectedStripped)
+}
+
+// normalizeDefaulted converts the given value to its JSON representation, as
+// defaults are, so that they can be compared regardless of the Go types, i.e.
+// int64 and float64.
+func normalizeDefaulted(in any) (any, error) {
+	switch in.(type) {
+	case string, []byte, json.RawMessage:
+		obj, err := toObject(in)
+		if err != nil {
+			return nil, err
+		}
+
+		in = obj
+	}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// stripDefaults returns a copy of the given value without the fields equal
+// to the defaults of the schema.
+//
+//nolint:cyclop
+func stripDefaults(in any, schema *apiextensionsv1.JSONSchemaProps) (any, error) {
+	if schema == nil {
+		return in, nil
+	}
+
+	switch v := in.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+
+		for key, value := range v {
+			prop, ok := schema.Properties[key]
+			if !ok {
+				if schema.AdditionalProperties == nil || schema.AdditionalProperties.Schema == nil {
+					out[key] = value
+
+					continue
+				}
+
+				prop = *schema.AdditionalProperties.Schema
+			}
+
+			if prop.Default != nil {
+				var def any
+				if err := json.Unmarshal(prop.Default.Raw, &def); err != nil {
+					return nil, fmt.Errorf("unable to decode the default of %s: %w", key, err)
+				}
+
+				if reflect.DeepEqual(value, def) {
+					continue
+				}
+			}
+
+			stripped, err := stripDefaults(value, &prop)
+			if err != nil {
+				return nil, err
+			}
+
+			// objects only holding defaulted fields have likely been
+			// created by defaulting as well
+			if m, ok := stripped.(map[string]any); ok && len(m) == 0 {
+				if orig, ok := value.(map[string]any); ok && len(orig) > 0 {
+					continue
+				}
+			}
+
+			out[key] = stripped
+		}
+
+		return out, nil
+	case []any:
+		if schema.Items == nil || schema.Items.Schema == nil {
+			return v, nil
+		}
+
+		out := make([]any, 0, len(v))
+
+		for i := range v {
+			stripped, err := stripDefaults(v[i], schema.Items.Schema)
+			if err != nil {
+				return nil, err
+			}
+
+			out = append(out, stripped)
+		}
+
+		return out, nil
+	default:
+		return in, nil
+	}
+}
@@ -0,0 +1,103 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestEqualIgnoringDefaults(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	schema := &apiextensionsv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"replicas": {
+				Type:    "integer",
+				Default: &apiextensionsv1.JSON{Raw: []byte(`1`)},
+			},
+			"image": {
+				Type: "string",
+			},
+			"strategy": {
+				Type: "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"type": {
+						Type:    "string",
+						Default: &apiextensionsv1.JSON{Raw: []byte(`"RollingUpdate"`)},
+					},
+				},
+			},
+			"ports": {
+				Type: "array",
+				Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{
+					Type: "object",
+					Properties: map[string]apiextensionsv1.JSONSchemaProps{
+						"port":     {Type: "integer"},
+						"protocol": {Type: "string", Default: &apiextensionsv1.JSON{Raw: []byte(`"TCP"`)}},
+					},
+				}},
+			},
+			"labels": {
+				Type: "object",
+				AdditionalProperties: &apiextensionsv1.JSONSchemaPropsOrBool{Schema: &apiextensionsv1.JSONSchemaProps{
+					Type:    "string",
+					Default: &apiextensionsv1.JSON{Raw: []byte(`"none"`)},
+				}},
+			},
+		},
+	}
+
+	expected := map[string]any{
+		"image": "app:1",
+		"ports": []any{map[string]any{"port": 8080}},
+	}
+
+	defaulted := unstructured.Unstructured{Object: map[string]any{
+		"image":    "app:1",
+		"replicas": int64(1),
+		"strategy": map[string]any{"type": "RollingUpdate"},
+		"ports":    []any{map[string]any{"port": int64(8080), "protocol": "TCP"}},
+		"labels":   map[string]any{"tier": "none"},
+	}}
+
+	g.Expect(&defaulted).Should(k8s.EqualIgnoringDefaults(expected, schema))
+	g.Expect(`{"image":"app:1","replicas":1,"ports":[{"port":8080}]}`).Should(k8s.EqualIgnoringDefaults(expected, schema))
+
+	g.Expect(`{"image":"app:1","replicas":2,"ports":[{"port":8080}]}`).ShouldNot(k8s.EqualIgnoringDefaults(expected, schema))
+	g.Expect(`{"image":"app:1","strategy":{"type":"Recreate"},"ports":[{"port":8080}]}`).ShouldNot(k8s.EqualIgnoringDefaults(expected, schema))
+	g.Expect(`{"image":"app:1","ports":[{"port":8080,"protocol":"UDP"}]}`).ShouldNot(k8s.EqualIgnoringDefaults(expected, schema))
+
+	// without a schema, nothing is ignored
+	g.Expect(&defaulted).ShouldNot(k8s.EqualIgnoringDefaults(expected, nil))
+}
+
+func TestEqualIgnoringDefaultsFailureMessage(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	schema := &apiextensionsv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"replicas": {Type: "integer", Default: &apiextensionsv1.JSON{Raw: []byte(`1`)}},
+		},
+	}
+
+	m := k8s.EqualIgnoringDefaults(`{"image":"app:1"}`, schema)
+	g.Expect(m.Match(`{"image":"app:2","replicas":1}`)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(And(
+		ContainSubstring("to equal, ignoring defaults,"),
+		ContainSubstring("app:2"),
+		Not(ContainSubstring("replicas")),
+	))
+
+	_, err := k8s.EqualIgnoringDefaults(`{}`, schema).Match(`{"replicas":`)
+	g.Expect(err).Should(MatchError(ContainSubstring("unable to convert actual")))
+}
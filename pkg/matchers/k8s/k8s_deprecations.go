@@ -0,0 +1,198 @@
+package k8s
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// deprecatedAPI describes an API version of a kind that has been deprecated,
+// and possibly removed, in favor of another one.
+type deprecatedAPI struct {
+	apiVersion   string
+	kinds        []string
+	deprecatedIn kubeVersion
+	removedIn    kubeVersion
+	replacement  string
+}
+
+// deprecatedAPIs lists the API versions deprecated by upstream Kubernetes,
+// see https://kubernetes.io/docs/reference/using-api/deprecation-guide/.
+//
+//nolint:gochecknoglobals
+var deprecatedAPIs = []deprecatedAPI{
+	{"extensions/v1beta1", []string{"Deployment", "DaemonSet", "ReplicaSet"}, kubeVersion{1, 9}, kubeVersion{1, 16}, "apps/v1"},
+	{"extensions/v1beta1", []string{"NetworkPolicy"}, kubeVersion{1, 9}, kubeVersion{1, 16}, "networking.k8s.io/v1"},
+	{"extensions/v1beta1", []string{"PodSecurityPolicy"}, kubeVersion{1, 11}, kubeVersion{1, 16}, "policy/v1beta1"},
+	{"extensions/v1beta1", []string{"Ingress"}, kubeVersion{1, 14}, kubeVersion{1, 22}, "networking.k8s.io/v1"},
+	{"apps/v1beta1", []string{"Deployment", "StatefulSet", "ReplicaSet", "ControllerRevision"}, kubeVersion{1, 9}, kubeVersion{1, 16}, "apps/v1"},
+	{"apps/v1beta2", []string{"Deployment", "StatefulSet", "DaemonSet", "ReplicaSet", "ControllerRevision"}, kubeVersion{1, 9}, kubeVersion{1, 16}, "apps/v1"},
+	{"networking.k8s.io/v1beta1", []string{"Ingress", "IngressClass"}, kubeVersion{1, 19}, kubeVersion{1, 22}, "networking.k8s.io/v1"},
+	{"apiextensions.k8s.io/v1beta1", []string{"CustomResourceDefinition"}, kubeVersion{1, 16}, kubeVersion{1, 22}, "apiextensions.k8s.io/v1"},
+	{"admissionregistration.k8s.io/v1beta1", []string{"MutatingWebhookConfiguration", "ValidatingWebhookConfiguration"}, kubeVersion{1, 16}, kubeVersion{1, 22}, "admissionregistration.k8s.io/v1"},
+	{"apiregistration.k8s.io/v1beta1", []string{"APIService"}, kubeVersion{1, 19}, kubeVersion{1, 22}, "apiregistration.k8s.io/v1"},
+	{"authentication.k8s.io/v1beta1", []string{"TokenReview"}, kubeVersion{1, 19}, kubeVersion{1, 22}, "authentication.k8s.io/v1"},
+	{"authorization.k8s.io/v1beta1", []string{"SubjectAccessReview", "LocalSubjectAccessReview", "SelfSubjectAccessReview", "SelfSubjectRulesReview"}, kubeVersion{1, 19}, kubeVersion{1, 22}, "authorization.k8s.io/v1"},
+	{"certificates.k8s.io/v1beta1", []string{"CertificateSigningRequest"}, kubeVersion{1, 19}, kubeVersion{1, 22}, "certificates.k8s.io/v1"},
+	{"coordination.k8s.io/v1beta1", []string{"Lease"}, kubeVersion{1, 19}, kubeVersion{1, 22}, "coordination.k8s.io/v1"},
+	{"rbac.authorization.k8s.io/v1beta1", []string{"ClusterRole", "ClusterRoleBinding", "Role", "RoleBinding"}, kubeVersion{1, 17}, kubeVersion{1, 22}, "rbac.authorization.k8s.io/v1"},
+	{"scheduling.k8s.io/v1beta1", []string{"PriorityClass"}, kubeVersion{1, 14}, kubeVersion{1, 22}, "scheduling.k8s.io/v1"},
+	{"storage.k8s.io/v1beta1", []string{"CSIDriver", "CSINode", "StorageClass", "VolumeAttachment"}, kubeVersion{1, 19}, kubeVersion{1, 22}, "storage.k8s.io/v1"},
+	{"batch/v1beta1", []string{"CronJob"}, kubeVersion{1, 21}, kubeVersion{1, 25}, "batch/v1"},
+	{"discovery.k8s.io/v1beta1", []string{"EndpointSlice"}, kubeVersion{1, 21}, kubeVersion{1, 25}, "discovery.k8s.io/v1"},
+	{"events.k8s.io/v1beta1", []string{"Event"}, kubeVersion{1, 19}, kubeVersion{1, 25}, "events.k8s.io/v1"},
+	{"autoscaling/v2beta1", []string{"HorizontalPodAutoscaler"}, kubeVersion{1, 22}, kubeVersion{1, 25}, "autoscaling/v2"},
+	{"policy/v1beta1", []string{"PodDisruptionBudget"}, kubeVersion{1, 21}, kubeVersion{1, 25}, "policy/v1"},
+	{"policy/v1beta1", []string{"PodSecurityPolicy"}, kubeVersion{1, 21}, kubeVersion{1, 25}, "Pod Security Admission"},
+	{"node.k8s.io/v1beta1", []string{"RuntimeClass"}, kubeVersion{1, 20}, kubeVersion{1, 25}, "node.k8s.io/v1"},
+	{"autoscaling/v2beta2", []string{"HorizontalPodAutoscaler"}, kubeVersion{1, 23}, kubeVersion{1, 26}, "autoscaling/v2"},
+	{"flowcontrol.apiserver.k8s.io/v1beta1", []string{"FlowSchema", "PriorityLevelConfiguration"}, kubeVersion{1, 23}, kubeVersion{1, 26}, "flowcontrol.apiserver.k8s.io/v1"},
+	{"storage.k8s.io/v1beta1", []string{"CSIStorageCapacity"}, kubeVersion{1, 24}, kubeVersion{1, 27}, "storage.k8s.io/v1"},
+	{"flowcontrol.apiserver.k8s.io/v1beta2", []string{"FlowSchema", "PriorityLevelConfiguration"}, kubeVersion{1, 26}, kubeVersion{1, 29}, "flowcontrol.apiserver.k8s.io/v1"},
+	{"flowcontrol.apiserver.k8s.io/v1beta3", []string{"FlowSchema", "PriorityLevelConfiguration"}, kubeVersion{1, 29}, kubeVersion{1, 32}, "flowcontrol.apiserver.k8s.io/v1"},
+}
+
+type kubeVersion struct {
+	major int
+	minor int
+}
+
+func (v kubeVersion) less(o kubeVersion) bool {
+	if v.major != o.major {
+		return v.major < o.major
+	}
+
+	return v.minor < o.minor
+}
+
+func (v kubeVersion) String() string {
+	return fmt.Sprintf("%d.%d", v.major, v.minor)
+}
+
+// parseKubeVersion parses versions such as 1.29, v1.29 or v1.29.3.
+func parseKubeVersion(version string) (kubeVersion, error) {
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) < 2 {
+		return kubeVersion{}, fmt.Errorf("invalid Kubernetes version %q, expected major.minor", version)
+	}
+
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return kubeVersion{}, fmt.Errorf("invalid Kubernetes version %q: %w", version, err)
+	}
+
+	// pre-release versions, i.e. 1.30-alpha.0, are considered as released
+	minor, err := strconv.Atoi(strings.SplitN(parts[1], "-", 2)[0])
+	if err != nil {
+		return kubeVersion{}, fmt.Errorf("invalid Kubernetes version %q: %w", version, err)
+	}
+
+	return kubeVersion{major: major, minor: minor}, nil
+}
+
+// NotUseDeprecatedAPIs succeeds if none of the actual objects uses an API
+// version that is deprecated, or removed, in the given Kubernetes version,
+// i.e. "1.29". The actual value can be an object, a list or rendered
+// manifests as multi-document YAML or JSON, so that Helm or Kustomize output
+// can be checked before being applied:
+//
+//	Expect(rendered).Should(k8s.NotUseDeprecatedAPIs("1.29"))
+//
+// The failure message reports each offending resource and its replacement.
+func NotUseDeprecatedAPIs(version string) types.GomegaMatcher {
+	return &deprecationsMatcher{
+		version: version,
+	}
+}
+
+var _ types.GomegaMatcher = &deprecationsMatcher{}
+
+type deprecationsMatcher struct {
+	version   string
+	offending []string
+}
+
+func (matcher *deprecationsMatcher) Match(actual interface{}) (bool, error) {
+	target, err := parseKubeVersion(matcher.version)
+	if err != nil {
+		return false, err
+	}
+
+	objects, err := toManifests(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.offending = nil
+
+	for _, obj := range objects {
+		apiVersion, _, _ := unstructured.NestedString(obj, "apiVersion")
+		kind := kindOf(obj)
+
+		for _, api := range deprecatedAPIs {
+			if api.apiVersion != apiVersion || !slices.Contains(api.kinds, kind) || target.less(api.deprecatedIn) {
+				continue
+			}
+
+			status := "deprecated in " + api.deprecatedIn.String()
+			if !target.less(api.removedIn) {
+				status = "removed in " + api.removedIn.String()
+			}
+
+			matcher.offending = append(matcher.offending, fmt.Sprintf("%s %s %s: %s, use %s",
+				apiVersion, kind, itemName(obj), status, api.replacement))
+		}
+	}
+
+	return len(matcher.offending) == 0, nil
+}
+
+func (matcher *deprecationsMatcher) FailureMessage(actual interface{}) string {
+	return format.Message(actual, fmt.Sprintf("not to use APIs deprecated in Kubernetes %s, found:\n  %s",
+		matcher.version, strings.Join(matcher.offending, "\n  ")))
+}
+
+func (matcher *deprecationsMatcher) NegatedFailureMessage(actual interface{}) string {
+	return format.Message(actual, "to use APIs deprecated in Kubernetes "+matcher.version)
+}
+
+// toManifests converts the given value to the objects it holds, expanding
+// lists and multi-document YAML.
+func toManifests(in any) ([]map[string]any, error) {
+	switch v := in.(type) {
+	case string:
+		return bytesToManifests([]byte(v))
+	case []byte:
+		return bytesToManifests(v)
+	}
+
+	if items, err := toItems(in); err == nil {
+		return items, nil
+	}
+
+	obj, err := toObject(in)
+	if err != nil {
+		return nil, err
+	}
+
+	return []map[string]any{obj}, nil
+}
+
+func bytesToManifests(data []byte) ([]map[string]any, error) {
+	objects, err := decodeObjects(data)
+	if err != nil {
+		return nil, &ConversionError{From: "YAML", To: "unstructured", Err: err}
+	}
+
+	out := make([]map[string]any, 0, len(objects))
+	for _, obj := range objects {
+		out = append(out, obj.Object)
+	}
+
+	return out, nil
+}
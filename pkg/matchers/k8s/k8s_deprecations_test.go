@@ -0,0 +1,91 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	. "github.com/onsi/gomega"
+)
+
+const rendered = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: ns
+---
+apiVersion: batch/v1beta1
+kind: CronJob
+metadata:
+  name: backup
+  namespace: ns
+---
+apiVersion: policy/v1beta1
+kind: PodDisruptionBudget
+metadata:
+  name: app
+  namespace: ns
+`
+
+func TestNotUseDeprecatedAPIs(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	// deprecated in 1.21, removed in 1.25
+	g.Expect(rendered).Should(k8s.NotUseDeprecatedAPIs("1.20"))
+	g.Expect(rendered).ShouldNot(k8s.NotUseDeprecatedAPIs("1.21"))
+	g.Expect(rendered).ShouldNot(k8s.NotUseDeprecatedAPIs("v1.29.3"))
+
+	m := k8s.NotUseDeprecatedAPIs("1.25")
+
+	ok, err := m.Match(rendered)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ok).Should(BeFalse())
+	g.Expect(m.FailureMessage(rendered)).Should(And(
+		ContainSubstring("batch/v1beta1 CronJob ns/backup: removed in 1.25, use batch/v1"),
+		ContainSubstring("policy/v1beta1 PodDisruptionBudget ns/app: removed in 1.25, use policy/v1"),
+		Not(ContainSubstring("apps/v1 Deployment")),
+	))
+
+	ok, err = m.Match([]byte(rendered))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ok).Should(BeFalse())
+
+	m = k8s.NotUseDeprecatedAPIs("1.23")
+
+	_, err = m.Match(rendered)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(m.FailureMessage(rendered)).Should(ContainSubstring("batch/v1beta1 CronJob ns/backup: deprecated in 1.21, use batch/v1"))
+
+	_, err = k8s.NotUseDeprecatedAPIs("latest").Match(rendered)
+	g.Expect(err).Should(HaveOccurred())
+}
+
+func TestNotUseDeprecatedAPIsObjects(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	ingress := unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "extensions/v1beta1",
+		"kind":       "Ingress",
+		"metadata":   map[string]any{"name": "web", "namespace": "ns"},
+	}}
+
+	g.Expect(&ingress).Should(k8s.NotUseDeprecatedAPIs("1.13"))
+	g.Expect(&ingress).ShouldNot(k8s.NotUseDeprecatedAPIs("1.22"))
+
+	list := unstructured.UnstructuredList{Items: []unstructured.Unstructured{ingress}}
+	g.Expect(&list).ShouldNot(k8s.NotUseDeprecatedAPIs("1.22"))
+
+	deploy := &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "app"},
+	}
+	g.Expect(deploy).Should(k8s.NotUseDeprecatedAPIs("1.30"))
+}
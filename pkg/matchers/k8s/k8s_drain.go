@@ -0,0 +1,111 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// mirrorPodAnnotation marks the API representation of static pods, which
+// cannot be evicted.
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+// Cordon returns a function marking the node with the given name as
+// unschedulable, as kubectl cordon does.
+func (m *Matcher) Cordon(name string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		return m.setUnschedulable(ctx, name, true)
+	}
+}
+
+// Uncordon returns a function marking the node with the given name as
+// schedulable again, as kubectl uncordon does.
+func (m *Matcher) Uncordon(name string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		return m.setUnschedulable(ctx, name, false)
+	}
+}
+
+// DrainSimulate returns a function that cordons the node with the given name
+// and evicts the pods running on it through the eviction subresource, as
+// kubectl drain --ignore-daemonsets does, so that rescheduling logic can be
+// tested without shelling out:
+//
+//	Eventually(k.DrainSimulate("worker-1")).WithContext(ctx).Should(Succeed())
+//
+// Pods owned by a DaemonSet, mirror pods and pods that have already
+// terminated are left alone. Pods are listed with the spec.nodeName field
+// selector. Evictions rejected by a PodDisruptionBudget are reported as
+// errors, so that the function can be polled until the drain completes.
+func (m *Matcher) DrainSimulate(name string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if err := m.setUnschedulable(ctx, name, true); err != nil {
+			return err
+		}
+
+		pods := corev1.PodList{}
+
+		if err := listAll(ctx, m.client, &pods, []client.ListOption{client.MatchingFields{"spec.nodeName": name}}); err != nil {
+			return fmt.Errorf("unable to list pods of node %s: %w", name, err)
+		}
+
+		var errs []error
+
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+
+			if !evictable(pod) {
+				continue
+			}
+
+			if err := m.Evict(client.ObjectKeyFromObject(pod))(ctx); err != nil && !isNotFound(err) {
+				errs = append(errs, err)
+			}
+		}
+
+		return errors.Join(errs...)
+	}
+}
+
+func (m *Matcher) setUnschedulable(ctx context.Context, name string, unschedulable bool) error {
+	node := corev1.Node{}
+	key := client.ObjectKey{Name: name}
+
+	if err := m.client.Get(ctx, key, &node); err != nil {
+		return newOpError("get", corev1.SchemeGroupVersion.WithKind("Node"), key, err)
+	}
+
+	if node.Spec.Unschedulable == unschedulable {
+		return nil
+	}
+
+	patch := client.MergeFrom(node.DeepCopy())
+	node.Spec.Unschedulable = unschedulable
+
+	if err := m.client.Patch(ctx, &node, patch); err != nil {
+		return newOpError("patch", corev1.SchemeGroupVersion.WithKind("Node"), key, err)
+	}
+
+	return nil
+}
+
+func evictable(pod *corev1.Pod) bool {
+	if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+		return false
+	}
+
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+
+	for _, ref := range pod.OwnerReferences {
+		if ref.Controller != nil && *ref.Controller && ref.Kind == "DaemonSet" {
+			return false
+		}
+	}
+
+	return true
+}
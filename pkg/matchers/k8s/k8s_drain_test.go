@@ -0,0 +1,128 @@
+package k8s_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestCordon(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}}
+
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(node).Build()
+	k := k8s.New(cli, scheme)
+
+	g.Expect(k.Cordon("worker-1")(t.Context())).Should(Succeed())
+	g.Expect(cli.Get(t.Context(), client.ObjectKeyFromObject(node), node)).Should(Succeed())
+	g.Expect(node.Spec.Unschedulable).Should(BeTrue())
+
+	g.Expect(k.Uncordon("worker-1")(t.Context())).Should(Succeed())
+	g.Expect(cli.Get(t.Context(), client.ObjectKeyFromObject(node), node)).Should(Succeed())
+	g.Expect(node.Spec.Unschedulable).Should(BeFalse())
+
+	g.Expect(k.Cordon("worker-2")(t.Context())).Should(MatchError(&k8s.NotFoundError{}))
+}
+
+func TestDrainSimulate(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	pod := func(name string, node string, mutate func(*corev1.Pod)) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Spec:       corev1.PodSpec{NodeName: node},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+
+		if mutate != nil {
+			mutate(p)
+		}
+
+		return p
+	}
+
+	objects := []client.Object{
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}},
+		pod("app", "worker-1", nil),
+		pod("protected", "worker-1", nil),
+		pod("other", "worker-2", nil),
+		pod("done", "worker-1", func(p *corev1.Pod) {
+			p.Status.Phase = corev1.PodSucceeded
+		}),
+		pod("static", "worker-1", func(p *corev1.Pod) {
+			p.Annotations = map[string]string{"kubernetes.io/config.mirror": "hash"}
+		}),
+		pod("agent", "worker-1", func(p *corev1.Pod) {
+			p.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "DaemonSet",
+				Name:       "agent",
+				UID:        "uid",
+				Controller: ptrTo(true),
+			}}
+		}),
+	}
+
+	budget := 1
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithIndex(&corev1.Pod{}, "spec.nodeName", func(o client.Object) []string {
+			//nolint:forcetypeassert
+			return []string{o.(*corev1.Pod).Spec.NodeName}
+		}).
+		Build()
+
+	// the first eviction of the protected pod violates its budget
+	k := k8s.New(cli, scheme, k8s.WithInterceptors(interceptor.Funcs{
+		SubResourceCreate: func(ctx context.Context, c client.Client, sub string, obj client.Object, sr client.Object, opts ...client.SubResourceCreateOption) error {
+			if sub == "eviction" && obj.GetName() == "protected" && budget > 0 {
+				budget--
+
+				return pdbViolation()
+			}
+
+			return c.SubResource(sub).Create(ctx, obj, sr, opts...)
+		},
+	}))
+
+	err := k.DrainSimulate("worker-1")(t.Context())
+	g.Expect(err).Should(k8s.RespectPDB())
+
+	g.Eventually(k.DrainSimulate("worker-1")).WithContext(t.Context()).Should(Succeed())
+
+	node := corev1.Node{}
+	g.Expect(cli.Get(t.Context(), client.ObjectKey{Name: "worker-1"}, &node)).Should(Succeed())
+	g.Expect(node.Spec.Unschedulable).Should(BeTrue())
+
+	pods := corev1.PodList{}
+	g.Expect(cli.List(t.Context(), &pods)).Should(Succeed())
+	g.Expect(pods.Items).Should(ConsistOf(
+		HaveField("Name", "other"),
+		HaveField("Name", "done"),
+		HaveField("Name", "static"),
+		HaveField("Name", "agent"),
+	))
+}
@@ -0,0 +1,171 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	eventsResource   = "v1/Event"
+	defaultArtifacts = "_artifacts"
+	allNamespaces    = "_all"
+)
+
+//nolint:gochecknoglobals
+var unsafePathChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// DumpOptions configures the resources dumped by Matcher.Dump and
+// Matcher.DumpOnFailure.
+type DumpOptions struct {
+	// Resources lists the resources to dump, in any of the forms accepted by
+	// UnstructuredMatcher. Events are always dumped.
+	Resources []string
+
+	// Namespaces restricts the dump to the given namespaces. When empty,
+	// resources are listed across all namespaces.
+	Namespaces []string
+
+	// Directory is the root directory dumps are written to. When empty, the
+	// ARTIFACTS environment variable is used, falling back to "_artifacts".
+	Directory string
+}
+
+// Dump writes a YAML file for each of the configured resources (plus events)
+// to dir, one directory per namespace (or "_all" when no namespace is given).
+// Failing to dump a resource does not prevent the others from being dumped,
+// errors are reported altogether.
+func (m *Matcher) Dump(ctx context.Context, dir string, opts DumpOptions) error {
+	resources := opts.Resources
+	if !slices.Contains(resources, eventsResource) {
+		resources = append(slices.Clone(resources), eventsResource)
+	}
+
+	namespaces := opts.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+
+	var errs []error
+
+	for _, ns := range namespaces {
+		for _, resource := range resources {
+			if err := m.dump(ctx, dir, resource, ns); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// DumpOnFailure registers a Ginkgo AfterEach node dumping the configured
+// resources when a spec fails, in a directory named after the spec. It must
+// be invoked from a container node (i.e. Describe) or the suite setup.
+func (m *Matcher) DumpOnFailure(opts DumpOptions) {
+	ginkgo.AfterEach(func(ctx ginkgo.SpecContext) {
+		report := ginkgo.CurrentSpecReport()
+		if !report.Failed() {
+			return
+		}
+
+		dir := filepath.Join(artifactsDirectory(opts), sanitizePath(report.FullText()))
+
+		if err := m.Dump(ctx, dir, opts); err != nil {
+			ginkgo.GinkgoWriter.Printf("unable to dump cluster state: %v\n", err)
+		}
+
+		ginkgo.AddReportEntry("cluster state", dir, ginkgo.ReportEntryVisibilityFailureOrVerbose)
+	})
+}
+
+// DumpOnTestFailure registers a cleanup function dumping the configured
+// resources if the test has failed, in a directory named after the test.
+func (m *Matcher) DumpOnTestFailure(t testing.TB, opts DumpOptions) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		if !t.Failed() {
+			return
+		}
+
+		dir := filepath.Join(artifactsDirectory(opts), sanitizePath(t.Name()))
+
+		// the test context is already canceled when cleanup functions run
+		if err := m.Dump(context.WithoutCancel(m.ctx), dir, opts); err != nil {
+			t.Logf("unable to dump cluster state: %v", err)
+		}
+
+		t.Logf("cluster state dumped to %s", dir)
+	})
+}
+
+func (m *Matcher) dump(ctx context.Context, dir string, resource string, ns string) error {
+	var opts []client.ListOption
+	if ns != "" {
+		opts = append(opts, client.InNamespace(ns))
+	}
+
+	list, err := m.Unstructured().List(resource, opts...)(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range list.Items {
+		list.Items[i].SetManagedFields(nil)
+	}
+
+	data, err := yaml.Marshal(list.UnstructuredContent())
+	if err != nil {
+		return fmt.Errorf("unable to marshal %s: %w", resource, err)
+	}
+
+	if ns == "" {
+		ns = allNamespaces
+	}
+
+	gvk := list.GroupVersionKind()
+	name := strings.ToLower(strings.TrimSuffix(gvk.Kind, "List"))
+
+	if gvk.Group != "" {
+		name += "." + gvk.Group
+	}
+
+	target := filepath.Join(dir, ns)
+
+	if err := os.MkdirAll(target, 0o750); err != nil {
+		return fmt.Errorf("unable to create directory %s: %w", target, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(target, name+".yaml"), data, 0o600); err != nil {
+		return fmt.Errorf("unable to write %s: %w", resource, err)
+	}
+
+	return nil
+}
+
+func artifactsDirectory(opts DumpOptions) string {
+	if opts.Directory != "" {
+		return opts.Directory
+	}
+
+	if dir := os.Getenv("ARTIFACTS"); dir != "" {
+		return dir
+	}
+
+	return defaultArtifacts
+}
+
+func sanitizePath(name string) string {
+	return strings.Trim(unsafePathChars.ReplaceAllString(name, "_"), "_")
+}
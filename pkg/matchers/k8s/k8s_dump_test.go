@@ -0,0 +1,85 @@
+package k8s_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestDump(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm1", Namespace: "ns1"}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm2", Namespace: "ns2"}},
+			&corev1.Event{ObjectMeta: metav1.ObjectMeta{Name: "ev1", Namespace: "ns1"}, Reason: "Boom"},
+		).
+		Build()
+
+	k := k8s.New(cli, scheme)
+	dir := t.TempDir()
+
+	err := k.Dump(t.Context(), dir, k8s.DumpOptions{
+		Resources:  []string{"v1/ConfigMap"},
+		Namespaces: []string{"ns1"},
+	})
+
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	cms, err := os.ReadFile(filepath.Join(dir, "ns1", "configmap.yaml"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(cms)).Should(ContainSubstring("name: cm1"))
+	g.Expect(string(cms)).ShouldNot(ContainSubstring("name: cm2"))
+
+	events, err := os.ReadFile(filepath.Join(dir, "ns1", "event.yaml"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(events)).Should(ContainSubstring("reason: Boom"))
+}
+
+func TestDumpAllNamespaces(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm1", Namespace: "ns1"}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm2", Namespace: "ns2"}},
+		).
+		Build()
+
+	k := k8s.New(cli, scheme)
+	dir := t.TempDir()
+
+	err := k.Dump(t.Context(), dir, k8s.DumpOptions{
+		Resources: []string{"v1/ConfigMap", "foo"},
+	})
+
+	// unresolvable resources do not prevent the others from being dumped
+	g.Expect(err).Should(HaveOccurred())
+
+	cms, err := os.ReadFile(filepath.Join(dir, "_all", "configmap.yaml"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(cms)).Should(ContainSubstring("name: cm1"))
+	g.Expect(string(cms)).Should(ContainSubstring("name: cm2"))
+}
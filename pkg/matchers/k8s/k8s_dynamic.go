@@ -0,0 +1,415 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// NewDynamic creates a Matcher backed by the given client-go dynamic client,
+// for code bases that do not otherwise use controller-runtime:
+//
+//	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(dc))
+//
+//	k, err := k8s.NewDynamic(dynamic.NewForConfigOrDie(cfg), mapper)
+//
+// The mapper translates kinds to resources, the scheme is populated as the
+// one of NewFromConfig, so that typed objects can be used as well, and
+// WithDiscovery is needed for resources to be referenced by short name.
+// Watches are not supported.
+func NewDynamic(dyn dynamic.Interface, mapper meta.RESTMapper, opts ...Option) (*Matcher, error) {
+	m := newMatcher(opts...)
+
+	scheme, err := newScheme(m.addToScheme)
+	if err != nil {
+		return nil, err
+	}
+
+	cli := &dynamicClient{
+		dynamic: dyn,
+		mapper:  mapper,
+		scheme:  scheme,
+	}
+
+	m.setup(cli, scheme)
+
+	return m, nil
+}
+
+var _ client.Client = &dynamicClient{}
+
+// dynamicClient implements client.Client over a dynamic client, converting
+// typed objects to and from their unstructured form with the scheme.
+type dynamicClient struct {
+	dynamic dynamic.Interface
+	mapper  meta.RESTMapper
+	scheme  *runtime.Scheme
+}
+
+func (c *dynamicClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	ri, _, err := c.resourceFor(obj, key.Namespace)
+	if err != nil {
+		return err
+	}
+
+	o := (&client.GetOptions{}).ApplyOptions(opts)
+
+	u, err := ri.Get(ctx, key.Name, *o.AsGetOptions())
+	if err != nil {
+		return err
+	}
+
+	return c.into(u, obj)
+}
+
+func (c *dynamicClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	gvk, err := c.GroupVersionKindFor(list)
+	if err != nil {
+		return err
+	}
+
+	gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+
+	o := (&client.ListOptions{}).ApplyOptions(opts)
+
+	ri, err := c.resource(gvk, o.Namespace)
+	if err != nil {
+		return err
+	}
+
+	ul, err := ri.List(ctx, *o.AsListOptions())
+	if err != nil {
+		return err
+	}
+
+	if uo, ok := list.(*unstructured.UnstructuredList); ok {
+		*uo = *ul
+
+		return nil
+	}
+
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(ul.UnstructuredContent(), list); err != nil {
+		return &ConversionError{From: "unstructured", To: fmt.Sprintf("%T", list), Err: err}
+	}
+
+	return nil
+}
+
+func (c *dynamicClient) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("unable to marshal apply configuration: %w", err)
+	}
+
+	var u unstructured.Unstructured
+	if err := u.UnmarshalJSON(data); err != nil {
+		return fmt.Errorf("unable to unmarshal apply configuration: %w", err)
+	}
+
+	ri, err := c.resource(u.GroupVersionKind(), u.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	o := (&client.ApplyOptions{}).ApplyOptions(opts)
+
+	result, err := ri.Patch(ctx, u.GetName(), types.ApplyPatchType, data, *o.AsPatchOptions())
+	if err != nil {
+		return err
+	}
+
+	// as the controller-runtime client, the result is only reported back to
+	// unstructured apply configurations
+	if uo, ok := obj.(interface{ SetUnstructuredContent(map[string]any) }); ok {
+		uo.SetUnstructuredContent(result.Object)
+	}
+
+	return nil
+}
+
+func (c *dynamicClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	ri, u, err := c.unstructuredFor(obj)
+	if err != nil {
+		return err
+	}
+
+	o := (&client.CreateOptions{}).ApplyOptions(opts)
+
+	result, err := ri.Create(ctx, u, *o.AsCreateOptions())
+	if err != nil {
+		return err
+	}
+
+	return c.into(result, obj)
+}
+
+func (c *dynamicClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	ri, _, err := c.resourceFor(obj, obj.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	o := (&client.DeleteOptions{}).ApplyOptions(opts)
+
+	return ri.Delete(ctx, obj.GetName(), *o.AsDeleteOptions())
+}
+
+func (c *dynamicClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	ri, u, err := c.unstructuredFor(obj)
+	if err != nil {
+		return err
+	}
+
+	o := (&client.UpdateOptions{}).ApplyOptions(opts)
+
+	result, err := ri.Update(ctx, u, *o.AsUpdateOptions())
+	if err != nil {
+		return err
+	}
+
+	return c.into(result, obj)
+}
+
+func (c *dynamicClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	ri, _, err := c.resourceFor(obj, obj.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	data, err := patch.Data(obj)
+	if err != nil {
+		return fmt.Errorf("unable to compute patch: %w", err)
+	}
+
+	o := (&client.PatchOptions{}).ApplyOptions(opts)
+
+	result, err := ri.Patch(ctx, obj.GetName(), patch.Type(), data, *o.AsPatchOptions())
+	if err != nil {
+		return err
+	}
+
+	return c.into(result, obj)
+}
+
+func (c *dynamicClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	o := (&client.DeleteAllOfOptions{}).ApplyOptions(opts)
+
+	ri, _, err := c.resourceFor(obj, o.Namespace)
+	if err != nil {
+		return err
+	}
+
+	return ri.DeleteCollection(ctx, *o.AsDeleteOptions(), *o.AsListOptions())
+}
+
+func (c *dynamicClient) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+func (c *dynamicClient) SubResource(subResource string) client.SubResourceClient {
+	return &dynamicSubResourceClient{
+		client:      c,
+		subResource: subResource,
+	}
+}
+
+func (c *dynamicClient) Scheme() *runtime.Scheme {
+	return c.scheme
+}
+
+func (c *dynamicClient) RESTMapper() meta.RESTMapper {
+	return c.mapper
+}
+
+func (c *dynamicClient) GroupVersionKindFor(obj runtime.Object) (schema.GroupVersionKind, error) {
+	return apiutil.GVKForObject(obj, c.scheme)
+}
+
+func (c *dynamicClient) IsObjectNamespaced(obj runtime.Object) (bool, error) {
+	return apiutil.IsObjectNamespaced(obj, c.scheme, c.mapper)
+}
+
+// resource returns the dynamic client for the resource of the given kind, in
+// the given namespace if the resource is namespaced.
+func (c *dynamicClient) resource(gvk schema.GroupVersionKind, namespace string) (dynamic.ResourceInterface, error) {
+	mapping, err := c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, &NoGVKError{Resource: gvk.String(), Err: err}
+	}
+
+	ri := c.dynamic.Resource(mapping.Resource)
+
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return ri.Namespace(namespace), nil
+	}
+
+	return ri, nil
+}
+
+// resourceFor is like resource, for the kind of the given object.
+func (c *dynamicClient) resourceFor(obj runtime.Object, namespace string) (dynamic.ResourceInterface, schema.GroupVersionKind, error) {
+	gvk, err := c.GroupVersionKindFor(obj)
+	if err != nil {
+		return nil, gvk, err
+	}
+
+	ri, err := c.resource(gvk, namespace)
+	if err != nil {
+		return nil, gvk, err
+	}
+
+	return ri, gvk, nil
+}
+
+// unstructuredFor returns the dynamic client for the resource of the given
+// object, along with the unstructured form of the object.
+func (c *dynamicClient) unstructuredFor(obj client.Object) (dynamic.ResourceInterface, *unstructured.Unstructured, error) {
+	u, err := toUnstructured(obj, c.GroupVersionKindFor)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ri, err := c.resource(u.GroupVersionKind(), u.GetNamespace())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ri, u, nil
+}
+
+// into stores the given object, as returned by the API server, into obj.
+func (c *dynamicClient) into(u *unstructured.Unstructured, obj runtime.Object) error {
+	if uo, ok := obj.(*unstructured.Unstructured); ok {
+		uo.Object = u.Object
+
+		return nil
+	}
+
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, obj); err != nil {
+		return &ConversionError{From: "unstructured", To: fmt.Sprintf("%T", obj), Err: err}
+	}
+
+	return nil
+}
+
+var _ client.SubResourceClient = &dynamicSubResourceClient{}
+
+type dynamicSubResourceClient struct {
+	client      *dynamicClient
+	subResource string
+}
+
+func (c *dynamicSubResourceClient) Get(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceGetOption) error {
+	ri, _, err := c.client.resourceFor(obj, obj.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	o := (&client.SubResourceGetOptions{}).ApplyOptions(opts)
+
+	u, err := ri.Get(ctx, obj.GetName(), *o.AsGetOptions(), c.subResource)
+	if err != nil {
+		return err
+	}
+
+	return c.client.into(u, subResource)
+}
+
+func (c *dynamicSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	ri, _, err := c.client.resourceFor(obj, obj.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	body, err := c.body(obj, subResource)
+	if err != nil {
+		return err
+	}
+
+	o := (&client.SubResourceCreateOptions{}).ApplyOptions(opts)
+
+	result, err := ri.Create(ctx, body, *o.AsCreateOptions(), c.subResource)
+	if err != nil {
+		return err
+	}
+
+	return c.client.into(result, subResource)
+}
+
+func (c *dynamicSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	ri, _, err := c.client.resourceFor(obj, obj.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	o := (&client.SubResourceUpdateOptions{}).ApplyOptions(opts)
+
+	target := obj
+	if o.SubResourceBody != nil {
+		target = o.SubResourceBody
+	}
+
+	body, err := c.body(obj, target)
+	if err != nil {
+		return err
+	}
+
+	result, err := ri.Update(ctx, body, *o.AsUpdateOptions(), c.subResource)
+	if err != nil {
+		return err
+	}
+
+	return c.client.into(result, target)
+}
+
+func (c *dynamicSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	ri, _, err := c.client.resourceFor(obj, obj.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	o := (&client.SubResourcePatchOptions{}).ApplyOptions(opts)
+
+	target := obj
+	if o.SubResourceBody != nil {
+		target = o.SubResourceBody
+	}
+
+	data, err := patch.Data(target)
+	if err != nil {
+		return fmt.Errorf("unable to compute patch: %w", err)
+	}
+
+	result, err := ri.Patch(ctx, obj.GetName(), patch.Type(), data, *o.AsPatchOptions(), c.subResource)
+	if err != nil {
+		return err
+	}
+
+	return c.client.into(result, target)
+}
+
+// body returns the unstructured form of the given subresource body, named
+// after obj if unnamed, as the dynamic client takes the name of the request
+// from the body.
+func (c *dynamicSubResourceClient) body(obj client.Object, body client.Object) (*unstructured.Unstructured, error) {
+	u, err := toUnstructured(body, c.client.GroupVersionKindFor)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.GetName() == "" {
+		u.SetName(obj.GetName())
+	}
+
+	return u, nil
+}
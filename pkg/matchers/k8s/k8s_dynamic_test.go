@@ -0,0 +1,124 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clienttesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/gomega"
+)
+
+func newDynamicMatcher(t *testing.T, objs ...runtime.Object) (*k8s.Matcher, *dynamicfake.FakeDynamicClient) {
+	t.Helper()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{corev1.SchemeGroupVersion})
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Namespace"), meta.RESTScopeRoot)
+
+	dyn := dynamicfake.NewSimpleDynamicClient(scheme, objs...)
+
+	k, err := k8s.NewDynamic(dyn, mapper)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	return k.WithContext(t.Context()), dyn
+}
+
+func TestNewDynamic(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	k, _ := newDynamicMatcher(t,
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"}, Data: map[string]string{"a": "b"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}},
+	)
+
+	key := client.ObjectKey{Namespace: "ns", Name: "cm"}
+
+	g.Expect(k.Unstructured().Get("v1/ConfigMap", key)(t.Context())).Should(jq.Match(`.data.a == "b"`))
+	g.Expect(k.Unstructured().Get("v1/Namespace", client.ObjectKey{Name: "ns"})(t.Context())).Should(jq.Match(`.metadata.name == "ns"`))
+
+	cm := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"}}
+	g.Expect(k.Object(&cm)()).Should(jq.Match(`.data.a == "b"`))
+	g.Expect(cm.Data).Should(HaveKeyWithValue("a", "b"))
+
+	g.Expect(k.Update(&cm, func() { cm.Data["a"] = "c" })()).Should(Succeed())
+	g.Expect(k.Object(&cm)()).Should(jq.Match(`.data.a == "c"`))
+
+	created := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "ns"}}
+	g.Expect(k.Create(t.Context(), &created)).Should(Succeed())
+
+	created.Labels = map[string]string{"app": "test"}
+	g.Expect(k.Client().Patch(t.Context(), &created, client.Merge)).Should(Succeed())
+	g.Expect(k.Object(&created)()).Should(jq.Match(`.metadata.labels.app == "test"`))
+
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}}
+	g.Expect(k.UpdateStatus(&ns, func() { ns.Status.Phase = corev1.NamespaceTerminating })()).Should(Succeed())
+	g.Expect(k.Object(&ns)()).Should(jq.Match(`.status.phase == "Terminating"`))
+
+	var list corev1.ConfigMapList
+	g.Expect(k.Client().List(t.Context(), &list, client.InNamespace("ns"))).Should(Succeed())
+	g.Expect(list.Items).Should(HaveLen(2))
+
+	g.Expect(k.Unstructured().List("v1/ConfigMap")(t.Context())).Should(jq.Match(`[.items[].metadata.name] | sort == ["cm", "other"]`))
+
+	g.Expect(k.Unstructured().Delete("v1/ConfigMap", key)(t.Context())).Should(Succeed())
+	g.Expect(k.Unstructured().Get("v1/ConfigMap", key)(t.Context())).Error().Should(k8s.BeNotFound())
+
+	_, err := k.Unstructured().Get("apps/v1/Deployment", key)(t.Context())
+	g.Expect(err).Should(HaveOccurred())
+}
+
+func TestNewDynamicApply(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	k, dyn := newDynamicMatcher(t)
+
+	var patches []clienttesting.PatchAction
+
+	// the fake dynamic client does not implement server-side apply
+	dyn.PrependReactor("patch", "configmaps", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		patch, _ := action.(clienttesting.PatchAction)
+		patches = append(patches, patch)
+
+		u := unstructured.Unstructured{}
+		g.Expect(u.UnmarshalJSON(patch.GetPatch())).Should(Succeed())
+		u.SetResourceVersion("1")
+
+		return true, &u, nil
+	})
+
+	cm := corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"},
+		Data:       map[string]string{"a": "b"},
+	}
+
+	g.Expect(k.ApplyAs(t.Context(), &cm, "test", true)).Should(Succeed())
+	g.Expect(cm.ResourceVersion).Should(Equal("1"))
+
+	g.Expect(patches).Should(HaveLen(1))
+	g.Expect(patches[0].GetPatchType()).Should(Equal(types.ApplyPatchType))
+	g.Expect(patches[0].GetNamespace()).Should(Equal("ns"))
+	g.Expect(patches[0].GetName()).Should(Equal("cm"))
+	g.Expect(patches[0].GetPatch()).Should(jq.Match(`.data.a == "b"`))
+}
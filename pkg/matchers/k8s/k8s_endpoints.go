@@ -0,0 +1,121 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EndpointSlices returns a pollable function listing the EndpointSlices
+// backing the Service identified by the given key, so that they can be
+// asserted with HaveReadyEndpoints:
+//
+//	Eventually(k.EndpointSlices(key)).WithContext(ctx).Should(k8s.HaveReadyEndpoints(1))
+func (m *Matcher) EndpointSlices(key client.ObjectKey) func(ctx context.Context) (*discoveryv1.EndpointSliceList, error) {
+	return func(ctx context.Context) (*discoveryv1.EndpointSliceList, error) {
+		list := discoveryv1.EndpointSliceList{}
+
+		err := m.client.List(ctx, &list,
+			client.InNamespace(key.Namespace),
+			client.MatchingLabels{discoveryv1.LabelServiceName: key.Name},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list EndpointSlices of service %s: %w", key, err)
+		}
+
+		return &list, nil
+	}
+}
+
+// HaveReadyEndpoints succeeds if the actual EndpointSlices have at least min
+// distinct ready addresses. The actual can be an EndpointSliceList, as
+// returned by EndpointSlices, a single EndpointSlice or their unstructured
+// representation. As mandated by the API, endpoints with no ready condition
+// are considered ready.
+func HaveReadyEndpoints(minimum int) types.GomegaMatcher {
+	return &readyEndpointsMatcher{
+		minimum: minimum,
+	}
+}
+
+var _ types.GomegaMatcher = &readyEndpointsMatcher{}
+
+type readyEndpointsMatcher struct {
+	minimum   int
+	addresses []string
+}
+
+func (matcher *readyEndpointsMatcher) Match(actual interface{}) (bool, error) {
+	slices, err := toEndpointSlices(actual)
+	if err != nil {
+		return false, err
+	}
+
+	ready := make(map[string]struct{})
+
+	for _, slice := range slices {
+		endpoints, _, err := unstructured.NestedSlice(slice, "endpoints")
+		if err != nil {
+			return false, fmt.Errorf("unable to read .endpoints of %s: %w", itemName(slice), err)
+		}
+
+		for _, e := range endpoints {
+			endpoint, ok := e.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			if r, found, _ := unstructured.NestedBool(endpoint, "conditions", "ready"); found && !r {
+				continue
+			}
+
+			addresses, _, _ := unstructured.NestedStringSlice(endpoint, "addresses")
+			for _, address := range addresses {
+				ready[address] = struct{}{}
+			}
+		}
+	}
+
+	matcher.addresses = make([]string, 0, len(ready))
+	for address := range ready {
+		matcher.addresses = append(matcher.addresses, address)
+	}
+
+	sort.Strings(matcher.addresses)
+
+	return len(matcher.addresses) >= matcher.minimum, nil
+}
+
+func (matcher *readyEndpointsMatcher) FailureMessage(_ interface{}) string {
+	return format.Message(matcher.addresses, fmt.Sprintf("to have at least %d ready addresses", matcher.minimum))
+}
+
+func (matcher *readyEndpointsMatcher) NegatedFailureMessage(_ interface{}) string {
+	return format.Message(matcher.addresses, fmt.Sprintf("to have less than %d ready addresses", matcher.minimum))
+}
+
+// toEndpointSlices returns the unstructured representation of the given
+// EndpointSlice or list of EndpointSlices.
+func toEndpointSlices(in any) ([]map[string]any, error) {
+	if v, ok := in.(*discoveryv1.EndpointSlice); ok {
+		obj, err := toObject(v)
+		if err != nil {
+			return nil, err
+		}
+
+		return []map[string]any{obj}, nil
+	}
+
+	obj, err := toObject(in)
+	if err == nil && kindOf(obj) == "EndpointSlice" {
+		return []map[string]any{obj}, nil
+	}
+
+	return toItems(in)
+}
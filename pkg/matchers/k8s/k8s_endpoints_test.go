@@ -0,0 +1,91 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestHaveReadyEndpoints(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	slice := discoveryv1.EndpointSlice{
+		ObjectMeta:  metav1.ObjectMeta{Name: "app-abc", Namespace: "ns"},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints: []discoveryv1.Endpoint{
+			{Addresses: []string{"10.0.0.1"}, Conditions: discoveryv1.EndpointConditions{Ready: ptrTo(true)}},
+			{Addresses: []string{"10.0.0.2"}},
+			{Addresses: []string{"10.0.0.3"}, Conditions: discoveryv1.EndpointConditions{Ready: ptrTo(false)}},
+		},
+	}
+
+	g.Expect(&slice).Should(k8s.HaveReadyEndpoints(2))
+	g.Expect(&slice).ShouldNot(k8s.HaveReadyEndpoints(3))
+
+	list := discoveryv1.EndpointSliceList{Items: []discoveryv1.EndpointSlice{slice, slice}}
+	g.Expect(&list).Should(k8s.HaveReadyEndpoints(2))
+	g.Expect(&list).ShouldNot(k8s.HaveReadyEndpoints(3))
+	g.Expect(&discoveryv1.EndpointSliceList{}).ShouldNot(k8s.HaveReadyEndpoints(1))
+
+	g.Expect(`{"kind":"EndpointSlice","endpoints":[{"addresses":["10.0.0.1"]}]}`).Should(k8s.HaveReadyEndpoints(1))
+
+	m := k8s.HaveReadyEndpoints(3)
+	g.Expect(m.Match(&slice)).Should(BeFalse())
+	g.Expect(m.FailureMessage(&slice)).Should(And(
+		ContainSubstring("to have at least 3 ready addresses"),
+		ContainSubstring("10.0.0.2"),
+	))
+}
+
+func TestEndpointSlices(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	slice := func(name string, service string, address string) *discoveryv1.EndpointSlice {
+		return &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "ns",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{address}}},
+		}
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			slice("app-1", "app", "10.0.0.1"),
+			slice("app-2", "app", "10.0.0.2"),
+			slice("other-1", "other", "10.0.0.3"),
+		).
+		Build()
+
+	k := k8s.New(cli, scheme)
+
+	g.Eventually(k.EndpointSlices(client.ObjectKey{Namespace: "ns", Name: "app"})).
+		WithContext(t.Context()).
+		Should(And(
+			k8s.HaveItems(2),
+			k8s.HaveReadyEndpoints(2),
+			Not(k8s.HaveReadyEndpoints(3)),
+		))
+
+	g.Expect(k.EndpointSlices(client.ObjectKey{Namespace: "ns", Name: "missing"})(t.Context())).
+		ShouldNot(k8s.HaveReadyEndpoints(1))
+}
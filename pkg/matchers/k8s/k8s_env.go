@@ -0,0 +1,327 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// HaveEnv succeeds if a container (or init container) of the pod spec of the
+// actual object defines the given environment variable, either with a value
+// or with valueFrom. It works with Pods and any kind embedding a
+// PodTemplateSpec. Further expectations can be added with WithValue,
+// FromSecret and FromConfigMap:
+//
+//	Expect(deployment).Should(k8s.HaveEnv("LOG_LEVEL").WithValue("debug"))
+//	Expect(deployment).Should(k8s.HaveEnv("DB_PASSWORD").FromSecret("db", "password"))
+//
+// Values set from a Secret or a ConfigMap can only be matched by the
+// HaveEnv variant of Matcher, which resolves them.
+func HaveEnv(name string) *EnvMatcher {
+	return &EnvMatcher{
+		name: name,
+	}
+}
+
+// HaveEnv is like the package level HaveEnv, but values set from a Secret or
+// a ConfigMap key are resolved through the Matcher, in the namespace of the
+// actual object, so that WithValue asserts the effective value:
+//
+//	Expect(deployment).Should(k.HaveEnv("DB_PASSWORD").FromSecret("db", "password").WithValue(Not(BeEmpty())))
+func (m *Matcher) HaveEnv(name string) *EnvMatcher {
+	return &EnvMatcher{
+		name:    name,
+		matcher: m,
+	}
+}
+
+var _ types.GomegaMatcher = &EnvMatcher{}
+
+// EnvMatcher is the matcher returned by HaveEnv.
+type EnvMatcher struct {
+	name      string
+	matcher   *Matcher
+	container string
+	value     types.GomegaMatcher
+	source    *envSource
+
+	failure string
+}
+
+// envSource identifies the key of a Secret or ConfigMap an environment
+// variable is set from.
+type envSource struct {
+	kind string
+	name string
+	key  string
+}
+
+func (s envSource) String() string {
+	return fmt.Sprintf("%s %s key %s", s.kind, s.name, s.key)
+}
+
+// InContainer only considers the container (or init container) with the
+// given name, rather than any container of the pod spec.
+func (matcher *EnvMatcher) InContainer(name string) *EnvMatcher {
+	matcher.container = name
+
+	return matcher
+}
+
+// WithValue requires the value of the environment variable to match the given
+// one, which can be either a string or a matcher.
+func (matcher *EnvMatcher) WithValue(expected any) *EnvMatcher {
+	matcher.value = toMatcher(expected)
+
+	return matcher
+}
+
+// FromSecret requires the environment variable to be set from the given key
+// of the given Secret through valueFrom.secretKeyRef.
+func (matcher *EnvMatcher) FromSecret(name string, key string) *EnvMatcher {
+	matcher.source = &envSource{kind: kindSecret, name: name, key: key}
+
+	return matcher
+}
+
+// FromConfigMap requires the environment variable to be set from the given
+// key of the given ConfigMap through valueFrom.configMapKeyRef.
+func (matcher *EnvMatcher) FromConfigMap(name string, key string) *EnvMatcher {
+	matcher.source = &envSource{kind: kindConfigMap, name: name, key: key}
+
+	return matcher
+}
+
+func (matcher *EnvMatcher) Match(actual interface{}) (bool, error) {
+	obj, err := toObject(actual)
+	if err != nil {
+		return false, err
+	}
+
+	spec, err := podSpec(obj)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.failure = ""
+
+	namespace, _, _ := unstructured.NestedString(obj, "metadata", "namespace")
+
+	var containers []string
+
+	for _, field := range []string{"containers", "initContainers"} {
+		items, _ := spec[field].([]any)
+
+		for i := range items {
+			c, ok := items[i].(map[string]any)
+			if !ok {
+				continue
+			}
+
+			n, _ := c["name"].(string)
+			if matcher.container != "" && n != matcher.container {
+				continue
+			}
+
+			containers = append(containers, n)
+
+			env, _ := c["env"].([]any)
+
+			for j := range env {
+				v, ok := env[j].(map[string]any)
+				if !ok || v["name"] != matcher.name {
+					continue
+				}
+
+				ok, err := matcher.matchVar(namespace, n, v)
+				if err != nil || ok {
+					return ok, err
+				}
+			}
+		}
+	}
+
+	if matcher.failure != "" {
+		return false, nil
+	}
+
+	switch {
+	case matcher.container != "" && len(containers) == 0:
+		matcher.failure = fmt.Sprintf("Expected container %s to define environment variable %s, but there is no such container", matcher.container, matcher.name)
+	case matcher.container != "":
+		matcher.failure = fmt.Sprintf("Expected container %s to define environment variable %s", matcher.container, matcher.name)
+	default:
+		matcher.failure = format.Message(containers, "to define environment variable "+matcher.name+" in any container")
+	}
+
+	return false, nil
+}
+
+// matchVar evaluates the expectations against the given environment variable
+// of the given container, recording the reason of the first failure.
+func (matcher *EnvMatcher) matchVar(namespace string, container string, v map[string]any) (bool, error) {
+	valueFrom, _ := v["valueFrom"].(map[string]any)
+	source := toEnvSource(valueFrom)
+
+	if matcher.source != nil && (source == nil || *source != *matcher.source) {
+		matcher.setFailure(fmt.Sprintf("Expected environment variable %s of container %s to be set from %s, but it is set from %s",
+			matcher.name, container, matcher.source, describeEnvVar(v)))
+
+		return false, nil
+	}
+
+	if matcher.value == nil {
+		return true, nil
+	}
+
+	value, resolved, err := matcher.resolve(namespace, v, source)
+	if err != nil {
+		return false, err
+	}
+
+	if !resolved {
+		matcher.setFailure(fmt.Sprintf("Expected environment variable %s of container %s to have a matching value, but it is set from %s, which can only be resolved by Matcher.HaveEnv",
+			matcher.name, container, describeEnvVar(v)))
+
+		return false, nil
+	}
+
+	ok, err := matcher.value.Match(value)
+	if err != nil {
+		return false, err
+	}
+
+	if !ok {
+		matcher.setFailure(fmt.Sprintf("Expected environment variable %s of container %s to match:\n%s",
+			matcher.name, container, matcher.value.FailureMessage(value)))
+	}
+
+	return ok, nil
+}
+
+// resolve returns the effective value of the given environment variable and
+// whether it could be determined.
+func (matcher *EnvMatcher) resolve(namespace string, v map[string]any, source *envSource) (string, bool, error) {
+	if _, ok := v["valueFrom"]; !ok {
+		value, _ := v["value"].(string)
+
+		return value, true, nil
+	}
+
+	if source == nil || matcher.matcher == nil {
+		return "", false, nil
+	}
+
+	key := client.ObjectKey{Namespace: namespace, Name: source.name}
+
+	var values []map[string]string
+
+	switch source.kind {
+	case kindSecret:
+		var secret corev1.Secret
+		if err := matcher.matcher.client.Get(matcher.matcher.ctx, key, &secret); err != nil {
+			return "", false, newOpError("get", corev1.SchemeGroupVersion.WithKind(kindSecret), key, err)
+		}
+
+		data := make(map[string]string, len(secret.Data))
+		for k, d := range secret.Data {
+			data[k] = string(d)
+		}
+
+		values = []map[string]string{data, secret.StringData}
+	default:
+		var cm corev1.ConfigMap
+		if err := matcher.matcher.client.Get(matcher.matcher.ctx, key, &cm); err != nil {
+			return "", false, newOpError("get", corev1.SchemeGroupVersion.WithKind(kindConfigMap), key, err)
+		}
+
+		data := make(map[string]string, len(cm.BinaryData))
+		for k, d := range cm.BinaryData {
+			data[k] = string(d)
+		}
+
+		values = []map[string]string{cm.Data, data}
+	}
+
+	for _, data := range values {
+		if value, ok := data[source.key]; ok {
+			return value, true, nil
+		}
+	}
+
+	return "", false, fmt.Errorf("%s %s has no key %s", source.kind, key, source.key)
+}
+
+func (matcher *EnvMatcher) setFailure(failure string) {
+	// the first failure is the most relevant one, later containers defining
+	// the variable are only checked for a match
+	if matcher.failure == "" {
+		matcher.failure = failure
+	}
+}
+
+func (matcher *EnvMatcher) FailureMessage(_ interface{}) string {
+	return matcher.failure
+}
+
+func (matcher *EnvMatcher) NegatedFailureMessage(_ interface{}) string {
+	expectations := []string{"environment variable " + matcher.name}
+
+	if matcher.container != "" {
+		expectations[0] += " in container " + matcher.container
+	}
+
+	if matcher.source != nil {
+		expectations = append(expectations, "set from "+matcher.source.String())
+	}
+
+	if matcher.value != nil {
+		expectations = append(expectations, "with a matching value")
+	}
+
+	return "Expected not to define " + strings.Join(expectations, ", ")
+}
+
+func toEnvSource(valueFrom map[string]any) *envSource {
+	for field, kind := range map[string]string{"secretKeyRef": kindSecret, "configMapKeyRef": kindConfigMap} {
+		ref, ok := valueFrom[field].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		name, _ := ref["name"].(string)
+		key, _ := ref["key"].(string)
+
+		return &envSource{kind: kind, name: name, key: key}
+	}
+
+	return nil
+}
+
+// describeEnvVar describes where the value of the given environment variable
+// comes from, for failure messages.
+func describeEnvVar(v map[string]any) string {
+	valueFrom, ok := v["valueFrom"].(map[string]any)
+	if !ok {
+		return "a value"
+	}
+
+	if source := toEnvSource(valueFrom); source != nil {
+		return source.String()
+	}
+
+	if ref, ok := valueFrom["fieldRef"].(map[string]any); ok {
+		return fmt.Sprintf("field %v", ref["fieldPath"])
+	}
+
+	if ref, ok := valueFrom["resourceFieldRef"].(map[string]any); ok {
+		return fmt.Sprintf("resource %v", ref["resource"])
+	}
+
+	return "an unknown source"
+}
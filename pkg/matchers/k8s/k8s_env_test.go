@@ -0,0 +1,155 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func newEnvDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"},
+		Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{
+				Name: "migrate",
+				Env: []corev1.EnvVar{
+					{Name: "LOG_LEVEL", Value: "info"},
+				},
+			}},
+			Containers: []corev1.Container{{
+				Name: "app",
+				Env: []corev1.EnvVar{
+					{Name: "LOG_LEVEL", Value: "debug"},
+					{Name: "DB_PASSWORD", ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "db"},
+							Key:                  "password",
+						},
+					}},
+					{Name: "DB_HOST", ValueFrom: &corev1.EnvVarSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "db"},
+							Key:                  "host",
+						},
+					}},
+					{Name: "POD_NAME", ValueFrom: &corev1.EnvVarSource{
+						FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+					}},
+				},
+			}},
+		}}},
+	}
+}
+
+func TestHaveEnv(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	d := newEnvDeployment()
+
+	g.Expect(d).Should(k8s.HaveEnv("LOG_LEVEL"))
+	g.Expect(d).Should(k8s.HaveEnv("LOG_LEVEL").WithValue("debug"))
+	g.Expect(d).Should(k8s.HaveEnv("LOG_LEVEL").WithValue("info"))
+	g.Expect(d).Should(k8s.HaveEnv("LOG_LEVEL").InContainer("migrate").WithValue(HavePrefix("in")))
+	g.Expect(d).Should(k8s.HaveEnv("DB_PASSWORD").FromSecret("db", "password"))
+	g.Expect(d).Should(k8s.HaveEnv("DB_HOST").FromConfigMap("db", "host"))
+	g.Expect(d).Should(k8s.HaveEnv("POD_NAME"))
+
+	g.Expect(d).ShouldNot(k8s.HaveEnv("MISSING"))
+	g.Expect(d).ShouldNot(k8s.HaveEnv("LOG_LEVEL").InContainer("migrate").WithValue("debug"))
+	g.Expect(d).ShouldNot(k8s.HaveEnv("DB_PASSWORD").InContainer("migrate"))
+	g.Expect(d).ShouldNot(k8s.HaveEnv("DB_PASSWORD").FromSecret("db", "pass"))
+	g.Expect(d).ShouldNot(k8s.HaveEnv("DB_PASSWORD").FromConfigMap("db", "password"))
+	g.Expect(d).ShouldNot(k8s.HaveEnv("LOG_LEVEL").FromSecret("db", "password"))
+
+	// valueFrom cannot be resolved without a client
+	g.Expect(d).ShouldNot(k8s.HaveEnv("DB_PASSWORD").WithValue("s3cr3t"))
+}
+
+func TestHaveEnvFailureMessage(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	d := newEnvDeployment()
+
+	m := k8s.HaveEnv("MISSING")
+	g.Expect(m.Match(d)).Should(BeFalse())
+	g.Expect(m.FailureMessage(d)).Should(And(
+		ContainSubstring("to define environment variable MISSING in any container"),
+		ContainSubstring("migrate"),
+	))
+
+	m = k8s.HaveEnv("LOG_LEVEL").InContainer("sidecar")
+	g.Expect(m.Match(d)).Should(BeFalse())
+	g.Expect(m.FailureMessage(d)).Should(ContainSubstring("Expected container sidecar to define environment variable LOG_LEVEL, but there is no such container"))
+
+	m = k8s.HaveEnv("LOG_LEVEL").InContainer("app").WithValue("trace")
+	g.Expect(m.Match(d)).Should(BeFalse())
+	g.Expect(m.FailureMessage(d)).Should(ContainSubstring("Expected environment variable LOG_LEVEL of container app to match"))
+
+	m = k8s.HaveEnv("DB_PASSWORD").FromConfigMap("db", "password")
+	g.Expect(m.Match(d)).Should(BeFalse())
+	g.Expect(m.FailureMessage(d)).Should(ContainSubstring("to be set from ConfigMap db key password, but it is set from Secret db key password"))
+
+	m = k8s.HaveEnv("POD_NAME").FromSecret("db", "name")
+	g.Expect(m.Match(d)).Should(BeFalse())
+	g.Expect(m.FailureMessage(d)).Should(ContainSubstring("but it is set from field metadata.name"))
+
+	m = k8s.HaveEnv("DB_PASSWORD").WithValue("s3cr3t")
+	g.Expect(m.Match(d)).Should(BeFalse())
+	g.Expect(m.FailureMessage(d)).Should(ContainSubstring("which can only be resolved by Matcher.HaveEnv"))
+
+	m = k8s.HaveEnv("LOG_LEVEL").FromSecret("db", "password")
+	g.Expect(m.NegatedFailureMessage(d)).Should(Equal("Expected not to define environment variable LOG_LEVEL, set from Secret db key password"))
+}
+
+func TestMatcherHaveEnv(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "ns"},
+		Data:       map[string][]byte{"password": []byte("s3cr3t")},
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "ns"},
+		Data:       map[string]string{"host": "db.ns.svc"},
+	}
+
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret, cm).Build()
+	k := k8s.New(cli, scheme).WithContext(t.Context())
+
+	d := newEnvDeployment()
+
+	g.Expect(d).Should(k.HaveEnv("DB_PASSWORD").FromSecret("db", "password").WithValue("s3cr3t"))
+	g.Expect(d).Should(k.HaveEnv("DB_HOST").WithValue(HaveSuffix(".svc")))
+	g.Expect(d).Should(k.HaveEnv("LOG_LEVEL").WithValue("debug"))
+
+	g.Expect(d).ShouldNot(k.HaveEnv("DB_PASSWORD").WithValue("guess"))
+	g.Expect(d).ShouldNot(k.HaveEnv("POD_NAME").WithValue("app"))
+
+	d.Namespace = "other"
+
+	_, err := k.HaveEnv("DB_PASSWORD").WithValue("s3cr3t").Match(d)
+	g.Expect(err).Should(MatchError(ContainSubstring("not found")))
+
+	d.Namespace = "ns"
+	d.Spec.Template.Spec.Containers[0].Env[1].ValueFrom.SecretKeyRef.Key = "user"
+
+	_, err = k.HaveEnv("DB_PASSWORD").WithValue("s3cr3t").Match(d)
+	g.Expect(err).Should(MatchError("Secret ns/db has no key user"))
+}
@@ -0,0 +1,203 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NotFoundError is returned when the object an operation refers to does not
+// exist. It wraps the error returned by the API server, so apierrors.IsNotFound
+// keeps working, and can be matched with errors.Is against a NotFoundError
+// whose unset fields act as wildcards:
+//
+//	if errors.Is(err, &k8s.NotFoundError{}) { ... }
+type NotFoundError struct {
+	Op  string
+	GVK schema.GroupVersionKind
+	Key client.ObjectKey
+	Err error
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s: %v", describeOp(e.Op, e.GVK, e.Key), e.Err)
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	t, ok := target.(*NotFoundError)
+	if !ok {
+		return false
+	}
+
+	return (t.GVK.Empty() || t.GVK == e.GVK) && (t.Key == client.ObjectKey{} || t.Key == e.Key)
+}
+
+// As makes a NotFoundError match an OpError too, as it is the failure of an
+// operation as well.
+func (e *NotFoundError) As(target any) bool {
+	t, ok := target.(**OpError)
+	if !ok {
+		return false
+	}
+
+	*t = &OpError{Op: e.Op, GVK: e.GVK, Key: e.Key, Err: e.Err}
+
+	return true
+}
+
+// OpError is returned when an operation on objects of a given kind, i.e. a
+// get or a list, fails. Objects that do not exist are reported with a
+// NotFoundError instead, which errors.As still converts to an OpError. Lists
+// only have the namespace of the key set, if any. It can be matched with
+// errors.Is against an OpError whose unset fields act as wildcards:
+//
+//	if errors.Is(err, &k8s.OpError{Op: "list"}) { ... }
+type OpError struct {
+	Op  string
+	GVK schema.GroupVersionKind
+	Key client.ObjectKey
+	Err error
+}
+
+func (e *OpError) Error() string {
+	return fmt.Sprintf("%s: %v", describeOp(e.Op, e.GVK, e.Key), e.Err)
+}
+
+func (e *OpError) Unwrap() error {
+	return e.Err
+}
+
+func (e *OpError) Is(target error) bool {
+	t, ok := target.(*OpError)
+	if !ok {
+		return false
+	}
+
+	return (t.Op == "" || t.Op == e.Op) &&
+		(t.GVK.Empty() || t.GVK == e.GVK) &&
+		(t.Key == client.ObjectKey{} || t.Key == e.Key)
+}
+
+// NoGVKError is returned when the GroupVersionKind of a resource string or of
+// a Go type cannot be determined.
+type NoGVKError struct {
+	Resource string
+	Err      error
+}
+
+func (e *NoGVKError) Error() string {
+	return fmt.Sprintf("unable to resolve the kind of %s: %v", e.Resource, e.Err)
+}
+
+func (e *NoGVKError) Unwrap() error {
+	return e.Err
+}
+
+func (e *NoGVKError) Is(target error) bool {
+	t, ok := target.(*NoGVKError)
+	if !ok {
+		return false
+	}
+
+	return t.Resource == "" || t.Resource == e.Resource
+}
+
+// ConversionError is returned when an object cannot be converted from one
+// representation to another, i.e. from a typed object to unstructured.
+type ConversionError struct {
+	From string
+	To   string
+	Err  error
+}
+
+func (e *ConversionError) Error() string {
+	return fmt.Sprintf("unable to convert %s to %s: %v", e.From, e.To, e.Err)
+}
+
+func (e *ConversionError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ConversionError) Is(target error) bool {
+	_, ok := target.(*ConversionError)
+
+	return ok
+}
+
+// newOpError wraps the error returned by an operation on the object with the
+// given kind and key, as a NotFoundError if the object does not exist.
+func newOpError(op string, gvk schema.GroupVersionKind, key client.ObjectKey, err error) error {
+	if apierrors.IsNotFound(err) {
+		return &NotFoundError{Op: op, GVK: gvk, Key: key, Err: err}
+	}
+
+	return &OpError{Op: op, GVK: gvk, Key: key, Err: err}
+}
+
+// newObjectError is like newOpError, for the kind of the given typed object,
+// which falls back to its Go type if it is not registered in the scheme.
+func newObjectError(cli client.Client, op string, obj runtime.Object, key client.ObjectKey, err error) error {
+	gvk, gvkErr := cli.GroupVersionKindFor(obj)
+	if gvkErr != nil {
+		gvk.Kind = fmt.Sprintf("%T", obj)
+	}
+
+	return newOpError(op, gvk, key, err)
+}
+
+// newListError is like newObjectError, for the kind of the items of the given
+// list and the namespace set by the given options.
+func newListError(cli client.Client, list client.ObjectList, opts []client.ListOption, err error) error {
+	gvk, gvkErr := cli.GroupVersionKindFor(list)
+	if gvkErr != nil {
+		gvk.Kind = fmt.Sprintf("%T", list)
+	} else {
+		gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	}
+
+	return newOpError("list", gvk, listKey(opts), err)
+}
+
+// listKey returns the key of a list operation, which only has the namespace
+// set by the given options, if any.
+func listKey(opts []client.ListOption) client.ObjectKey {
+	lo := client.ListOptions{}
+	lo.ApplyOptions(opts)
+
+	return client.ObjectKey{Namespace: lo.Namespace}
+}
+
+// describeOp describes an operation for the error messages, i.e. "unable to
+// get ConfigMap ns/app" or "unable to list Pod in namespace ns".
+func describeOp(op string, gvk schema.GroupVersionKind, key client.ObjectKey) string {
+	var b strings.Builder
+
+	b.WriteString("unable to " + op + " " + gvk.Kind)
+
+	switch {
+	case key.Name != "":
+		b.WriteString(" " + key.String())
+	case key.Namespace != "":
+		b.WriteString(" in namespace " + key.Namespace)
+	}
+
+	return b.String()
+}
+
+// gvkFor returns the kind of the given object as a NoGVKError on failure.
+func gvkFor(cli client.Client, obj runtime.Object) (schema.GroupVersionKind, error) {
+	gvk, err := cli.GroupVersionKindFor(obj)
+	if err != nil {
+		return schema.GroupVersionKind{}, &NoGVKError{Resource: fmt.Sprintf("%T", obj), Err: err}
+	}
+
+	return gvk, nil
+}
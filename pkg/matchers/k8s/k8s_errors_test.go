@@ -0,0 +1,134 @@
+package k8s_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestErrors(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	k := k8s.New(fake.NewClientBuilder().WithScheme(scheme).Build(), scheme)
+	key := client.ObjectKey{Namespace: "ns", Name: "missing"}
+
+	_, err := k.Unstructured().Get("v1/ConfigMap", key)(t.Context())
+	g.Expect(err).Should(MatchError(&k8s.NotFoundError{}))
+	g.Expect(err).Should(MatchError(&k8s.NotFoundError{Key: key}))
+	g.Expect(err).ShouldNot(MatchError(&k8s.NotFoundError{Key: client.ObjectKey{Name: "other"}}))
+	g.Expect(err).ShouldNot(MatchError(&k8s.NoGVKError{}))
+	g.Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+	g.Expect(err).Should(MatchError(ContainSubstring("unable to get ConfigMap ns/missing")))
+
+	nf := &k8s.NotFoundError{}
+	g.Expect(errors.As(err, &nf)).Should(BeTrue())
+	g.Expect(nf.GVK.Kind).Should(Equal("ConfigMap"))
+	g.Expect(nf.Op).Should(Equal("get"))
+
+	_, err = k8s.NewTyped[*corev1.ConfigMap](k.Client()).Get(t.Context(), key)
+	g.Expect(err).Should(MatchError(&k8s.NotFoundError{Key: key}))
+
+	_, err = k.Unstructured().Get("configmap", key)(t.Context())
+	g.Expect(err).Should(MatchError(&k8s.NoGVKError{Resource: `"configmap"`}))
+	g.Expect(err).Should(MatchError(ContainSubstring("no discovery client configured")))
+
+	_, err = k.GetSubresource(t.Context(), &unknown{}, "status")
+	g.Expect(err).Should(MatchError(&k8s.NoGVKError{}))
+	g.Expect(err).Should(MatchError(ContainSubstring("unable to resolve the kind of *k8s_test.unknown")))
+}
+
+func TestOpErrors(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	forbidden := apierrors.NewForbidden(corev1.Resource("configmaps"), "", errors.New("denied"))
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			List: func(_ context.Context, _ client.WithWatch, _ client.ObjectList, _ ...client.ListOption) error {
+				return forbidden
+			},
+		}).
+		Build()
+
+	k := k8s.New(cli, scheme)
+	cmGVK := corev1.SchemeGroupVersion.WithKind("ConfigMap")
+
+	fns := map[string]func(ctx context.Context) error{
+		"List": func(ctx context.Context) error {
+			_, err := k.List(&corev1.ConfigMapList{}, client.InNamespace("ns"))(ctx)
+
+			return err
+		},
+		"ListAll": func(ctx context.Context) error {
+			_, err := k.ListAll(&corev1.ConfigMapList{}, client.InNamespace("ns"))(ctx)
+
+			return err
+		},
+		"Unstructured.List": func(ctx context.Context) error {
+			_, err := k.Unstructured().List("v1/ConfigMap", client.InNamespace("ns"))(ctx)
+
+			return err
+		},
+		"Unstructured.ListAll": func(ctx context.Context) error {
+			_, err := k.Unstructured().ListAll("v1/ConfigMap", client.InNamespace("ns"))(ctx)
+
+			return err
+		},
+	}
+
+	for name, fn := range fns {
+		err := fn(t.Context())
+
+		op := &k8s.OpError{}
+		g.Expect(errors.As(err, &op)).Should(BeTrue(), name)
+		g.Expect(op.Op).Should(Equal("list"), name)
+		g.Expect(op.GVK).Should(Equal(cmGVK), name)
+		g.Expect(op.Key).Should(Equal(client.ObjectKey{Namespace: "ns"}), name)
+
+		g.Expect(err).Should(MatchError(&k8s.OpError{Op: "list", GVK: cmGVK}), name)
+		g.Expect(err).ShouldNot(MatchError(&k8s.OpError{Op: "get"}), name)
+		g.Expect(err).ShouldNot(MatchError(&k8s.NotFoundError{}), name)
+		g.Expect(apierrors.IsForbidden(err)).Should(BeTrue(), name)
+		g.Expect(err).Should(MatchError(HavePrefix("unable to list ConfigMap in namespace ns: ")), name)
+	}
+
+	// not found errors are operation errors too
+	_, err := k.Unstructured().Get("v1/ConfigMap", client.ObjectKey{Namespace: "ns", Name: "missing"})(t.Context())
+
+	op := &k8s.OpError{}
+	g.Expect(errors.As(err, &op)).Should(BeTrue())
+	g.Expect(op.Op).Should(Equal("get"))
+	g.Expect(op.Key.Name).Should(Equal("missing"))
+	g.Expect(err).Should(MatchError(&k8s.NotFoundError{}))
+}
+
+// unknown is a type that is not registered in any scheme.
+type unknown struct {
+	corev1.ConfigMap
+}
+
+func (u *unknown) DeepCopyObject() runtime.Object {
+	return &unknown{ConfigMap: *u.ConfigMap.DeepCopy()}
+}
@@ -0,0 +1,52 @@
+package k8s
+
+import (
+	"context"
+
+	"github.com/onsi/gomega/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Eventually creates an asynchronous assertion bound to the context of the
+// Matcher and configured with the defaults set by WithDefaultTimeout and
+// WithDefaultPolling. Both can still be overridden on the returned assertion.
+func (m *Matcher) Eventually(actual any, args ...any) types.AsyncAssertion {
+	a := m.gomega.Eventually(actual, args...).WithContext(m.ctx)
+
+	if m.timeout > 0 {
+		a = a.WithTimeout(m.timeout)
+	}
+
+	if m.polling > 0 {
+		a = a.WithPolling(m.polling)
+	}
+
+	return a
+}
+
+// EventuallyGet creates an asynchronous assertion that repeatedly fetches
+// the given object, identified by its name and namespace, and matches it
+// once it has been retrieved.
+func (m *Matcher) EventuallyGet(obj client.Object) types.AsyncAssertion {
+	key := client.ObjectKeyFromObject(obj)
+
+	return m.Eventually(func(ctx context.Context) (client.Object, error) {
+		if err := m.client.Get(ctx, key, obj); err != nil {
+			return nil, newObjectError(m.client, "get", obj, key, err)
+		}
+
+		return obj, nil
+	})
+}
+
+// EventuallyList creates an asynchronous assertion that repeatedly lists the
+// objects matching the given options into list, and matches it.
+func (m *Matcher) EventuallyList(list client.ObjectList, opts ...client.ListOption) types.AsyncAssertion {
+	return m.Eventually(func(ctx context.Context) (client.ObjectList, error) {
+		if err := m.client.List(ctx, list, opts...); err != nil {
+			return nil, newListError(m.client, list, opts, err)
+		}
+
+		return list, nil
+	})
+}
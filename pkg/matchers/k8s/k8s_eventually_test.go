@@ -0,0 +1,96 @@
+package k8s_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestEventuallyGet(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	calls := atomic.Int32{}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"},
+			Data:       map[string]string{"foo": "bar"},
+		}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				// the object shows up after a few attempts
+				if calls.Add(1) < 3 {
+					return apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, key.Name)
+				}
+
+				return c.Get(ctx, key, obj, opts...)
+			},
+		}).
+		Build()
+
+	k := k8s.New(cli, scheme,
+		k8s.WithDefaultTimeout(5*time.Second),
+		k8s.WithDefaultPolling(10*time.Millisecond),
+	).WithContext(t.Context()).WithGomega(g)
+
+	cm := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"}}
+
+	k.EventuallyGet(&cm).Should(HaveField("Data", HaveKeyWithValue("foo", "bar")))
+	k.EventuallyList(&corev1.ConfigMapList{}, client.InNamespace("ns")).Should(HaveField("Items", HaveLen(1)))
+
+	g.Expect(calls.Load()).Should(BeNumerically(">=", 3))
+}
+
+func TestEventuallyDefaults(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		Build()
+
+	var failures []string
+
+	gg := NewGomega(func(message string, _ ...int) {
+		failures = append(failures, message)
+	})
+
+	k := k8s.New(cli, scheme,
+		k8s.WithDefaultTimeout(100*time.Millisecond),
+		k8s.WithDefaultPolling(10*time.Millisecond),
+	).WithContext(t.Context()).WithGomega(gg)
+
+	start := time.Now()
+
+	k.EventuallyGet(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "missing", Namespace: "ns"}}).Should(Not(BeNil()))
+
+	g.Expect(time.Since(start)).Should(BeNumerically("<", 5*time.Second))
+	g.Expect(failures).Should(ConsistOf(And(
+		ContainSubstring("unable to get ConfigMap ns/missing"),
+		ContainSubstring("not found"),
+	)))
+}
@@ -0,0 +1,104 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Evict returns a function evicting the pod with the given key through the
+// eviction subresource, as kubectl drain does, so that PodDisruptionBudgets
+// are honored:
+//
+//	Eventually(k.Evict(key)).WithContext(ctx).Should(Succeed())
+//	Expect(k.Evict(key)(ctx)).Should(k8s.RespectPDB())
+func (m *Matcher) Evict(key client.ObjectKey) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		pod := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		}
+
+		eviction := policyv1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		}
+
+		if err := m.client.SubResource("eviction").Create(ctx, &pod, &eviction); err != nil {
+			return newOpError("evict", corev1.SchemeGroupVersion.WithKind("Pod"), key, err)
+		}
+
+		return nil
+	}
+}
+
+// RespectPDB succeeds if the actual error, i.e. the one returned by Evict,
+// reports that the eviction has been rejected because it would violate a
+// PodDisruptionBudget.
+func RespectPDB() types.GomegaMatcher {
+	return &pdbMatcher{}
+}
+
+var _ types.GomegaMatcher = &pdbMatcher{}
+
+type pdbMatcher struct {
+	err error
+}
+
+func (matcher *pdbMatcher) Match(actual interface{}) (bool, error) {
+	if actual == nil {
+		matcher.err = nil
+
+		return false, nil
+	}
+
+	err, ok := actual.(error)
+	if !ok {
+		return false, fmt.Errorf("an error is expected, got %T", actual)
+	}
+
+	matcher.err = err
+
+	return isDisruptionBudgetError(err), nil
+}
+
+func (matcher *pdbMatcher) FailureMessage(_ interface{}) string {
+	if matcher.err == nil {
+		return "Expected eviction to be rejected by a PodDisruptionBudget, but it succeeded"
+	}
+
+	return fmt.Sprintf("Expected eviction to be rejected by a PodDisruptionBudget, but it failed with: %v", matcher.err)
+}
+
+func (matcher *pdbMatcher) NegatedFailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected eviction not to be rejected by a PodDisruptionBudget, but it failed with: %v", matcher.err)
+}
+
+func isDisruptionBudgetError(err error) bool {
+	if !apierrors.IsTooManyRequests(err) {
+		return false
+	}
+
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) {
+		return false
+	}
+
+	details := status.Status().Details
+	if details == nil {
+		return false
+	}
+
+	for _, c := range details.Causes {
+		if c.Type == policyv1.DisruptionBudgetCause {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,99 @@
+package k8s_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	. "github.com/onsi/gomega"
+)
+
+// pdbViolation is the error returned by the API server when an eviction
+// would violate a PodDisruptionBudget.
+func pdbViolation() error {
+	return &apierrors.StatusError{ErrStatus: metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    429,
+		Reason:  metav1.StatusReasonTooManyRequests,
+		Message: "Cannot evict pod as it would violate the pod's disruption budget.",
+		Details: &metav1.StatusDetails{
+			Causes: []metav1.StatusCause{{
+				Type:    policyv1.DisruptionBudgetCause,
+				Message: "The disruption budget app needs 1 healthy pods and has 1 currently",
+			}},
+		},
+	}}
+}
+
+func TestEvict(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	pods := []client.Object{
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "free", Namespace: "ns"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "protected", Namespace: "ns"}},
+	}
+
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pods...).Build()
+
+	// the fake client does not implement PodDisruptionBudgets
+	k := k8s.New(cli, scheme, k8s.WithInterceptors(interceptor.Funcs{
+		SubResourceCreate: func(ctx context.Context, c client.Client, sub string, obj client.Object, sr client.Object, opts ...client.SubResourceCreateOption) error {
+			if sub == "eviction" && obj.GetName() == "protected" {
+				return pdbViolation()
+			}
+
+			return c.SubResource(sub).Create(ctx, obj, sr, opts...)
+		},
+	}))
+
+	free := client.ObjectKeyFromObject(pods[0])
+	protected := client.ObjectKeyFromObject(pods[1])
+
+	g.Expect(k.Evict(free)(t.Context())).Should(Succeed())
+	g.Expect(cli.Get(t.Context(), free, &corev1.Pod{})).Should(MatchError(apierrors.IsNotFound, "IsNotFound"))
+
+	err := k.Evict(protected)(t.Context())
+	g.Expect(err).Should(k8s.RespectPDB())
+	g.Expect(cli.Get(t.Context(), protected, &corev1.Pod{})).Should(Succeed())
+
+	err = k.Evict(free)(t.Context())
+	g.Expect(err).Should(MatchError(&k8s.NotFoundError{}))
+	g.Expect(err).ShouldNot(k8s.RespectPDB())
+}
+
+func TestRespectPDB(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	g.Expect(pdbViolation()).Should(k8s.RespectPDB())
+	g.Expect(nil).ShouldNot(k8s.RespectPDB())
+	g.Expect(apierrors.NewTooManyRequests("slow down", 1)).ShouldNot(k8s.RespectPDB())
+	g.Expect(errors.New("boom")).ShouldNot(k8s.RespectPDB())
+
+	m := k8s.RespectPDB()
+
+	ok, err := m.Match(nil)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ok).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(ContainSubstring("but it succeeded"))
+
+	_, err = m.Match("boom")
+	g.Expect(err).Should(HaveOccurred())
+}
@@ -0,0 +1,174 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// NewFromConfig creates a Matcher from the given REST config. The scheme is
+// populated with the built-in Kubernetes types, CustomResourceDefinitions and
+// any type registered with WithAddToScheme, and a cached discovery client is
+// configured so that resources can be referenced by short name. When
+// WithCache is given, reads are served by a shared informer cache.
+func NewFromConfig(cfg *rest.Config, opts ...Option) (*Matcher, error) {
+	m := newMatcher(opts...)
+
+	scheme, err := newScheme(m.addToScheme)
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create client: %w", err)
+	}
+
+	live := cli
+
+	if m.cacheCtx != nil {
+		cli, err = newCachedClient(m.cacheCtx, cfg, scheme)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if m.discovery == nil {
+		dc, err := discovery.NewDiscoveryClientForConfig(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create discovery client: %w", err)
+		}
+
+		m.discovery = memory.NewMemCacheClient(dc)
+	}
+
+	m.setup(cli, scheme)
+	m.config = cfg
+
+	if m.cacheCtx != nil {
+		m.live = m.instrument(live)
+	}
+
+	return m, nil
+}
+
+// NewFromKubeconfig creates a Matcher from the given kubeconfig file and
+// context. When path is empty the default loading rules apply (i.e. the
+// KUBECONFIG environment variable, then $HOME/.kube/config), when kubeContext is
+// empty the current context of the kubeconfig is used.
+func NewFromKubeconfig(path string, kubeContext string, opts ...Option) (*Matcher, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if path != "" {
+		rules.ExplicitPath = path
+	}
+
+	overrides := clientcmd.ConfigOverrides{
+		CurrentContext: kubeContext,
+	}
+
+	cfg, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &overrides).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("unable to load kubeconfig: %w", err)
+	}
+
+	return NewFromConfig(cfg, opts...)
+}
+
+// NewFake creates a Matcher backed by a controller-runtime fake client seeded
+// with the given objects, replacing the scheme and client builder set up of
+// unit tests:
+//
+//	k := k8s.NewFake(&deployment, &secret).WithContext(t.Context())
+//
+// The scheme holds the same types as the one of NewFromConfig, use
+// NewFakeWithOptions to register additional types or to configure the
+// Matcher. No discovery client is available, so resources must be referenced
+// in the group/version/Kind form.
+func NewFake(objs ...client.Object) *Matcher {
+	return NewFakeWithOptions(nil, objs...)
+}
+
+// NewFakeWithOptions is like NewFake, but applies the given options, i.e.
+// WithAddToScheme to register custom types:
+//
+//	k := k8s.NewFakeWithOptions([]k8s.Option{k8s.WithAddToScheme(myapiv1.AddToScheme)}, &myResource)
+//
+// It panics if the scheme cannot be populated, which only happens with
+// conflicting type registrations.
+func NewFakeWithOptions(opts []Option, objs ...client.Object) *Matcher {
+	m := newMatcher(opts...)
+
+	scheme, err := newScheme(m.addToScheme)
+	if err != nil {
+		panic(err)
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		Build()
+
+	m.setup(cli, scheme)
+
+	return m
+}
+
+// newScheme creates a scheme holding the built-in Kubernetes types,
+// CustomResourceDefinitions and the types registered by the given functions.
+func newScheme(addToScheme []func(*runtime.Scheme) error) (*runtime.Scheme, error) {
+	scheme := runtime.NewScheme()
+
+	fns := []func(*runtime.Scheme) error{
+		clientgoscheme.AddToScheme,
+		apiextensionsv1.AddToScheme,
+	}
+
+	for _, fn := range append(fns, addToScheme...) {
+		if err := fn(scheme); err != nil {
+			return nil, fmt.Errorf("unable to populate scheme: %w", err)
+		}
+	}
+
+	return scheme, nil
+}
+
+func newCachedClient(ctx context.Context, cfg *rest.Config, scheme *runtime.Scheme) (client.Client, error) {
+	c, err := cache.New(cfg, cache.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create cache: %w", err)
+	}
+
+	go func() {
+		// informers are created on demand, so the only failure Start can
+		// report is an already started cache, which cannot happen here
+		_ = c.Start(ctx)
+	}()
+
+	if !c.WaitForCacheSync(ctx) {
+		return nil, errors.New("unable to start cache")
+	}
+
+	cli, err := client.New(cfg, client.Options{
+		Scheme: scheme,
+		Cache: &client.CacheOptions{
+			Reader:       c,
+			Unstructured: true,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create cached client: %w", err)
+	}
+
+	return cli, nil
+}
@@ -0,0 +1,161 @@
+package k8s_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+
+	. "github.com/onsi/gomega"
+)
+
+const kubeconfig = `
+apiVersion: v1
+kind: Config
+clusters:
+- name: dev
+  cluster:
+    server: https://dev.example.com:6443
+- name: prod
+  cluster:
+    server: https://prod.example.com:6443
+users:
+- name: admin
+  user:
+    token: secret
+contexts:
+- name: dev
+  context:
+    cluster: dev
+    user: admin
+- name: prod
+  context:
+    cluster: prod
+    user: admin
+current-context: dev
+`
+
+func TestNewFromConfig(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Foo"}
+
+	addToScheme := func(s *runtime.Scheme) error {
+		s.AddKnownTypeWithName(gvk, &runtime.Unknown{})
+
+		return nil
+	}
+
+	cfg := &rest.Config{Host: "https://127.0.0.1:6443"}
+
+	k, err := k8s.NewFromConfig(cfg, k8s.WithAddToScheme(addToScheme))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(k.Config()).Should(BeIdenticalTo(cfg))
+	g.Expect(k.Scheme().Recognizes(gvk)).Should(BeTrue())
+	g.Expect(k.Scheme().Recognizes(schema.GroupVersionKind{Version: "v1", Kind: "Pod"})).Should(BeTrue())
+	g.Expect(k.Scheme().Recognizes(schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"})).Should(BeTrue())
+	g.Expect(k.Client().Scheme()).Should(BeIdenticalTo(k.Scheme()))
+}
+
+func TestNewFromConfigAppliesOptionsOnce(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	calls := 0
+	count := func(_ *k8s.Matcher) { calls++ }
+
+	_, err := k8s.NewFromConfig(&rest.Config{Host: "https://127.0.0.1:6443"}, count)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(calls).Should(Equal(1))
+
+	_ = k8s.NewFakeWithOptions([]k8s.Option{count})
+	g.Expect(calls).Should(Equal(2))
+}
+
+func TestNewFromKubeconfig(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	g.Expect(os.WriteFile(path, []byte(kubeconfig), 0o600)).Should(Succeed())
+
+	k, err := k8s.NewFromKubeconfig(path, "")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(k.Config().Host).Should(Equal("https://dev.example.com:6443"))
+
+	k, err = k8s.NewFromKubeconfig(path, "prod")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(k.Config().Host).Should(Equal("https://prod.example.com:6443"))
+	g.Expect(k.Config().BearerToken).Should(Equal("secret"))
+
+	_, err = k8s.NewFromKubeconfig(path, "staging")
+	g.Expect(err).Should(HaveOccurred())
+}
+
+func TestNewFromConfigWithCache(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	k, err := k8s.NewFromConfig(&rest.Config{Host: "https://127.0.0.1:6443"}, k8s.WithCache(t.Context()))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(k.Live().Client()).ShouldNot(BeIdenticalTo(k.Client()))
+	g.Expect(k.Live().Live().Client()).Should(BeIdenticalTo(k.Live().Client()))
+
+	k, err = k8s.NewFromConfig(&rest.Config{Host: "https://127.0.0.1:6443"})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(k.Live().Client()).Should(BeIdenticalTo(k.Client()))
+}
+
+func TestNewFake(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"},
+		Data:       map[string]string{"a": "b"},
+	}
+
+	k := k8s.NewFake(cm).WithContext(t.Context())
+
+	g.Expect(k.Scheme().Recognizes(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"})).Should(BeTrue())
+	g.Expect(k.Scheme().Recognizes(schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"})).Should(BeTrue())
+	g.Expect(k.Object(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"}})()).
+		Should(jq.Match(`.data.a == "b"`))
+}
+
+func TestNewFakeWithOptions(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Foo"}
+
+	k := k8s.NewFakeWithOptions([]k8s.Option{k8s.WithAddToScheme(func(s *runtime.Scheme) error {
+		s.AddKnownTypeWithName(gvk, &runtime.Unknown{})
+
+		return nil
+	})})
+
+	g.Expect(k.Scheme().Recognizes(gvk)).Should(BeTrue())
+	g.Expect(k.Client().Scheme()).Should(BeIdenticalTo(k.Scheme()))
+
+	g.Expect(func() {
+		k8s.NewFakeWithOptions([]k8s.Option{k8s.WithAddToScheme(func(_ *runtime.Scheme) error {
+			return errors.New("conflict")
+		})})
+	}).Should(PanicWith(MatchError(ContainSubstring("conflict"))))
+}
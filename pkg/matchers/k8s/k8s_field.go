@@ -0,0 +1,183 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/onsi/gomega/types"
+)
+
+// HaveField succeeds if the field at the given path, i.e. .spec.foo.bar, is
+// set on the actual object, whatever its value, including null. Segments
+// containing dots can be quoted, i.e. .metadata.labels["app.kubernetes.io/name"].
+// Unlike gomega.HaveField, only the presence of the field is checked, and the
+// failure message reports the first missing segment of the path.
+func HaveField(path string) types.GomegaMatcher {
+	return &fieldMatcher{
+		Path: path,
+	}
+}
+
+// NotHaveField succeeds if the field at the given path is not set on the
+// actual object, see HaveField.
+func NotHaveField(path string) types.GomegaMatcher {
+	return &fieldMatcher{
+		Path:   path,
+		absent: true,
+	}
+}
+
+var _ types.GomegaMatcher = &fieldMatcher{}
+
+type fieldMatcher struct {
+	Path   string
+	absent bool
+
+	kind    string
+	missing string
+}
+
+func (matcher *fieldMatcher) Match(actual interface{}) (bool, error) {
+	fields, err := parseFieldPath(matcher.Path)
+	if err != nil {
+		return false, err
+	}
+
+	obj, err := toObject(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.kind = kindOf(obj)
+	matcher.missing = lookupField(obj, fields)
+
+	if matcher.absent {
+		return matcher.missing != "", nil
+	}
+
+	return matcher.missing == "", nil
+}
+
+func (matcher *fieldMatcher) FailureMessage(_ interface{}) string {
+	if matcher.absent {
+		return fmt.Sprintf("Expected %s not to have field %s, but it is set", matcher.kind, matcher.Path)
+	}
+
+	return fmt.Sprintf("Expected %s to have field %s, but %s", matcher.kind, matcher.Path, matcher.missing)
+}
+
+func (matcher *fieldMatcher) NegatedFailureMessage(_ interface{}) string {
+	if matcher.absent {
+		return fmt.Sprintf("Expected %s to have field %s, but %s", matcher.kind, matcher.Path, matcher.missing)
+	}
+
+	return fmt.Sprintf("Expected %s not to have field %s, but it is set", matcher.kind, matcher.Path)
+}
+
+// lookupField walks the given fields with the semantics of
+// unstructured.NestedFieldNoCopy, returning a description of the first
+// segment that could not be found, or an empty string if the field is set.
+func lookupField(obj map[string]any, fields []string) string {
+	var current any = obj
+
+	for i, field := range fields {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return fmt.Sprintf("%s is %s, not an object", formatFieldPath(fields[:i]), describeValue(current))
+		}
+
+		current, ok = m[field]
+		if !ok {
+			return formatFieldPath(fields[:i+1]) + " is missing"
+		}
+	}
+
+	return ""
+}
+
+// parseFieldPath splits a path like .spec.selector.matchLabels["app.kubernetes.io/name"]
+// into its segments.
+func parseFieldPath(path string) ([]string, error) {
+	var fields []string
+
+	rest := path
+
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, `["`):
+			end := strings.Index(rest[2:], `"]`)
+			if end < 0 {
+				return nil, fmt.Errorf("invalid field path %s: unterminated quoted segment", path)
+			}
+
+			field, err := strconv.Unquote(rest[1 : end+3])
+			if err != nil {
+				return nil, fmt.Errorf("invalid field path %s: %w", path, err)
+			}
+
+			fields = append(fields, field)
+			rest = rest[end+4:]
+		case strings.HasPrefix(rest, "."):
+			rest = rest[1:]
+
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+
+			if end == 0 {
+				if strings.HasPrefix(rest, "[") && len(fields) == 0 {
+					continue
+				}
+
+				return nil, fmt.Errorf("invalid field path %s: empty segment", path)
+			}
+
+			fields = append(fields, rest[:end])
+			rest = rest[end:]
+		default:
+			return nil, fmt.Errorf("invalid field path %s: segments must start with . or [", path)
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil, errors.New("invalid field path: no segments")
+	}
+
+	return fields, nil
+}
+
+func formatFieldPath(fields []string) string {
+	if len(fields) == 0 {
+		return "the object"
+	}
+
+	var sb strings.Builder
+
+	for _, f := range fields {
+		if strings.ContainsAny(f, `.[]"`) {
+			sb.WriteString("[" + strconv.Quote(f) + "]")
+		} else {
+			sb.WriteString("." + f)
+		}
+	}
+
+	return sb.String()
+}
+
+func describeValue(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case []any:
+		return "an array"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	default:
+		return "a number"
+	}
+}
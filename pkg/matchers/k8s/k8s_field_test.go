@@ -0,0 +1,70 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestHaveField(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	deploy := appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "app",
+			Labels: map[string]string{"app.kubernetes.io/name": "app"},
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: ptrTo[int32](0)},
+	}
+
+	g.Expect(&deploy).Should(k8s.HaveField(".spec.replicas"))
+	g.Expect(&deploy).Should(k8s.HaveField(`.metadata.labels["app.kubernetes.io/name"]`))
+	g.Expect(&deploy).Should(k8s.NotHaveField(".spec.paused"))
+	g.Expect(&deploy).Should(k8s.NotHaveField(`.metadata.labels["app.kubernetes.io/version"]`))
+	g.Expect(&deploy).ShouldNot(k8s.HaveField(".spec.paused"))
+	g.Expect(&deploy).ShouldNot(k8s.NotHaveField(".spec.replicas"))
+
+	// null values are set, as with unstructured.NestedFieldNoCopy
+	g.Expect(`{"spec":{"foo":null}}`).Should(k8s.HaveField(".spec.foo"))
+	g.Expect(`{"spec":{"foo":null}}`).ShouldNot(k8s.HaveField(".spec.foo.bar"))
+}
+
+func TestHaveFieldFailureMessage(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	in := `{"kind":"Foo","spec":{"foo":{"bar":1},"list":[1]}}`
+
+	m := k8s.HaveField(".spec.foo.baz.qux")
+	g.Expect(m.Match(in)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(Equal("Expected Foo to have field .spec.foo.baz.qux, but .spec.foo.baz is missing"))
+
+	m = k8s.HaveField(".spec.list.item")
+	g.Expect(m.Match(in)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(Equal("Expected Foo to have field .spec.list.item, but .spec.list is an array, not an object"))
+
+	m = k8s.HaveField(`.metadata["a.b"]`)
+	g.Expect(m.Match(in)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(Equal(`Expected Foo to have field .metadata["a.b"], but .metadata is missing`))
+
+	m = k8s.NotHaveField(".spec.foo.bar")
+	g.Expect(m.Match(in)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(Equal("Expected Foo not to have field .spec.foo.bar, but it is set"))
+
+	m = k8s.NotHaveField(".spec.foo.baz")
+	g.Expect(m.Match(in)).Should(BeTrue())
+	g.Expect(m.NegatedFailureMessage(nil)).Should(Equal("Expected Foo to have field .spec.foo.baz, but .spec.foo.baz is missing"))
+
+	for _, path := range []string{"", "spec", ".spec.", ".spec..foo", `.spec["foo`} {
+		_, err := k8s.HaveField(path).Match(in)
+		g.Expect(err).Should(MatchError(ContainSubstring("invalid field path")), path)
+	}
+}
@@ -0,0 +1,182 @@
+package k8s
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"text/template"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// ApplyFixtures creates or updates the resources defined by the YAML files
+// matching the given glob pattern, i.e. "testdata/fixtures/*.yaml", or held
+// by the given directory. Files can contain multiple documents as well as
+// List kinds and, when data is not nil, are rendered as Go templates with
+// the given data first (see TemplateFuncs for the available functions).
+// Files are processed in lexical order and documents in order of appearance,
+// and the applied resources are returned in the same order so that they can
+// be used in later assertions.
+func ApplyFixtures(ctx context.Context, cli client.Client, pattern string, data any) ([]*unstructured.Unstructured, error) {
+	files, err := fixtureFiles(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []*unstructured.Unstructured
+
+	for _, file := range files {
+		objects, err := loadFixture(file, data)
+		if err != nil {
+			return applied, err
+		}
+
+		for _, obj := range objects {
+			if err := createOrUpdate(ctx, cli, obj); err != nil {
+				return applied, fmt.Errorf("unable to apply %s %s from %s: %w", obj.GetKind(), client.ObjectKeyFromObject(obj), file, err)
+			}
+
+			applied = append(applied, obj)
+		}
+	}
+
+	return applied, nil
+}
+
+// fixtureFiles returns the files matching the given pattern, or the YAML
+// files of the given directory, sorted.
+func fixtureFiles(pattern string) ([]string, error) {
+	if fi, err := os.Stat(pattern); err == nil && fi.IsDir() {
+		pattern = filepath.Join(pattern, "*")
+	}
+
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("unable to glob %s: %w", pattern, err)
+	}
+
+	files := make([]string, 0, len(matches))
+
+	for _, m := range matches {
+		switch filepath.Ext(m) {
+		case ".yaml", ".yml", ".json":
+			files = append(files, m)
+		}
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no fixtures found matching %s", pattern)
+	}
+
+	slices.Sort(files)
+
+	return files, nil
+}
+
+// loadFixture reads the objects defined by the given file, rendering it as a
+// template if data is not nil.
+func loadFixture(file string, data any) ([]*unstructured.Unstructured, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", file, err)
+	}
+
+	if data != nil {
+		tmpl, err := template.New(filepath.Base(file)).Funcs(TemplateFuncs()).Option("missingkey=error").Parse(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse template %s: %w", file, err)
+		}
+
+		out := bytes.Buffer{}
+		if err := tmpl.Execute(&out, data); err != nil {
+			return nil, fmt.Errorf("unable to render template %s: %w", file, err)
+		}
+
+		content = out.Bytes()
+	}
+
+	objects, err := decodeObjects(content)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode %s: %w", file, err)
+	}
+
+	return objects, nil
+}
+
+// decodeObjects decodes the objects defined by the given multi-document YAML
+// or JSON content, expanding List kinds and skipping empty documents.
+func decodeObjects(content []byte) ([]*unstructured.Unstructured, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+
+	var objects []*unstructured.Unstructured
+
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := yaml.YAMLToJSON(doc)
+		if err != nil {
+			return nil, err
+		}
+
+		if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+			continue
+		}
+
+		obj := unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(data); err != nil {
+			return nil, err
+		}
+
+		if !obj.IsList() {
+			objects = append(objects, &obj)
+
+			continue
+		}
+
+		list, err := obj.ToList()
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	}
+}
+
+// createOrUpdate creates the given object, or updates it if it already
+// exists.
+func createOrUpdate(ctx context.Context, cli client.Client, obj *unstructured.Unstructured) error {
+	err := cli.Create(ctx, obj)
+	if !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	existing := unstructured.Unstructured{}
+	existing.SetGroupVersionKind(obj.GroupVersionKind())
+
+	if err := cli.Get(ctx, client.ObjectKeyFromObject(obj), &existing); err != nil {
+		return err
+	}
+
+	obj.SetResourceVersion(existing.GetResourceVersion())
+
+	return cli.Update(ctx, obj)
+}
@@ -0,0 +1,109 @@
+package k8s_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+const fixtureDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: {{ .Namespace }}
+spec:
+  replicas: 3
+  selector:
+    matchLabels:
+      app: app
+  template:
+    metadata:
+      labels:
+        app: app
+    spec:
+      containers:
+      - name: app
+        image: {{ .Image }}
+`
+
+const fixtureConfig = `
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  namespace: {{ .Namespace }}
+data:
+  foo: bar
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Secret
+  metadata:
+    name: secret
+    namespace: {{ .Namespace }}
+  stringData:
+    token: xyz
+`
+
+func TestApplyFixtures(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	g.Expect(os.WriteFile(filepath.Join(dir, "01-config.yaml"), []byte(fixtureConfig), 0o600)).Should(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "02-deployment.yaml"), []byte(fixtureDeployment), 0o600)).Should(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a fixture"), 0o600)).Should(Succeed())
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"},
+			Data:       map[string]string{"foo": "baz"},
+		}).
+		Build()
+
+	data := map[string]any{"Namespace": "ns", "Image": "quay.io/app:latest"}
+
+	objects, err := k8s.ApplyFixtures(t.Context(), cli, dir, data)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(objects).Should(HaveLen(3))
+	g.Expect(objects[0].GetKind()).Should(Equal("ConfigMap"))
+	g.Expect(objects[1].GetKind()).Should(Equal("Secret"))
+	g.Expect(objects[2].GetKind()).Should(Equal("Deployment"))
+
+	u := k8s.New(cli, scheme).Unstructured()
+
+	g.Expect(u.Get("v1/ConfigMap", client.ObjectKey{Namespace: "ns", Name: "cm"})(t.Context())).
+		Should(jq.Match(`.data.foo == "bar"`))
+	g.Expect(u.Get("apps/v1/Deployment", client.ObjectKey{Namespace: "ns", Name: "app"})(t.Context())).
+		Should(jq.Match(`.spec.replicas == 3 and .spec.template.spec.containers[0].image == "quay.io/app:latest"`))
+
+	objects, err = k8s.ApplyFixtures(t.Context(), cli, filepath.Join(dir, "*-config.yaml"), data)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(objects).Should(HaveLen(2))
+
+	_, err = k8s.ApplyFixtures(t.Context(), cli, dir, map[string]any{"Namespace": "ns"})
+	g.Expect(err).Should(MatchError(ContainSubstring(`map has no entry for key "Image"`)))
+
+	_, err = k8s.ApplyFixtures(t.Context(), cli, filepath.Join(dir, "*.json"), nil)
+	g.Expect(err).Should(MatchError(ContainSubstring("no fixtures found")))
+}
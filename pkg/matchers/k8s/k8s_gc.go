@@ -0,0 +1,113 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Dependents returns a pollable function listing the objects of the given
+// kinds having an ownerReference to the given owner, identified by UID. The
+// objects are looked up in the namespace of the owner or, if it is cluster
+// scoped, in all namespaces. It can be used to wait for cascade deletion:
+//
+//	Eventually(k.Dependents(owner, gvks...)).WithContext(ctx).Should(k8s.HaveItems(0))
+func (m *Matcher) Dependents(owner client.Object, gvks ...schema.GroupVersionKind) func(ctx context.Context) (*unstructured.UnstructuredList, error) {
+	return func(ctx context.Context) (*unstructured.UnstructuredList, error) {
+		return m.dependents(ctx, string(owner.GetUID()), owner.GetNamespace(), gvks)
+	}
+}
+
+// BeGarbageCollected succeeds if no object of the given kinds has an
+// ownerReference to the actual owner, i.e. once all its dependents have been
+// removed by the garbage collector. As the dependents are looked up on each
+// evaluation, it can be polled:
+//
+//	k.Eventually(owner).Should(k.BeGarbageCollected(gvks...))
+func (m *Matcher) BeGarbageCollected(gvks ...schema.GroupVersionKind) types.GomegaMatcher {
+	return &garbageCollectedMatcher{
+		matcher: m,
+		gvks:    gvks,
+	}
+}
+
+var _ types.GomegaMatcher = &garbageCollectedMatcher{}
+
+type garbageCollectedMatcher struct {
+	matcher    *Matcher
+	gvks       []schema.GroupVersionKind
+	dependents []string
+}
+
+func (matcher *garbageCollectedMatcher) Match(actual interface{}) (bool, error) {
+	obj, err := toObject(actual)
+	if err != nil {
+		return false, err
+	}
+
+	owner := unstructured.Unstructured{Object: obj}
+	if owner.GetUID() == "" {
+		return false, errors.New("the owner has no uid, it must be retrieved from the API server first")
+	}
+
+	list, err := matcher.matcher.dependents(matcher.matcher.ctx, string(owner.GetUID()), owner.GetNamespace(), matcher.gvks)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.dependents = make([]string, 0, len(list.Items))
+	for i := range list.Items {
+		matcher.dependents = append(matcher.dependents, snapshotKey(&list.Items[i]))
+	}
+
+	return len(matcher.dependents) == 0, nil
+}
+
+func (matcher *garbageCollectedMatcher) FailureMessage(_ interface{}) string {
+	return matcher.matcher.annotate(fmt.Sprintf("Expected all dependents to be garbage collected, but %d remain:\n%s",
+		len(matcher.dependents), strings.Join(matcher.dependents, "\n")))
+}
+
+func (matcher *garbageCollectedMatcher) NegatedFailureMessage(_ interface{}) string {
+	return matcher.matcher.annotate("Expected some dependents not to be garbage collected, but none remain")
+}
+
+func (m *Matcher) dependents(ctx context.Context, uid string, namespace string, gvks []schema.GroupVersionKind) (*unstructured.UnstructuredList, error) {
+	out := unstructured.UnstructuredList{}
+
+	var opts []client.ListOption
+	if namespace != "" {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+
+	for _, gvk := range gvks {
+		list := unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+		if err := listAll(ctx, m.client, &list, opts); err != nil {
+			return nil, fmt.Errorf("unable to list %s: %w", gvk.Kind, err)
+		}
+
+		for i := range list.Items {
+			for _, ref := range list.Items[i].GetOwnerReferences() {
+				if string(ref.UID) != uid {
+					continue
+				}
+
+				item := list.Items[i]
+				item.SetGroupVersionKind(gvk)
+				out.Items = append(out.Items, item)
+
+				break
+			}
+		}
+	}
+
+	return &out, nil
+}
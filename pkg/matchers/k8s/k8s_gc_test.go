@@ -0,0 +1,80 @@
+package k8s_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestBeGarbageCollected(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	owner := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns", UID: types.UID("owner-uid")},
+	}
+
+	ref := metav1.OwnerReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "app", UID: owner.UID}
+
+	dependent := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns", OwnerReferences: []metav1.OwnerReference{ref}},
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			owner,
+			dependent,
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "ns", OwnerReferences: []metav1.OwnerReference{ref}}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "ns"}},
+		).
+		Build()
+
+	k := k8s.New(cli, scheme, k8s.WithDefaultTimeout(time.Second), k8s.WithDefaultPolling(10*time.Millisecond)).
+		WithContext(t.Context()).
+		WithGomega(g)
+
+	gvks := []schema.GroupVersionKind{
+		corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+		corev1.SchemeGroupVersion.WithKind("Secret"),
+	}
+
+	g.Expect(k.Dependents(owner, gvks...)(t.Context())).Should(k8s.HaveItems(2))
+
+	m := k.BeGarbageCollected(gvks...)
+	g.Expect(m.Match(owner)).Should(BeFalse())
+	g.Expect(m.FailureMessage(owner)).Should(And(
+		ContainSubstring("but 2 remain"),
+		ContainSubstring("v1/ConfigMap ns/cm"),
+		ContainSubstring("v1/Secret ns/secret"),
+	))
+
+	g.Expect(owner).Should(k.BeGarbageCollected(corev1.SchemeGroupVersion.WithKind("Service")))
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+
+		_ = cli.Delete(t.Context(), dependent)
+		_ = cli.Delete(t.Context(), &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "ns"}})
+	}()
+
+	k.Eventually(owner).Should(k.BeGarbageCollected(gvks...))
+
+	_, err := k.BeGarbageCollected(gvks...).Match(&appsv1.Deployment{})
+	g.Expect(err).Should(MatchError(ContainSubstring("the owner has no uid")))
+}
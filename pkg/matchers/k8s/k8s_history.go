@@ -0,0 +1,222 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// defaultHistorySize is the number of distinct samples kept by a History
+// unless WithSize is used.
+const defaultHistorySize = 32
+
+// Sample is a summary of one or more consecutive identical values observed
+// by a History.
+type Sample struct {
+	// First and Last are the times the summarized value has been observed at
+	// for the first and the last time.
+	First time.Time
+	Last  time.Time
+	// Count is the number of consecutive times the value has been observed.
+	Count int
+	// Status is the .status of the observed object, rendered as JSON, or the
+	// value itself for anything but a Kubernetes object.
+	Status string
+	// Err is the error returned by the sampled function, if any.
+	Err error
+}
+
+func (s Sample) String() string {
+	summary := "status: " + s.Status
+	if s.Err != nil {
+		summary = "error: " + s.Err.Error()
+	}
+
+	if s.Count > 1 {
+		return fmt.Sprintf("%s %s (observed %d times until %s)",
+			s.First.Format(time.RFC3339Nano),
+			summary,
+			s.Count,
+			s.Last.Format(time.RFC3339Nano),
+		)
+	}
+
+	return fmt.Sprintf("%s %s", s.First.Format(time.RFC3339Nano), summary)
+}
+
+// History records a summary of every value returned by a pollable function,
+// so that how a resource evolved while Eventually was polling can be shown
+// when the assertion fails, rather than only the last sample.
+type History[T any] struct {
+	fn func(ctx context.Context) (T, error)
+
+	lock    sync.Mutex
+	size    int
+	samples []Sample
+	dropped int
+}
+
+// Record wraps a pollable function, recording a summary of the .status of
+// every value it returns, i.e.:
+//
+//	h := k8s.Record(u.Get("apps/v1/Deployment", key))
+//	h.LogOnFailure(t)
+//
+//	Eventually(h.Poll).WithContext(ctx).Should(jq.Match(`.status.readyReplicas == 3`))
+//
+// Consecutive identical summaries are collapsed into a single Sample, and
+// only the last 32 distinct ones are kept, see WithSize.
+func Record[T any](fn func(ctx context.Context) (T, error)) *History[T] {
+	return &History[T]{
+		fn:   fn,
+		size: defaultHistorySize,
+	}
+}
+
+// WithSize sets the number of distinct samples kept, older samples are
+// discarded first.
+func (h *History[T]) WithSize(size int) *History[T] {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.size = max(size, 1)
+	h.trim()
+
+	return h
+}
+
+// Poll invokes the wrapped function and records a summary of its result,
+// which is returned unchanged.
+func (h *History[T]) Poll(ctx context.Context) (T, error) {
+	v, err := h.fn(ctx)
+
+	h.add(time.Now(), summarize(v, err), err)
+
+	return v, err
+}
+
+// Samples returns a copy of the recorded samples, oldest first.
+func (h *History[T]) Samples() []Sample {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return append([]Sample(nil), h.samples...)
+}
+
+// Reset discards the recorded samples.
+func (h *History[T]) Reset() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.samples = nil
+	h.dropped = 0
+}
+
+func (h *History[T]) String() string {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	items := make([]string, 0, len(h.samples)+1)
+	if h.dropped > 0 {
+		items = append(items, fmt.Sprintf("(%d older samples discarded)", h.dropped))
+	}
+
+	for _, s := range h.samples {
+		items = append(items, s.String())
+	}
+
+	return strings.Join(items, "\n")
+}
+
+// LogOnFailure registers a cleanup function that logs the recorded samples
+// if the test has failed.
+func (h *History[T]) LogOnFailure(t testing.TB) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Logf("observed values:\n%s", h)
+		}
+	})
+}
+
+// ReportOnFailure adds the recorded samples as a Ginkgo report entry if the
+// current spec has failed. It is meant to be invoked from an AfterEach node.
+func (h *History[T]) ReportOnFailure() {
+	if ginkgo.CurrentSpecReport().Failed() {
+		ginkgo.AddReportEntry("observed values", h.String(), ginkgo.ReportEntryVisibilityFailureOrVerbose)
+	}
+}
+
+func (h *History[T]) add(now time.Time, status string, err error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if n := len(h.samples); n > 0 {
+		last := &h.samples[n-1]
+
+		if last.Status == status && errorString(last.Err) == errorString(err) {
+			last.Last = now
+			last.Count++
+
+			return
+		}
+	}
+
+	h.samples = append(h.samples, Sample{
+		First:  now,
+		Last:   now,
+		Count:  1,
+		Status: status,
+		Err:    err,
+	})
+
+	h.trim()
+}
+
+func (h *History[T]) trim() {
+	if excess := len(h.samples) - h.size; excess > 0 {
+		h.samples = append(h.samples[:0:0], h.samples[excess:]...)
+		h.dropped += excess
+	}
+}
+
+// summarize renders the .status of the given value, the value itself if it
+// is not a Kubernetes object.
+func summarize(v any, err error) string {
+	if err != nil {
+		return ""
+	}
+
+	obj, oerr := toObject(v)
+	if oerr != nil {
+		return fmt.Sprintf("%v", v)
+	}
+
+	status, ok, _ := unstructured.NestedFieldNoCopy(obj, "status")
+	if !ok {
+		return "{}"
+	}
+
+	data, merr := json.Marshal(status)
+	if merr != nil {
+		return fmt.Sprintf("%v", status)
+	}
+
+	return string(data)
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,96 @@
+package k8s_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRecord(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"},
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(deploy).
+		WithStatusSubresource(deploy).
+		Build()
+
+	k := k8s.New(cli, scheme)
+	key := client.ObjectKeyFromObject(deploy)
+
+	// every poll bumps the ready replicas, until 3 are reported
+	h := k8s.Record(func(ctx context.Context) (*appsv1.Deployment, error) {
+		d := appsv1.Deployment{}
+		if err := k.Client().Get(ctx, key, &d); err != nil {
+			return nil, err
+		}
+
+		if d.Status.ReadyReplicas < 3 {
+			d.Status.ReadyReplicas++
+			if err := k.Client().Status().Update(ctx, &d); err != nil {
+				return nil, err
+			}
+		}
+
+		return &d, nil
+	})
+
+	g.Eventually(h.Poll).WithContext(t.Context()).WithTimeout(2 * time.Second).Should(WithTransform(json.Marshal, jq.Match(`.status.readyReplicas == 3`)))
+	g.Expect(h.Poll(t.Context())).Should(WithTransform(json.Marshal, jq.Match(`.status.readyReplicas == 3`)))
+
+	samples := h.Samples()
+	g.Expect(samples).Should(HaveLen(3))
+	g.Expect(samples[0].Status).Should(Equal(`{"readyReplicas":1}`))
+	g.Expect(samples[1].Status).Should(Equal(`{"readyReplicas":2}`))
+	g.Expect(samples[2].Status).Should(Equal(`{"readyReplicas":3}`))
+	g.Expect(samples[2].Count).Should(Equal(2))
+
+	g.Expect(h.String()).Should(ContainSubstring(`status: {"readyReplicas":3} (observed 2 times until`))
+
+	h.WithSize(1)
+	g.Expect(h.Samples()).Should(HaveLen(1))
+	g.Expect(h.String()).Should(HavePrefix("(2 older samples discarded)"))
+
+	h.Reset()
+	g.Expect(h.Samples()).Should(BeEmpty())
+}
+
+func TestRecordErrors(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	failure := errors.New("boom")
+
+	h := k8s.Record(func(_ context.Context) (string, error) {
+		return "", failure
+	})
+
+	_, err := h.Poll(t.Context())
+	g.Expect(err).Should(MatchError(failure))
+
+	g.Expect(h.Samples()).Should(ConsistOf(HaveField("Err", MatchError(failure))))
+	g.Expect(h.String()).Should(ContainSubstring("error: boom"))
+}
@@ -0,0 +1,335 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+const (
+	defaultRegistry   = "docker.io"
+	defaultRepository = "library"
+	defaultTag        = "latest"
+)
+
+//nolint:gochecknoglobals
+var (
+	imageRepositoryPattern = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*$`)
+	imageTagPattern        = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+	imageDigestPattern     = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-zA-Z0-9=_-]+$`)
+)
+
+// ImageReference is an OCI image reference, normalized as the container
+// runtimes do: images without a registry are pulled from docker.io, single
+// component official images live in the library repository, and images
+// without a tag nor a digest use the latest tag.
+type ImageReference struct {
+	// Registry is the host, and optional port, i.e. docker.io or
+	// localhost:5000.
+	Registry string
+	// Repository is the path of the image within the registry, i.e.
+	// library/nginx.
+	Repository string
+	// Tag is the tag of the image, if any.
+	Tag string
+	// Digest is the digest of the image, if any, i.e. sha256:0123....
+	Digest string
+}
+
+func (r ImageReference) String() string {
+	s := r.Registry + "/" + r.Repository
+
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+
+	return s
+}
+
+// ParseImageReference parses and normalizes the given image reference, i.e.
+// nginx:1.25 is parsed as docker.io/library/nginx:1.25.
+//
+//nolint:cyclop
+func ParseImageReference(ref string) (ImageReference, error) {
+	result := ImageReference{}
+	rest := ref
+
+	if i := strings.Index(rest, "@"); i >= 0 {
+		result.Digest = rest[i+1:]
+		rest = rest[:i]
+
+		if !imageDigestPattern.MatchString(result.Digest) {
+			return ImageReference{}, fmt.Errorf("invalid image reference %q: invalid digest", ref)
+		}
+	}
+
+	// a colon after the last slash separates the tag, a colon before it the
+	// port of the registry
+	if i := strings.LastIndex(rest, ":"); i >= 0 && i > strings.LastIndex(rest, "/") {
+		result.Tag = rest[i+1:]
+		rest = rest[:i]
+
+		if !imageTagPattern.MatchString(result.Tag) {
+			return ImageReference{}, fmt.Errorf("invalid image reference %q: invalid tag", ref)
+		}
+	}
+
+	result.Registry = defaultRegistry
+	result.Repository = rest
+
+	if i := strings.Index(rest, "/"); i >= 0 {
+		host := rest[:i]
+
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			result.Registry = host
+			result.Repository = rest[i+1:]
+		}
+	}
+
+	if result.Registry == defaultRegistry && !strings.Contains(result.Repository, "/") {
+		result.Repository = defaultRepository + "/" + result.Repository
+	}
+
+	if !imageRepositoryPattern.MatchString(result.Repository) {
+		return ImageReference{}, fmt.Errorf("invalid image reference %q: invalid repository", ref)
+	}
+
+	if result.Tag == "" && result.Digest == "" {
+		result.Tag = defaultTag
+	}
+
+	return result, nil
+}
+
+// HaveImage succeeds if any container, or init container, of the pod spec of
+// the actual object uses an image matching the expected one. The actual can
+// also be an image reference itself. The expected value can be either an
+// image reference, compared with the actual ones once both are normalized,
+// or a matcher for an ImageReference, i.e.:
+//
+//	Expect(deploy).Should(k8s.HaveImage("nginx:1.25"))
+//	Expect(deploy).Should(k8s.HaveImage(k8s.ImageTag(HavePrefix("1."))))
+//	Expect(deploy).Should(k8s.HaveImage(And(
+//	    k8s.ImageRepository(Equal("org/app")),
+//	    k8s.ImageDigest(Not(BeEmpty())),
+//	)))
+func HaveImage(expected any) types.GomegaMatcher {
+	return &imageMatcher{
+		expected: expected,
+	}
+}
+
+var _ types.GomegaMatcher = &imageMatcher{}
+
+type imageMatcher struct {
+	expected any
+	images   []string
+}
+
+func (matcher *imageMatcher) Match(actual interface{}) (bool, error) {
+	images, err := imagesOf(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.images = images
+
+	var inner types.GomegaMatcher
+
+	switch e := matcher.expected.(type) {
+	case types.GomegaMatcher:
+		inner = e
+	case string:
+		ref, err := ParseImageReference(e)
+		if err != nil {
+			return false, err
+		}
+
+		inner = &imageEqualMatcher{expected: ref}
+	default:
+		return false, fmt.Errorf("an image reference or a matcher is expected, got %T", matcher.expected)
+	}
+
+	for _, image := range images {
+		ref, err := ParseImageReference(image)
+		if err != nil {
+			return false, err
+		}
+
+		ok, err := inner.Match(ref)
+		if err != nil {
+			return false, err
+		}
+
+		if ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (matcher *imageMatcher) FailureMessage(_ interface{}) string {
+	return format.Message(matcher.images, "to contain an image matching", matcher.expected)
+}
+
+func (matcher *imageMatcher) NegatedFailureMessage(_ interface{}) string {
+	return format.Message(matcher.images, "not to contain an image matching", matcher.expected)
+}
+
+// ImageRegistry succeeds if the registry of the actual image reference, an
+// ImageReference or a string, matches the given value, which can be either a
+// string or a matcher.
+func ImageRegistry(expected any) types.GomegaMatcher {
+	return &imageFieldMatcher{field: "registry", expected: toMatcher(expected)}
+}
+
+// ImageRepository succeeds if the repository of the actual image reference,
+// i.e. library/nginx, matches the given value, which can be either a string
+// or a matcher.
+func ImageRepository(expected any) types.GomegaMatcher {
+	return &imageFieldMatcher{field: "repository", expected: toMatcher(expected)}
+}
+
+// ImageTag succeeds if the tag of the actual image reference matches the
+// given value, which can be either a string or a matcher.
+func ImageTag(expected any) types.GomegaMatcher {
+	return &imageFieldMatcher{field: "tag", expected: toMatcher(expected)}
+}
+
+// ImageDigest succeeds if the digest of the actual image reference matches
+// the given value, which can be either a string or a matcher.
+func ImageDigest(expected any) types.GomegaMatcher {
+	return &imageFieldMatcher{field: "digest", expected: toMatcher(expected)}
+}
+
+var _ types.GomegaMatcher = &imageFieldMatcher{}
+
+type imageFieldMatcher struct {
+	field    string
+	expected types.GomegaMatcher
+
+	ref   ImageReference
+	value string
+}
+
+func (matcher *imageFieldMatcher) Match(actual interface{}) (bool, error) {
+	ref, err := toImageReference(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.ref = ref
+
+	switch matcher.field {
+	case "registry":
+		matcher.value = ref.Registry
+	case "repository":
+		matcher.value = ref.Repository
+	case "tag":
+		matcher.value = ref.Tag
+	case "digest":
+		matcher.value = ref.Digest
+	}
+
+	return matcher.expected.Match(matcher.value)
+}
+
+func (matcher *imageFieldMatcher) FailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected %s of image %s to match:\n%s", matcher.field, matcher.ref, matcher.expected.FailureMessage(matcher.value))
+}
+
+func (matcher *imageFieldMatcher) NegatedFailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected %s of image %s not to match:\n%s", matcher.field, matcher.ref, matcher.expected.NegatedFailureMessage(matcher.value))
+}
+
+var _ types.GomegaMatcher = &imageEqualMatcher{}
+
+type imageEqualMatcher struct {
+	expected ImageReference
+}
+
+func (matcher *imageEqualMatcher) Match(actual interface{}) (bool, error) {
+	ref, err := toImageReference(actual)
+	if err != nil {
+		return false, err
+	}
+
+	return ref == matcher.expected, nil
+}
+
+func (matcher *imageEqualMatcher) FailureMessage(actual interface{}) string {
+	return format.Message(actual, "to be the same image as", matcher.expected)
+}
+
+func (matcher *imageEqualMatcher) NegatedFailureMessage(actual interface{}) string {
+	return format.Message(actual, "not to be the same image as", matcher.expected)
+}
+
+func toImageReference(in any) (ImageReference, error) {
+	switch v := in.(type) {
+	case ImageReference:
+		return v, nil
+	case *ImageReference:
+		if v == nil {
+			return ImageReference{}, errors.New("an image reference is expected, got nil")
+		}
+
+		return *v, nil
+	case string:
+		return ParseImageReference(v)
+	default:
+		return ImageReference{}, fmt.Errorf("an image reference is expected, got %T", in)
+	}
+}
+
+// imagesOf returns the images of the containers and init containers of the
+// pod spec of the given object, or the given image reference itself.
+func imagesOf(in any) ([]string, error) {
+	switch v := in.(type) {
+	case ImageReference:
+		return []string{v.String()}, nil
+	case string:
+		// JSON documents are Kubernetes objects, anything else an image
+		if !strings.HasPrefix(strings.TrimSpace(v), "{") {
+			return []string{v}, nil
+		}
+	}
+
+	obj, err := toObject(in)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := podSpec(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var images []string
+
+	for _, field := range []string{"containers", "initContainers"} {
+		items, _ := spec[field].([]any)
+
+		for i := range items {
+			c, ok := items[i].(map[string]any)
+			if !ok {
+				continue
+			}
+
+			if image, ok := c["image"].(string); ok {
+				images = append(images, image)
+			}
+		}
+	}
+
+	return images, nil
+}
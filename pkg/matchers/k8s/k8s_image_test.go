@@ -0,0 +1,112 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestParseImageReference(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	items := map[string]k8s.ImageReference{
+		"nginx":                            {Registry: "docker.io", Repository: "library/nginx", Tag: "latest"},
+		"nginx:1.25":                       {Registry: "docker.io", Repository: "library/nginx", Tag: "1.25"},
+		"docker.io/nginx:1.25":             {Registry: "docker.io", Repository: "library/nginx", Tag: "1.25"},
+		"org/app:v1":                       {Registry: "docker.io", Repository: "org/app", Tag: "v1"},
+		"quay.io/org/app:v1":               {Registry: "quay.io", Repository: "org/app", Tag: "v1"},
+		"localhost:5000/app":               {Registry: "localhost:5000", Repository: "app", Tag: "latest"},
+		"localhost/app:v1":                 {Registry: "localhost", Repository: "app", Tag: "v1"},
+		"quay.io/org/app@" + testDigest:    {Registry: "quay.io", Repository: "org/app", Digest: testDigest},
+		"quay.io/org/app:v1@" + testDigest: {Registry: "quay.io", Repository: "org/app", Tag: "v1", Digest: testDigest},
+	}
+
+	for ref, expected := range items {
+		actual, err := k8s.ParseImageReference(ref)
+		g.Expect(err).ShouldNot(HaveOccurred(), ref)
+		g.Expect(actual).Should(Equal(expected), ref)
+	}
+
+	for _, ref := range []string{"", "Nginx", "nginx:", "nginx:-1", "nginx@sha256", "quay.io/org/app@:x"} {
+		_, err := k8s.ParseImageReference(ref)
+		g.Expect(err).Should(MatchError(ContainSubstring("invalid image reference")), ref)
+	}
+
+	g.Expect(k8s.ImageReference{Registry: "quay.io", Repository: "org/app", Tag: "v1", Digest: testDigest}.String()).
+		Should(Equal("quay.io/org/app:v1@" + testDigest))
+}
+
+func TestHaveImage(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	deploy := appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{Name: "init", Image: "busybox"}},
+					Containers: []corev1.Container{
+						{Name: "app", Image: "quay.io/org/app:v1.2.3@" + testDigest},
+						{Name: "proxy", Image: "docker.io/library/nginx:1.25"},
+					},
+				},
+			},
+		},
+	}
+
+	g.Expect(&deploy).Should(k8s.HaveImage("nginx:1.25"))
+	g.Expect(&deploy).Should(k8s.HaveImage("busybox:latest"))
+	g.Expect(&deploy).ShouldNot(k8s.HaveImage("nginx"))
+	g.Expect(&deploy).ShouldNot(k8s.HaveImage("quay.io/org/app:v1.2.3"))
+
+	g.Expect(&deploy).Should(k8s.HaveImage(k8s.ImageTag(HavePrefix("v1."))))
+	g.Expect(&deploy).Should(k8s.HaveImage(And(
+		k8s.ImageRegistry("quay.io"),
+		k8s.ImageRepository("org/app"),
+		k8s.ImageDigest(testDigest),
+	)))
+	g.Expect(&deploy).ShouldNot(k8s.HaveImage(k8s.ImageRegistry("gcr.io")))
+
+	g.Expect("nginx:1.25").Should(k8s.HaveImage("docker.io/library/nginx:1.25"))
+	g.Expect("nginx:1.25").Should(k8s.ImageTag("1.25"))
+	g.Expect("nginx:1.25").Should(k8s.ImageRepository(Equal("library/nginx")))
+
+	_, err := k8s.HaveImage(42).Match(&deploy)
+	g.Expect(err).Should(MatchError("an image reference or a matcher is expected, got int"))
+
+	_, err = k8s.ImageTag("v1").Match(42)
+	g.Expect(err).Should(MatchError("an image reference is expected, got int"))
+}
+
+func TestHaveImageFailureMessage(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app", Image: "quay.io/org/app:v1"}},
+		},
+	}
+
+	m := k8s.HaveImage("quay.io/org/app:v2")
+	g.Expect(m.Match(&pod)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(And(
+		ContainSubstring("quay.io/org/app:v1"),
+		ContainSubstring("to contain an image matching"),
+		ContainSubstring("quay.io/org/app:v2"),
+	))
+
+	m = k8s.ImageTag("v2")
+	g.Expect(m.Match("quay.io/org/app:v1")).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(HavePrefix("Expected tag of image quay.io/org/app:v1 to match:\n"))
+}
@@ -0,0 +1,50 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AsUser returns a copy of the Matcher whose API calls impersonate the given
+// user and groups, i.e. a ServiceAccount as
+// "system:serviceaccount:<namespace>:<name>", so that least-privilege
+// behavior can be verified through the same assertions:
+//
+//	sa, err := k.AsUser("system:serviceaccount:ns:app")
+//	Expect(err).ShouldNot(HaveOccurred())
+//	Expect(sa.Client().List(ctx, &corev1.SecretList{})).Should(MatchError(k8serrors.IsForbidden, "IsForbidden"))
+//
+// It requires the Matcher to have been created by one of the factory
+// constructors, as a REST config is needed to set up impersonation. Reads
+// performed through the returned Matcher always hit the API server, since
+// an informer cache set up by WithCache is bound to the original identity.
+func (m *Matcher) AsUser(user string, groups ...string) (*Matcher, error) {
+	if m.config == nil {
+		return nil, errors.New("impersonation requires a Matcher created from a REST config")
+	}
+
+	if user == "" {
+		return nil, errors.New("a user name is required")
+	}
+
+	cfg := rest.CopyConfig(m.config)
+	cfg.Impersonate = rest.ImpersonationConfig{
+		UserName: user,
+		Groups:   groups,
+	}
+
+	cli, err := client.New(cfg, client.Options{Scheme: m.scheme})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create client impersonating %s: %w", user, err)
+	}
+
+	c := *m
+	c.config = cfg
+	c.client = m.instrument(cli)
+	c.live = nil
+
+	return &c, nil
+}
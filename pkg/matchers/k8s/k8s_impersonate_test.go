@@ -0,0 +1,90 @@
+package k8s_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestAsUser(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	var mu sync.Mutex
+
+	impersonated := map[string][]string{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api":
+			_, _ = w.Write([]byte(`{"kind":"APIVersions","versions":["v1"]}`))
+		case "/apis":
+			_, _ = w.Write([]byte(`{"kind":"APIGroupList","apiVersion":"v1","groups":[]}`))
+		case "/api/v1":
+			_, _ = w.Write([]byte(`{"kind":"APIResourceList","groupVersion":"v1","resources":[` +
+				`{"name":"configmaps","namespaced":true,"kind":"ConfigMap","verbs":["get"]}]}`))
+		case "/api/v1/namespaces/ns/configmaps/cm":
+			mu.Lock()
+			impersonated[r.Header.Get("Impersonate-User")] = r.Header.Values("Impersonate-Group")
+			mu.Unlock()
+
+			_, _ = w.Write([]byte(`{"kind":"ConfigMap","apiVersion":"v1","metadata":{"name":"cm","namespace":"ns"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := &rest.Config{Host: srv.URL}
+
+	k, err := k8s.NewFromConfig(cfg)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	sa, err := k.AsUser("system:serviceaccount:ns:app", "system:serviceaccounts")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(sa.Config().Impersonate.UserName).Should(Equal("system:serviceaccount:ns:app"))
+	g.Expect(k.Config().Impersonate.UserName).Should(BeEmpty())
+
+	cm := corev1.ConfigMap{}
+
+	g.Expect(sa.Client().Get(t.Context(), client.ObjectKey{Namespace: "ns", Name: "cm"}, &cm)).Should(Succeed())
+	g.Expect(k.Client().Get(t.Context(), client.ObjectKey{Namespace: "ns", Name: "cm"}, &cm)).Should(Succeed())
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	g.Expect(impersonated).Should(HaveKeyWithValue("system:serviceaccount:ns:app", ConsistOf("system:serviceaccounts")))
+	g.Expect(impersonated).Should(HaveKey(""))
+	g.Expect(sa.Metrics().Total()).Should(Equal(2))
+
+	_, err = k.AsUser("")
+	g.Expect(err).Should(HaveOccurred())
+}
+
+func TestAsUserWithoutConfig(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	k := k8s.New(fake.NewClientBuilder().WithScheme(scheme).Build(), scheme)
+
+	_, err := k.AsUser("alice")
+	g.Expect(err).Should(MatchError(ContainSubstring("requires a Matcher created from a REST config")))
+}
@@ -0,0 +1,229 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// HaveItems succeeds if the actual list has a number of items matching the
+// expected one, which can be either an int or a matcher, i.e.
+// BeNumerically(">=", 3). The actual can be a typed list, an
+// UnstructuredList or any value holding an items array.
+func HaveItems(expected any) types.GomegaMatcher {
+	return &itemsMatcher{
+		expected: toMatcher(expected),
+	}
+}
+
+var _ types.GomegaMatcher = &itemsMatcher{}
+
+type itemsMatcher struct {
+	expected types.GomegaMatcher
+	count    int
+}
+
+func (matcher *itemsMatcher) Match(actual interface{}) (bool, error) {
+	items, err := toItems(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.count = len(items)
+
+	return matcher.expected.Match(matcher.count)
+}
+
+func (matcher *itemsMatcher) FailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected number of items to match:\n%s", matcher.expected.FailureMessage(matcher.count))
+}
+
+func (matcher *itemsMatcher) NegatedFailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected number of items not to match:\n%s", matcher.expected.NegatedFailureMessage(matcher.count))
+}
+
+// AllItems succeeds if every item of the actual list matches the given
+// matcher. Items are passed to the matcher in their unstructured form, so
+// that jq.Match can be used, and the first item not matching is reported by
+// namespace and name.
+func AllItems(matcher types.GomegaMatcher) types.GomegaMatcher {
+	return &itemMatcher{
+		matcher: matcher,
+		all:     true,
+	}
+}
+
+// AnyItem succeeds if at least one item of the actual list matches the given
+// matcher, see AllItems.
+func AnyItem(matcher types.GomegaMatcher) types.GomegaMatcher {
+	return &itemMatcher{
+		matcher: matcher,
+	}
+}
+
+var _ types.GomegaMatcher = &itemMatcher{}
+
+type itemMatcher struct {
+	matcher types.GomegaMatcher
+	all     bool
+
+	count   int
+	item    map[string]any
+	failure string
+}
+
+func (matcher *itemMatcher) Match(actual interface{}) (bool, error) {
+	items, err := toItems(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.count = len(items)
+	matcher.item = nil
+	matcher.failure = ""
+
+	for _, item := range items {
+		ok, err := matcher.matcher.Match(item)
+		if err != nil {
+			return false, fmt.Errorf("item %s: %w", itemName(item), err)
+		}
+
+		switch {
+		case matcher.all && !ok:
+			matcher.item = item
+			matcher.failure = matcher.matcher.FailureMessage(item)
+
+			return false, nil
+		case !matcher.all && ok:
+			matcher.item = item
+			matcher.failure = matcher.matcher.NegatedFailureMessage(item)
+
+			return true, nil
+		}
+	}
+
+	return matcher.all, nil
+}
+
+func (matcher *itemMatcher) FailureMessage(_ interface{}) string {
+	if matcher.all {
+		return fmt.Sprintf("Expected all items to match, but item %s does not:\n%s", itemName(matcher.item), matcher.failure)
+	}
+
+	return fmt.Sprintf("Expected any item to match, but none of the %d items does", matcher.count)
+}
+
+func (matcher *itemMatcher) NegatedFailureMessage(_ interface{}) string {
+	if matcher.all {
+		return fmt.Sprintf("Expected not all items to match, but all the %d items do", matcher.count)
+	}
+
+	return fmt.Sprintf("Expected no item to match, but item %s does:\n%s", itemName(matcher.item), matcher.failure)
+}
+
+// itemName identifies an item of a list by namespace and name.
+func itemName(item map[string]any) string {
+	name, _, _ := unstructured.NestedString(item, "metadata", "name")
+	namespace, _, _ := unstructured.NestedString(item, "metadata", "namespace")
+
+	if namespace == "" {
+		return name
+	}
+
+	return namespace + "/" + name
+}
+
+// Count returns a pollable function counting the objects matching the given
+// options, following continue tokens so that the count is never truncated.
+func (m *Matcher) Count(list client.ObjectList, opts ...client.ListOption) func(ctx context.Context) (int, error) {
+	return func(ctx context.Context) (int, error) {
+		if err := listAll(ctx, m.client, list, opts); err != nil {
+			return 0, fmt.Errorf("unable to list %T: %w", list, err)
+		}
+
+		return meta.LenList(list), nil
+	}
+}
+
+// Count returns a pollable function counting the resources matching the
+// given options, following continue tokens so that the count is never
+// truncated.
+func (u *UnstructuredMatcher) Count(resource string, opts ...client.ListOption) func(ctx context.Context) (int, error) {
+	return func(ctx context.Context) (int, error) {
+		list, err := u.ListAll(resource, opts...)(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		return len(list.Items), nil
+	}
+}
+
+// toItems returns the unstructured representation of the items of the given
+// list.
+func toItems(in any) ([]map[string]any, error) {
+	switch v := in.(type) {
+	case *unstructured.UnstructuredList:
+		return unstructuredItems(v.Items), nil
+	case unstructured.UnstructuredList:
+		return unstructuredItems(v.Items), nil
+	case []unstructured.Unstructured:
+		return unstructuredItems(v), nil
+	case client.ObjectList:
+		objects, err := meta.ExtractList(v)
+		if err != nil {
+			return nil, fmt.Errorf("unable to extract items: %w", err)
+		}
+
+		items := make([]map[string]any, 0, len(objects))
+
+		for _, o := range objects {
+			u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+			if err != nil {
+				return nil, &ConversionError{From: fmt.Sprintf("%T", o), To: "unstructured", Err: err}
+			}
+
+			items = append(items, u)
+		}
+
+		return items, nil
+	}
+
+	obj, err := toObject(in)
+	if err != nil {
+		return nil, err
+	}
+
+	values, ok, err := unstructured.NestedSlice(obj, "items")
+	if err != nil || !ok {
+		return nil, fmt.Errorf("a list is expected, got:\n%s", format.Object(in, 1))
+	}
+
+	items := make([]map[string]any, 0, len(values))
+
+	for i := range values {
+		item, ok := values[i].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("item %d is not an object:\n%s", i, format.Object(values[i], 1))
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func unstructuredItems(in []unstructured.Unstructured) []map[string]any {
+	items := make([]map[string]any, 0, len(in))
+	for i := range in {
+		items = append(items, in[i].Object)
+	}
+
+	return items
+}
@@ -0,0 +1,96 @@
+package k8s_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestHaveItems(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	list := corev1.PodList{Items: []corev1.Pod{{}, {}, {}}}
+
+	g.Expect(&list).Should(k8s.HaveItems(3))
+	g.Expect(&list).Should(k8s.HaveItems(BeNumerically(">", 2)))
+	g.Expect(&list).ShouldNot(k8s.HaveItems(2))
+	g.Expect(`{"items":[{},{}]}`).Should(k8s.HaveItems(2))
+
+	m := k8s.HaveItems(2)
+	g.Expect(m.Match(&list)).Should(BeFalse())
+	g.Expect(m.FailureMessage(&list)).Should(ContainSubstring("Expected number of items to match"))
+
+	_, err := k8s.HaveItems(2).Match(`{"metadata":{}}`)
+	g.Expect(err).Should(MatchError(ContainSubstring("a list is expected")))
+}
+
+func TestCount(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	builder := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithInterceptorFuncs(paginate())
+
+	for i := range 5 {
+		builder = builder.WithObjects(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("pod-%d", i), Namespace: "ns"},
+		})
+	}
+
+	k := k8s.New(builder.Build(), scheme).WithContext(t.Context())
+
+	g.Eventually(k.Unstructured().Count("v1/Pod", k8s.InNamespace("ns"), k8s.Limit(2))).
+		WithContext(t.Context()).
+		Should(Equal(5))
+
+	g.Expect(k.Count(&corev1.PodList{}, k8s.InNamespace("ns"), k8s.Limit(2))(t.Context())).Should(Equal(5))
+	g.Expect(k.Count(&corev1.PodList{}, k8s.InNamespace("other"))(t.Context())).Should(Equal(0))
+}
+
+func TestAllItems(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	list := corev1.PodList{Items: []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ns"}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "ns"}, Status: corev1.PodStatus{Phase: corev1.PodPending}},
+	}}
+
+	g.Expect(&list).Should(k8s.AllItems(jq.Match(`.metadata.namespace == "ns"`)))
+	g.Expect(&list).ShouldNot(k8s.AllItems(jq.Match(`.status.phase == "Running"`)))
+	g.Expect(&list).Should(k8s.AnyItem(jq.Match(`.status.phase == "Pending"`)))
+	g.Expect(&list).ShouldNot(k8s.AnyItem(jq.Match(`.status.phase == "Failed"`)))
+	g.Expect(&list).Should(k8s.AnyItem(k8s.HavePhase("Running")))
+
+	g.Expect(&corev1.PodList{}).Should(k8s.AllItems(jq.Match(`false`)))
+	g.Expect(&corev1.PodList{}).ShouldNot(k8s.AnyItem(jq.Match(`true`)))
+
+	m := k8s.AllItems(jq.Match(`.status.phase == "Running"`))
+	g.Expect(m.Match(&list)).Should(BeFalse())
+	g.Expect(m.FailureMessage(&list)).Should(ContainSubstring("Expected all items to match, but item ns/b does not"))
+
+	m = k8s.AnyItem(jq.Match(`.status.phase == "Running"`))
+	g.Expect(m.Match(&list)).Should(BeTrue())
+	g.Expect(m.NegatedFailureMessage(&list)).Should(ContainSubstring("Expected no item to match, but item ns/a does"))
+
+	m = k8s.AnyItem(jq.Match(`.status.phase == "Failed"`))
+	g.Expect(m.Match(&list)).Should(BeFalse())
+	g.Expect(m.FailureMessage(&list)).Should(Equal("Expected any item to match, but none of the 2 items does"))
+}
@@ -0,0 +1,97 @@
+package k8s
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// The functions below mimic the ones provided by controller-runtime's komega
+// package, so that suites migrating from it can keep their call sites, i.e.
+// Eventually(k.Object(cm)), while getting unstructured results that can be
+// matched with jq.Match. As in komega, they use the context of the Matcher
+// and take no arguments.
+
+// Object returns a function that fetches the given object, identified by its
+// name and namespace, updates it in place and returns its unstructured
+// representation.
+func (m *Matcher) Object(obj client.Object) func() (*unstructured.Unstructured, error) {
+	key := client.ObjectKeyFromObject(obj)
+
+	return func() (*unstructured.Unstructured, error) {
+		if err := m.client.Get(m.ctx, key, obj); err != nil {
+			return nil, err
+		}
+
+		return m.toUnstructured(obj)
+	}
+}
+
+// Objects returns a function that lists the objects matching the given
+// options into list and returns its unstructured representation.
+func (m *Matcher) Objects(list client.ObjectList, opts ...client.ListOption) func() (*unstructured.UnstructuredList, error) {
+	return func() (*unstructured.UnstructuredList, error) {
+		if err := m.client.List(m.ctx, list, opts...); err != nil {
+			return nil, err
+		}
+
+		u, err := m.toUnstructured(list)
+		if err != nil {
+			return nil, err
+		}
+
+		items, _ := u.Object["items"].([]any)
+		delete(u.Object, "items")
+
+		out := unstructured.UnstructuredList{Object: u.Object}
+
+		for i := range items {
+			if item, ok := items[i].(map[string]any); ok {
+				out.Items = append(out.Items, unstructured.Unstructured{Object: item})
+			}
+		}
+
+		return &out, nil
+	}
+}
+
+// Update returns a function that fetches the given object, applies fn to it
+// and updates it. It can be used with Eventually to retry on conflicts.
+func (m *Matcher) Update(obj client.Object, fn func(), opts ...client.UpdateOption) func() error {
+	key := client.ObjectKeyFromObject(obj)
+
+	return func() error {
+		if err := m.client.Get(m.ctx, key, obj); err != nil {
+			return err
+		}
+
+		fn()
+
+		return m.client.Update(m.ctx, obj, opts...)
+	}
+}
+
+// UpdateStatus is like Update but updates the status subresource.
+func (m *Matcher) UpdateStatus(obj client.Object, fn func(), opts ...client.SubResourceUpdateOption) func() error {
+	key := client.ObjectKeyFromObject(obj)
+
+	return func() error {
+		if err := m.client.Get(m.ctx, key, obj); err != nil {
+			return err
+		}
+
+		fn()
+
+		return m.client.Status().Update(m.ctx, obj, opts...)
+	}
+}
+
+// toUnstructured converts the given typed object to its unstructured
+// representation, filling in the apiVersion and kind that typed objects
+// returned by the client usually lack.
+func (m *Matcher) toUnstructured(obj runtime.Object) (*unstructured.Unstructured, error) {
+	return toUnstructured(obj, func(o runtime.Object) (schema.GroupVersionKind, error) {
+		return gvkFor(m.client, o)
+	})
+}
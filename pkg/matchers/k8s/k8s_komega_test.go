@@ -0,0 +1,65 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestKomega(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptrTo[int32](1)},
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			deploy,
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm-1", Namespace: "ns"}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm-2", Namespace: "ns"}},
+		).
+		WithStatusSubresource(deploy).
+		Build()
+
+	k := k8s.New(cli, scheme).WithContext(t.Context())
+
+	obj := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"}}
+
+	g.Eventually(k.Object(obj)).Should(And(
+		jq.Match(`.kind == "Deployment" and .apiVersion == "apps/v1"`),
+		jq.Match(`.spec.replicas == 1`),
+	))
+	g.Expect(obj.Spec.Replicas).Should(HaveValue(Equal(int32(1))))
+
+	g.Eventually(k.Objects(&corev1.ConfigMapList{}, k8s.InNamespace("ns"))).Should(And(
+		k8s.HaveItems(2),
+		k8s.AllItems(jq.Match(`.kind == "ConfigMap"`)),
+	))
+
+	g.Eventually(k.Update(obj, func() {
+		obj.Spec.Replicas = ptrTo[int32](3)
+	})).Should(Succeed())
+
+	g.Eventually(k.UpdateStatus(obj, func() {
+		obj.Status.ReadyReplicas = 3
+	})).Should(Succeed())
+
+	g.Eventually(k.Object(obj)).Should(jq.Match(`.spec.replicas == 3 and .status.readyReplicas == 3`))
+}
@@ -0,0 +1,142 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const defaultKubectl = "kubectl"
+
+// FromKubectl normalizes the JSON output of kubectl, i.e. of
+// "kubectl get -o json", to an unstructured list, so that the output of a
+// single object, of a List or of a stream of objects can be asserted the
+// same way with jq.Match, HaveItems, AllItems or AnyItem:
+//
+//	out, err := exec.Command("kubectl", "get", "deployment", "app", "-o", "json").Output()
+//	Expect(err).ShouldNot(HaveOccurred())
+//	Expect(k8s.FromKubectl(out)).Should(k8s.HaveItems(1))
+//
+// The items of List kinds are flattened, while items lacking an apiVersion and
+// a kind, as returned by typed lists, are stamped with the ones of the list.
+// An empty output results in an empty list.
+func FromKubectl(output []byte) (*unstructured.UnstructuredList, error) {
+	result := unstructured.UnstructuredList{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "List",
+		},
+		Items: []unstructured.Unstructured{},
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(output))
+
+	for {
+		obj := make(map[string]any)
+
+		err := dec.Decode(&obj)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, &ConversionError{From: "kubectl output", To: "unstructured", Err: err}
+		}
+
+		items, err := kubectlItems(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Items = append(result.Items, items...)
+	}
+
+	return &result, nil
+}
+
+// kubectlItems returns the items of the given object when it is a list, or
+// the object itself otherwise.
+func kubectlItems(obj map[string]any) ([]unstructured.Unstructured, error) {
+	u := unstructured.Unstructured{Object: obj}
+
+	if !u.IsList() {
+		return []unstructured.Unstructured{u}, nil
+	}
+
+	values, _, _ := unstructured.NestedSlice(obj, "items")
+	items := make([]unstructured.Unstructured, 0, len(values))
+
+	for i := range values {
+		item, ok := values[i].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unable to convert item %d of %s: unexpected type %T", i, u.GetKind(), values[i])
+		}
+
+		if item["kind"] == nil && u.GetKind() != "List" {
+			item["apiVersion"] = u.GetAPIVersion()
+			item["kind"] = strings.TrimSuffix(u.GetKind(), "List")
+		}
+
+		items = append(items, unstructured.Unstructured{Object: item})
+	}
+
+	return items, nil
+}
+
+// Kubectl returns a function running kubectl with the given arguments and
+// normalizing its output with FromKubectl, for e2e suites that intentionally
+// exercise the CLI path rather than the API:
+//
+//	Eventually(k8s.Kubectl("get", "pods", "-n", ns, "-l", "app=test")).
+//	    WithContext(ctx).
+//	    Should(k8s.AllItems(jq.Match(`.status.phase == "Running"`)))
+//
+// The output format defaults to JSON when not set by the given arguments, any
+// other format fails the conversion.
+// The kubectl binary is looked up in the PATH, unless set by the KUBECTL
+// environment variable, and honors KUBECONFIG as usual.
+func Kubectl(args ...string) func(ctx context.Context) (*unstructured.UnstructuredList, error) {
+	return func(ctx context.Context) (*unstructured.UnstructuredList, error) {
+		kubectl := os.Getenv("KUBECTL")
+		if kubectl == "" {
+			kubectl = defaultKubectl
+		}
+
+		cmdArgs := slices.Clone(args)
+		if !hasOutputFlag(args) {
+			cmdArgs = append(cmdArgs, "-o", "json")
+		}
+
+		var stdout, stderr bytes.Buffer
+
+		cmd := exec.CommandContext(ctx, kubectl, cmdArgs...)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+
+		if err := cmd.Run(); err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				err = fmt.Errorf("%w: %s", err, msg)
+			}
+
+			return nil, fmt.Errorf("unable to run kubectl %s: %w", strings.Join(args, " "), err)
+		}
+
+		return FromKubectl(stdout.Bytes())
+	}
+}
+
+func hasOutputFlag(args []string) bool {
+	return slices.ContainsFunc(args, func(arg string) bool {
+		return arg == "--output" || strings.HasPrefix(arg, "--output=") ||
+			(strings.HasPrefix(arg, "-o") && !strings.HasPrefix(arg, "--"))
+	})
+}
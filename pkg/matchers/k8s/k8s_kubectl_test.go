@@ -0,0 +1,99 @@
+package k8s_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestFromKubectl(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	single := `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "a", "namespace": "ns"}, "data": {"k": "v"}}`
+
+	u, err := k8s.FromKubectl([]byte(single))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(u.GetKind()).Should(Equal("List"))
+	g.Expect(u).Should(k8s.HaveItems(1))
+	g.Expect(u).Should(jq.Match(`.items[0].data.k == "v"`))
+
+	list := `{
+		"apiVersion": "v1",
+		"kind": "List",
+		"items": [
+			{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "a"}},
+			{"apiVersion": "v1", "kind": "Secret", "metadata": {"name": "b"}}
+		],
+		"metadata": {"resourceVersion": ""}
+	}`
+
+	u, err = k8s.FromKubectl([]byte(list))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(u).Should(jq.Match(`[.items[].kind] == ["ConfigMap", "Secret"]`))
+
+	// typed lists, i.e. from "kubectl get --raw", lack the kind of items
+	typed := `{"apiVersion": "apps/v1", "kind": "DeploymentList", "items": [{"metadata": {"name": "app"}}]}`
+
+	u, err = k8s.FromKubectl([]byte(typed))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(u).Should(k8s.AllItems(jq.Match(`.apiVersion == "apps/v1" and .kind == "Deployment"`)))
+
+	// a stream of objects, i.e. from "kubectl get --watch"
+	u, err = k8s.FromKubectl([]byte(single + "\n" + list))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(u).Should(k8s.HaveItems(3))
+
+	u, err = k8s.FromKubectl(nil)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(u.Items).Should(BeEmpty())
+
+	_, err = k8s.FromKubectl([]byte(`NAME   DATA   AGE`))
+	g.Expect(err).Should(MatchError(ContainSubstring("unable to convert kubectl output to unstructured")))
+
+	_, err = k8s.FromKubectl([]byte(`{"kind": "List", "items": ["a"]}`))
+	g.Expect(err).Should(MatchError("unable to convert item 0 of List: unexpected type string"))
+}
+
+//nolint:paralleltest
+func TestKubectl(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("a shell script is used in place of kubectl")
+	}
+
+	g := NewWithT(t)
+
+	kubectl := filepath.Join(t.TempDir(), "kubectl")
+
+	script := `#!/bin/sh
+if [ "$1" = "fail" ]; then
+  echo 'error: the server does not have a resource type "fail"' >&2
+  exit 1
+fi
+echo "{\"apiVersion\": \"v1\", \"kind\": \"ConfigMap\", \"metadata\": {\"name\": \"$3\", \"annotations\": {\"args\": \"$*\"}}}"
+`
+
+	g.Expect(os.WriteFile(kubectl, []byte(script), 0o700)).Should(Succeed())
+
+	t.Setenv("KUBECTL", kubectl)
+
+	g.Eventually(k8s.Kubectl("get", "configmap", "app")).
+		WithContext(t.Context()).
+		Should(k8s.AnyItem(jq.Match(`.metadata.name == "app" and .metadata.annotations.args == "get configmap app -o json"`)))
+
+	g.Expect(k8s.Kubectl("get", "configmap", "app", "-ojson")(t.Context())).
+		Should(k8s.AnyItem(jq.Match(`.metadata.annotations.args == "get configmap app -ojson"`)))
+
+	_, err := k8s.Kubectl("fail")(t.Context())
+	g.Expect(err).Should(MatchError(And(
+		HavePrefix("unable to run kubectl fail: exit status 1"),
+		ContainSubstring(`the server does not have a resource type "fail"`),
+	)))
+}
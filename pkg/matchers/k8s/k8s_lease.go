@@ -0,0 +1,116 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/onsi/gomega/types"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Lease returns a pollable function fetching the coordination.k8s.io Lease
+// with the given key, i.e. the one used for leader election by a controller:
+//
+//	Eventually(k.Lease(key)).WithContext(ctx).Should(k8s.HoldLease("controller-0"))
+func (m *Matcher) Lease(key client.ObjectKey) func(ctx context.Context) (*coordinationv1.Lease, error) {
+	return func(ctx context.Context) (*coordinationv1.Lease, error) {
+		lease := coordinationv1.Lease{}
+
+		if err := m.client.Get(ctx, key, &lease); err != nil {
+			return nil, newOpError("get", coordinationv1.SchemeGroupVersion.WithKind("Lease"), key, err)
+		}
+
+		return &lease, nil
+	}
+}
+
+// HoldLease succeeds if the actual Lease is held by the given identity, i.e.
+// its .spec.holderIdentity, and has not expired, i.e. its .spec.renewTime is
+// not older than its .spec.leaseDurationSeconds. The freshness requirement
+// can be tightened with RenewedWithin.
+func HoldLease(holder string) *LeaseMatcher {
+	return &LeaseMatcher{
+		holder: holder,
+	}
+}
+
+var _ types.GomegaMatcher = &LeaseMatcher{}
+
+// LeaseMatcher is the matcher returned by HoldLease.
+type LeaseMatcher struct {
+	holder        string
+	renewedWithin time.Duration
+
+	name    string
+	failure string
+}
+
+// RenewedWithin requires the .spec.renewTime of the Lease not to be older
+// than the given duration, regardless of its .spec.leaseDurationSeconds.
+func (matcher *LeaseMatcher) RenewedWithin(d time.Duration) *LeaseMatcher {
+	matcher.renewedWithin = d
+
+	return matcher
+}
+
+func (matcher *LeaseMatcher) Match(actual interface{}) (bool, error) {
+	obj, err := toObject(actual)
+	if err != nil {
+		return false, err
+	}
+
+	lease, err := decode[*coordinationv1.Lease](obj)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.name = client.ObjectKeyFromObject(lease).String()
+	matcher.failure = ""
+
+	holder := ""
+	if lease.Spec.HolderIdentity != nil {
+		holder = *lease.Spec.HolderIdentity
+	}
+
+	if holder != matcher.holder {
+		matcher.failure = fmt.Sprintf("Expected lease %s to be held by %q, but it is held by %q", matcher.name, matcher.holder, holder)
+
+		return false, nil
+	}
+
+	if lease.Spec.RenewTime == nil {
+		matcher.failure = fmt.Sprintf("Expected lease %s to be held by %q, but it has never been renewed", matcher.name, matcher.holder)
+
+		return false, nil
+	}
+
+	validity := matcher.renewedWithin
+	if validity <= 0 {
+		if lease.Spec.LeaseDurationSeconds == nil {
+			matcher.failure = fmt.Sprintf("Expected lease %s to be held by %q, but it has no lease duration", matcher.name, matcher.holder)
+
+			return false, nil
+		}
+
+		validity = time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+	}
+
+	if elapsed := time.Since(lease.Spec.RenewTime.Time); elapsed > validity {
+		matcher.failure = fmt.Sprintf("Expected lease %s to be held by %q, but it has not been renewed for %s, more than %s",
+			matcher.name, matcher.holder, elapsed.Round(time.Second), validity)
+
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (matcher *LeaseMatcher) FailureMessage(_ interface{}) string {
+	return matcher.failure
+}
+
+func (matcher *LeaseMatcher) NegatedFailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected lease %s not to be held by %q", matcher.name, matcher.holder)
+}
@@ -0,0 +1,78 @@
+package k8s_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func newLease(holder string, renewed time.Duration, duration int32) *coordinationv1.Lease {
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: "controller-leader", Namespace: "ns"},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       ptrTo(holder),
+			LeaseDurationSeconds: ptrTo(duration),
+			RenewTime:            ptrTo(metav1.NewMicroTime(time.Now().Add(-renewed))),
+		},
+	}
+}
+
+func TestLease(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	lease := newLease("controller-0", time.Second, 15)
+
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(lease).Build()
+	k := k8s.New(cli, scheme)
+
+	g.Eventually(k.Lease(client.ObjectKeyFromObject(lease))).
+		WithContext(t.Context()).
+		Should(k8s.HoldLease("controller-0"))
+
+	_, err := k.Lease(client.ObjectKey{Namespace: "ns", Name: "missing"})(t.Context())
+	g.Expect(err).Should(MatchError(&k8s.NotFoundError{}))
+}
+
+func TestHoldLease(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	g.Expect(newLease("controller-0", time.Second, 15)).Should(k8s.HoldLease("controller-0"))
+	g.Expect(newLease("controller-0", time.Second, 15)).Should(k8s.HoldLease("controller-0").RenewedWithin(5 * time.Second))
+	g.Expect(newLease("controller-0", time.Second, 15)).ShouldNot(k8s.HoldLease("controller-1"))
+	g.Expect(newLease("controller-0", 10*time.Second, 15)).ShouldNot(k8s.HoldLease("controller-0").RenewedWithin(5 * time.Second))
+
+	m := k8s.HoldLease("controller-1")
+	g.Expect(m.Match(newLease("controller-0", time.Second, 15))).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(Equal(`Expected lease ns/controller-leader to be held by "controller-1", but it is held by "controller-0"`))
+
+	m = k8s.HoldLease("controller-0")
+	g.Expect(m.Match(newLease("controller-0", time.Minute, 15))).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(Equal(`Expected lease ns/controller-leader to be held by "controller-0", but it has not been renewed for 1m0s, more than 15s`))
+
+	lease := newLease("controller-0", 0, 15)
+	lease.Spec.RenewTime = nil
+
+	m = k8s.HoldLease("controller-0")
+	g.Expect(m.Match(lease)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(HaveSuffix("but it has never been renewed"))
+
+	m = k8s.HoldLease("controller-0")
+	g.Expect(m.Match(newLease("controller-0", time.Second, 15))).Should(BeTrue())
+	g.Expect(m.NegatedFailureMessage(nil)).Should(Equal(`Expected lease ns/controller-leader not to be held by "controller-0"`))
+}
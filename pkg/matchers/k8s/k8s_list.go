@@ -0,0 +1,114 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultPageSize is the page size used by ListAll when no Limit is given.
+const defaultPageSize = 500
+
+// Aliases of the most common controller-runtime list options, so that tests
+// can build queries without importing the client package.
+type (
+	// InNamespace restricts a list to the given namespace.
+	InNamespace = client.InNamespace
+	// MatchingLabels restricts a list to the objects with the given labels.
+	MatchingLabels = client.MatchingLabels
+	// MatchingFields restricts a list to the objects with the given field
+	// values, i.e. MatchingFields{"status.phase": "Running"}.
+	MatchingFields = client.MatchingFields
+	// Limit sets the maximum number of objects returned by a list, and the
+	// page size used by ListAll.
+	Limit = client.Limit
+)
+
+// List returns a pollable function listing the objects matching the given
+// options into list.
+func (m *Matcher) List(list client.ObjectList, opts ...client.ListOption) func(ctx context.Context) (client.ObjectList, error) {
+	return func(ctx context.Context) (client.ObjectList, error) {
+		if err := m.client.List(ctx, list, opts...); err != nil {
+			return nil, newListError(m.client, list, opts, err)
+		}
+
+		return list, nil
+	}
+}
+
+// ListAll is like List but follows continue tokens, so that all the matching
+// objects are returned regardless of the page size (set with Limit).
+func (m *Matcher) ListAll(list client.ObjectList, opts ...client.ListOption) func(ctx context.Context) (client.ObjectList, error) {
+	return func(ctx context.Context) (client.ObjectList, error) {
+		if err := listAll(ctx, m.client, list, opts); err != nil {
+			return nil, newListError(m.client, list, opts, err)
+		}
+
+		return list, nil
+	}
+}
+
+// ListAll is like List but follows continue tokens, so that all the matching
+// resources are returned regardless of the page size (set with Limit).
+func (u *UnstructuredMatcher) ListAll(resource string, opts ...client.ListOption) func(ctx context.Context) (*unstructured.UnstructuredList, error) {
+	return func(ctx context.Context) (*unstructured.UnstructuredList, error) {
+		gvk, err := u.Resolve(resource)
+		if err != nil {
+			return nil, err
+		}
+
+		list := unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+		if err := listAll(ctx, u.matcher.client, &list, opts); err != nil {
+			return nil, newOpError("list", gvk, listKey(opts), err)
+		}
+
+		return &list, nil
+	}
+}
+
+// listAll lists all the pages of objects matching the given options into
+// list, which ends up holding the items of all pages.
+func listAll(ctx context.Context, cli client.Client, list client.ObjectList, opts []client.ListOption) error {
+	lo := client.ListOptions{}
+	lo.ApplyOptions(opts)
+
+	if lo.Limit == 0 {
+		lo.Limit = defaultPageSize
+	}
+
+	var items []runtime.Object
+
+	for {
+		if err := cli.List(ctx, list, &lo); err != nil {
+			return err
+		}
+
+		page, err := meta.ExtractList(list)
+		if err != nil {
+			return fmt.Errorf("unable to extract items: %w", err)
+		}
+
+		// the next page is decoded in the same list, which may reuse the
+		// memory backing the items of the current one
+		for _, item := range page {
+			items = append(items, item.DeepCopyObject())
+		}
+
+		lo.Continue = list.GetContinue()
+		if lo.Continue == "" {
+			break
+		}
+	}
+
+	if err := meta.SetList(list, items); err != nil {
+		return fmt.Errorf("unable to set items: %w", err)
+	}
+
+	return nil
+}
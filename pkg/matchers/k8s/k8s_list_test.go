@@ -0,0 +1,98 @@
+package k8s_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	. "github.com/onsi/gomega"
+)
+
+// paginate emulates the API server pagination, which the fake client does
+// not implement, by splitting the result of each List call in pages.
+func paginate() interceptor.Funcs {
+	return interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			lo := client.ListOptions{}
+			lo.ApplyOptions(opts)
+
+			if err := c.List(ctx, list, &client.ListOptions{Namespace: lo.Namespace, LabelSelector: lo.LabelSelector}); err != nil {
+				return err
+			}
+
+			items, err := meta.ExtractList(list)
+			if err != nil {
+				return err
+			}
+
+			start := 0
+			if lo.Continue != "" {
+				start, _ = strconv.Atoi(lo.Continue)
+			}
+
+			end := min(len(items), start+int(lo.Limit))
+			if lo.Limit == 0 {
+				end = len(items)
+			}
+
+			list.SetContinue("")
+			if end < len(items) {
+				list.SetContinue(strconv.Itoa(end))
+			}
+
+			return meta.SetList(list, items[start:end])
+		},
+	}
+}
+
+func TestListAll(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	builder := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithInterceptorFuncs(paginate())
+
+	for i := range 7 {
+		builder = builder.WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("cm-%d", i),
+				Namespace: "ns",
+				Labels:    map[string]string{"app": "test"},
+			},
+		})
+	}
+
+	k := k8s.New(builder.Build(), scheme)
+
+	// a single page is truncated
+	g.Expect(k.List(&corev1.ConfigMapList{}, k8s.InNamespace("ns"), k8s.Limit(3))(t.Context())).
+		Should(HaveField("Items", HaveLen(3)))
+
+	g.Expect(k.ListAll(&corev1.ConfigMapList{}, k8s.InNamespace("ns"), k8s.Limit(3))(t.Context())).
+		Should(HaveField("Items", HaveLen(7)))
+
+	g.Expect(k.ListAll(&corev1.ConfigMapList{}, k8s.MatchingLabels{"app": "test"})(t.Context())).
+		Should(HaveField("Items", HaveLen(7)))
+
+	list, err := k.Unstructured().ListAll("v1/ConfigMap", k8s.InNamespace("ns"), k8s.Limit(2))(t.Context())
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(list).Should(jq.Match(`[.items[].metadata.name] | sort == ["cm-0","cm-1","cm-2","cm-3","cm-4","cm-5","cm-6"]`))
+	g.Expect(list.GetContinue()).Should(BeEmpty())
+}
@@ -0,0 +1,106 @@
+package k8s
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/onsi/gomega/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// HaveFieldOwnedBy succeeds if the .metadata.managedFields of the actual
+// object record the given field manager as an owner of the field at the
+// given path, in the form accepted by HaveField, i.e.:
+//
+//	Expect(deploy).Should(k8s.HaveFieldOwnedBy(".spec.replicas", "my-controller"))
+//
+// so that server-side apply based controllers can be checked not to fight
+// over fields with other actors. A field is owned by a manager if its
+// managed fields set includes it, either as a leaf or as an object some of
+// whose fields are owned.
+func HaveFieldOwnedBy(path string, manager string) types.GomegaMatcher {
+	return &fieldOwnerMatcher{
+		Path:    path,
+		Manager: manager,
+	}
+}
+
+var _ types.GomegaMatcher = &fieldOwnerMatcher{}
+
+type fieldOwnerMatcher struct {
+	Path    string
+	Manager string
+
+	kind   string
+	owners []string
+}
+
+func (matcher *fieldOwnerMatcher) Match(actual interface{}) (bool, error) {
+	fields, err := parseFieldPath(matcher.Path)
+	if err != nil {
+		return false, err
+	}
+
+	obj, err := toObject(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.kind = kindOf(obj)
+
+	entries, _, err := unstructured.NestedSlice(obj, "metadata", "managedFields")
+	if err != nil {
+		return false, fmt.Errorf("unable to read .metadata.managedFields: %w", err)
+	}
+
+	matcher.owners = nil
+
+	for i := range entries {
+		entry, ok := entries[i].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		manager, _ := entry["manager"].(string)
+		set, _ := entry["fieldsV1"].(map[string]any)
+
+		if ownsField(set, fields) && !slices.Contains(matcher.owners, manager) {
+			matcher.owners = append(matcher.owners, manager)
+		}
+	}
+
+	return slices.Contains(matcher.owners, matcher.Manager), nil
+}
+
+func (matcher *fieldOwnerMatcher) FailureMessage(_ interface{}) string {
+	if len(matcher.owners) == 0 {
+		return fmt.Sprintf("Expected field %s of %s to be owned by %q, but it is not owned by any manager", matcher.Path, matcher.kind, matcher.Manager)
+	}
+
+	return fmt.Sprintf("Expected field %s of %s to be owned by %q, but it is owned by %q", matcher.Path, matcher.kind, matcher.Manager, matcher.owners)
+}
+
+func (matcher *fieldOwnerMatcher) NegatedFailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected field %s of %s not to be owned by %q, but it is owned by %q", matcher.Path, matcher.kind, matcher.Manager, matcher.owners)
+}
+
+// ownsField reports whether the given FieldsV1 set, i.e.
+// {"f:spec":{"f:replicas":{}}}, includes the field with the given path.
+func ownsField(set map[string]any, fields []string) bool {
+	if set == nil {
+		return false
+	}
+
+	current := set
+
+	for _, field := range fields {
+		next, ok := current["f:"+field].(map[string]any)
+		if !ok {
+			return false
+		}
+
+		current = next
+	}
+
+	return true
+}
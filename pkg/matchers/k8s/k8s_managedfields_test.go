@@ -0,0 +1,68 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestHaveFieldOwnedBy(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	deploy := appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "app",
+			ManagedFields: []metav1.ManagedFieldsEntry{{
+				Manager:    "my-controller",
+				Operation:  metav1.ManagedFieldsOperationApply,
+				APIVersion: "apps/v1",
+				FieldsType: "FieldsV1",
+				FieldsV1: &metav1.FieldsV1{Raw: []byte(
+					`{"f:metadata":{"f:labels":{"f:app.kubernetes.io/name":{}}},"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"app\"}":{".":{},"f:image":{}}}}}}}`,
+				)},
+			}, {
+				Manager:     "kube-controller-manager",
+				Operation:   metav1.ManagedFieldsOperationUpdate,
+				APIVersion:  "apps/v1",
+				FieldsType:  "FieldsV1",
+				FieldsV1:    &metav1.FieldsV1{Raw: []byte(`{"f:status":{"f:replicas":{}}}`)},
+				Subresource: "status",
+			}, {
+				Manager:    "hpa",
+				Operation:  metav1.ManagedFieldsOperationApply,
+				APIVersion: "apps/v1",
+				FieldsType: "FieldsV1",
+				FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)},
+			}},
+		},
+	}
+
+	g.Expect(&deploy).Should(k8s.HaveFieldOwnedBy(`.metadata.labels["app.kubernetes.io/name"]`, "my-controller"))
+	g.Expect(&deploy).Should(k8s.HaveFieldOwnedBy(".spec.template.spec.containers", "my-controller"))
+	g.Expect(&deploy).Should(k8s.HaveFieldOwnedBy(".spec", "my-controller"))
+	g.Expect(&deploy).Should(k8s.HaveFieldOwnedBy(".spec", "hpa"))
+	g.Expect(&deploy).Should(k8s.HaveFieldOwnedBy(".status.replicas", "kube-controller-manager"))
+	g.Expect(&deploy).ShouldNot(k8s.HaveFieldOwnedBy(".spec.replicas", "my-controller"))
+
+	m := k8s.HaveFieldOwnedBy(".spec.replicas", "my-controller")
+	g.Expect(m.Match(&deploy)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(Equal(`Expected field .spec.replicas of Deployment to be owned by "my-controller", but it is owned by ["hpa"]`))
+
+	m = k8s.HaveFieldOwnedBy(".spec.paused", "my-controller")
+	g.Expect(m.Match(&deploy)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(Equal(`Expected field .spec.paused of Deployment to be owned by "my-controller", but it is not owned by any manager`))
+
+	m = k8s.HaveFieldOwnedBy(".spec.replicas", "hpa")
+	g.Expect(m.Match(&deploy)).Should(BeTrue())
+	g.Expect(m.NegatedFailureMessage(nil)).Should(Equal(`Expected field .spec.replicas of Deployment not to be owned by "hpa", but it is owned by ["hpa"]`))
+
+	_, err := k8s.HaveFieldOwnedBy("spec", "hpa").Match(&deploy)
+	g.Expect(err).Should(MatchError(ContainSubstring("invalid field path")))
+}
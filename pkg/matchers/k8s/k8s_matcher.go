@@ -0,0 +1,289 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// Matcher provides access to Kubernetes resources in a form that can be
+// consumed by Gomega assertions, i.e. as pollable functions that can be fed
+// to Eventually/Consistently and combined with the jq/yq matchers.
+type Matcher struct {
+	ctx       context.Context
+	client    client.Client
+	live      client.Client
+	scheme    *runtime.Scheme
+	discovery discovery.DiscoveryInterface
+	resolver  *resolver
+	refresh   time.Duration
+	config    *rest.Config
+	limiter   flowcontrol.RateLimiter
+	verbose   bool
+	recorder  *Recorder
+	in        *instrumentation
+	gomega    types.Gomega
+	timeout   time.Duration
+	polling   time.Duration
+
+	// bounds each API call, see WithTimeout
+	callTimeout time.Duration
+
+	interceptors *interceptor.Funcs
+
+	// names the objects created without a name, see WithTestNames
+	test testing.TB
+
+	// deletes the objects created through the Matcher, see Tracked
+	tracker *tracker
+
+	// only used by the factory constructors
+	addToScheme []func(*runtime.Scheme) error
+	cacheCtx    context.Context
+}
+
+// Option configures a Matcher.
+type Option func(*Matcher)
+
+// WithDiscovery sets the discovery client used to resolve short resource
+// names such as "deployment" or "deploy" to their GroupVersionKind.
+func WithDiscovery(dc discovery.DiscoveryInterface) Option {
+	return func(m *Matcher) {
+		m.discovery = dc
+	}
+}
+
+// WithDiscoveryRefreshInterval sets the minimum interval between the discovery
+// refreshes triggered by resources that cannot be resolved, which defaults to
+// 10 seconds. A zero interval refreshes upon every miss.
+func WithDiscoveryRefreshInterval(interval time.Duration) Option {
+	return func(m *Matcher) {
+		m.refresh = interval
+	}
+}
+
+// WithAddToScheme registers additional types in the scheme created by the
+// factory constructors (NewFromConfig, NewFromKubeconfig, NewFakeWithOptions),
+// on top of the built-in Kubernetes types. It has no effect on New.
+func WithAddToScheme(fns ...func(*runtime.Scheme) error) Option {
+	return func(m *Matcher) {
+		m.addToScheme = append(m.addToScheme, fns...)
+	}
+}
+
+// WithCache makes the factory constructors (NewFromConfig, NewFromKubeconfig)
+// serve Get and List requests from a shared informer cache, which is started
+// lazily for each requested kind and stopped when the given context is done.
+// Live requests can still be performed through Matcher.Live. It has no effect
+// on New, where a cache-backed client can be provided directly.
+func WithCache(ctx context.Context) Option {
+	return func(m *Matcher) {
+		m.cacheCtx = ctx
+	}
+}
+
+// WithRateLimit limits the rate of the API calls performed through the
+// Matcher to qps requests per second, with bursts of at most burst requests.
+// The limit is shared by all the copies of the Matcher (i.e. the ones
+// returned by WithContext and Live), which helps keeping polling assertions
+// against shared clusters polite.
+func WithRateLimit(qps float32, burst int) Option {
+	return func(m *Matcher) {
+		m.limiter = flowcontrol.NewTokenBucketRateLimiter(qps, burst)
+	}
+}
+
+// WithVerbose makes the Matcher report the number of API calls it has
+// performed in the errors and failure messages it produces.
+func WithVerbose() Option {
+	return func(m *Matcher) {
+		m.verbose = true
+	}
+}
+
+// WithInterceptors routes the API calls performed through the Matcher via the
+// given interceptor functions, so that tests can inject latency or errors,
+// i.e. make the first Get calls fail with NotFound, to exercise the retry
+// logic of the code under test.
+func WithInterceptors(funcs interceptor.Funcs) Option {
+	return func(m *Matcher) {
+		m.interceptors = &funcs
+	}
+}
+
+// WithDefaultTimeout sets the timeout applied to the assertions created by
+// Matcher.Eventually and its variants.
+func WithDefaultTimeout(timeout time.Duration) Option {
+	return func(m *Matcher) {
+		m.timeout = timeout
+	}
+}
+
+// WithDefaultPolling sets the polling interval applied to the assertions
+// created by Matcher.Eventually and its variants.
+func WithDefaultPolling(polling time.Duration) Option {
+	return func(m *Matcher) {
+		m.polling = polling
+	}
+}
+
+// New creates a Matcher backed by the given client and scheme.
+func New(cli client.Client, scheme *runtime.Scheme, opts ...Option) *Matcher {
+	m := newMatcher(opts...)
+	m.setup(cli, scheme)
+
+	return m
+}
+
+// newMatcher creates a Matcher with the given options applied, which the
+// factory constructors complete with setup once they have built the client,
+// so that options are only applied once.
+func newMatcher(opts ...Option) *Matcher {
+	m := Matcher{
+		ctx:     context.Background(),
+		gomega:  gomega.Default,
+		refresh: defaultRefreshInterval,
+	}
+
+	for _, opt := range opts {
+		opt(&m)
+	}
+
+	return &m
+}
+
+// setup completes the Matcher with the given client and scheme.
+func (m *Matcher) setup(cli client.Client, scheme *runtime.Scheme) {
+	m.scheme = scheme
+	m.resolver = newResolver(m.discovery, m.refresh)
+	m.in = newInstrumentation()
+	m.in.limiter = m.limiter
+	m.in.verbose = m.verbose
+	m.in.recorder = m.recorder
+	m.client = m.instrument(cli)
+}
+
+// WithContext returns a copy of the Matcher that uses the given context for
+// the API calls performed by Gomega matchers, which have no other way to get
+// hold of a context.
+func (m *Matcher) WithContext(ctx context.Context) *Matcher {
+	c := *m
+	c.ctx = ctx
+
+	return &c
+}
+
+// WithTimeout returns a copy of the Matcher that bounds each API call it
+// performs to the given duration, on top of the deadline of the context the
+// call is made with, so that a hung API server fails a single call rather
+// than stalling a polling function past the timeout of Eventually. Unlike
+// WithDefaultTimeout, it applies to individual calls, not to assertions.
+func (m *Matcher) WithTimeout(timeout time.Duration) *Matcher {
+	c := *m
+	c.callTimeout = timeout
+	c.client = withCallTimeout(m.client, timeout)
+
+	if m.live != nil {
+		c.live = withCallTimeout(m.live, timeout)
+	}
+
+	return &c
+}
+
+// WithGomega returns a copy of the Matcher that creates assertions through
+// the given Gomega instance, i.e. the one returned by NewWithT, rather than
+// the global one.
+func (m *Matcher) WithGomega(g types.Gomega) *Matcher {
+	c := *m
+	c.gomega = g
+
+	return &c
+}
+
+// Client returns the controller-runtime client used by the Matcher.
+func (m *Matcher) Client() client.Client {
+	return m.client
+}
+
+// Live returns a copy of the Matcher that bypasses the informer cache set up
+// by WithCache and reads directly from the API server. If no cache has been
+// configured, the returned Matcher behaves as the original one.
+func (m *Matcher) Live() *Matcher {
+	c := *m
+
+	if m.live != nil {
+		c.client = m.live
+	}
+
+	return &c
+}
+
+// Metrics returns a snapshot of the number of API calls performed through the
+// Matcher and all its copies.
+func (m *Matcher) Metrics() Metrics {
+	return m.in.metrics()
+}
+
+// Config returns the REST config the Matcher has been created from, if it has
+// been created by one of the factory constructors, nil otherwise.
+func (m *Matcher) Config() *rest.Config {
+	return m.config
+}
+
+// Scheme returns the scheme used by the Matcher.
+func (m *Matcher) Scheme() *runtime.Scheme {
+	return m.scheme
+}
+
+// Unstructured returns an UnstructuredMatcher sharing the configuration of
+// the Matcher.
+func (m *Matcher) Unstructured() *UnstructuredMatcher {
+	return &UnstructuredMatcher{
+		matcher: m,
+	}
+}
+
+func (m *Matcher) instrument(cli client.Client) client.Client {
+	if m.interceptors != nil {
+		cli = interceptor.NewClient(withWatch(cli), *m.interceptors)
+	}
+
+	return &instrumentedClient{
+		Client:  cli,
+		in:      m.in,
+		timeout: m.callTimeout,
+	}
+}
+
+func withCallTimeout(cli client.Client, timeout time.Duration) client.Client {
+	ic, ok := cli.(*instrumentedClient)
+	if !ok {
+		return cli
+	}
+
+	c := *ic
+	c.timeout = timeout
+
+	return &c
+}
+
+// annotate appends the API call metrics to the given failure message, if the
+// Matcher has been configured with WithVerbose.
+func (m *Matcher) annotate(message string) string {
+	if !m.verbose {
+		return message
+	}
+
+	return fmt.Sprintf("%s\n[api calls: %s]", message, m.in.metrics())
+}
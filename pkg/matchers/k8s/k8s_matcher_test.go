@@ -0,0 +1,65 @@
+package k8s_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestWithTimeout(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"},
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(cm).
+		Build()
+
+	// updates hang until the context of the call is done, as they would
+	// against an unresponsive API server
+	k := k8s.New(cli, scheme, k8s.WithInterceptors(interceptor.Funcs{
+		Update: func(ctx context.Context, _ client.WithWatch, _ client.Object, _ ...client.UpdateOption) error {
+			<-ctx.Done()
+
+			return ctx.Err()
+		},
+	}))
+
+	bounded := k.WithTimeout(50 * time.Millisecond).WithContext(t.Context())
+
+	start := time.Now()
+	err := bounded.Update(cm, func() { cm.Data = map[string]string{"a": "b"} })()
+	g.Expect(err).Should(MatchError(context.DeadlineExceeded))
+	g.Expect(time.Since(start)).Should(BeNumerically("<", 5*time.Second))
+
+	// calls that complete in time are not affected
+	u, err := bounded.Object(cm)()
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(u.GetName()).Should(Equal("cm"))
+
+	// the original Matcher is not bounded, only the context is
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	err = k.WithContext(ctx).Update(cm, func() {})()
+	g.Expect(err).Should(MatchError(context.DeadlineExceeded))
+}
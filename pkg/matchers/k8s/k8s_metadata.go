@@ -0,0 +1,126 @@
+package k8s
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// HaveFinalizer succeeds if the .metadata.finalizers of the actual object
+// contain the given finalizer.
+func HaveFinalizer(name string) types.GomegaMatcher {
+	return &finalizerMatcher{
+		name: name,
+	}
+}
+
+var _ types.GomegaMatcher = &finalizerMatcher{}
+
+type finalizerMatcher struct {
+	name       string
+	finalizers []string
+}
+
+func (matcher *finalizerMatcher) Match(actual interface{}) (bool, error) {
+	obj, err := toObject(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.finalizers, _, err = unstructured.NestedStringSlice(obj, "metadata", "finalizers")
+	if err != nil {
+		return false, fmt.Errorf("unable to read .metadata.finalizers: %w", err)
+	}
+
+	return slices.Contains(matcher.finalizers, matcher.name), nil
+}
+
+func (matcher *finalizerMatcher) FailureMessage(_ interface{}) string {
+	return format.Message(matcher.finalizers, "to contain finalizer", matcher.name)
+}
+
+func (matcher *finalizerMatcher) NegatedFailureMessage(_ interface{}) string {
+	return format.Message(matcher.finalizers, "not to contain finalizer", matcher.name)
+}
+
+// HaveOwnerReferenceTo succeeds if the .metadata.ownerReferences of the
+// actual object contain a reference to the given owner. References are
+// matched by UID when the owner has one, by kind and name otherwise (the kind
+// is ignored if it cannot be determined, i.e. for typed objects without
+// TypeMeta).
+func HaveOwnerReferenceTo(owner any) types.GomegaMatcher {
+	return &ownerReferenceMatcher{
+		owner: owner,
+	}
+}
+
+var _ types.GomegaMatcher = &ownerReferenceMatcher{}
+
+type ownerReferenceMatcher struct {
+	owner      any
+	expected   map[string]any
+	references []any
+}
+
+func (matcher *ownerReferenceMatcher) Match(actual interface{}) (bool, error) {
+	owner, err := toObject(matcher.owner)
+	if err != nil {
+		return false, fmt.Errorf("invalid owner: %w", err)
+	}
+
+	obj, err := toObject(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.expected = map[string]any{}
+
+	if uid, ok, _ := unstructured.NestedString(owner, "metadata", "uid"); ok && uid != "" {
+		matcher.expected["uid"] = uid
+	} else {
+		matcher.expected["name"], _, _ = unstructured.NestedString(owner, "metadata", "name")
+
+		if kind, ok, _ := unstructured.NestedString(owner, "kind"); ok && kind != "" {
+			matcher.expected["kind"] = kind
+		}
+	}
+
+	matcher.references, _, err = unstructured.NestedSlice(obj, "metadata", "ownerReferences")
+	if err != nil {
+		return false, fmt.Errorf("unable to read .metadata.ownerReferences: %w", err)
+	}
+
+	for i := range matcher.references {
+		ref, ok := matcher.references[i].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if matchesReference(ref, matcher.expected) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (matcher *ownerReferenceMatcher) FailureMessage(_ interface{}) string {
+	return format.Message(matcher.references, "to contain an owner reference matching", matcher.expected)
+}
+
+func (matcher *ownerReferenceMatcher) NegatedFailureMessage(_ interface{}) string {
+	return format.Message(matcher.references, "not to contain an owner reference matching", matcher.expected)
+}
+
+func matchesReference(ref map[string]any, expected map[string]any) bool {
+	for k, v := range expected {
+		if ref[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
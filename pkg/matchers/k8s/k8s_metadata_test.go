@@ -0,0 +1,62 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestHaveFinalizer(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	cm := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Finalizers: []string{"example.com/cleanup"}},
+	}
+
+	g.Expect(&cm).Should(k8s.HaveFinalizer("example.com/cleanup"))
+	g.Expect(&cm).ShouldNot(k8s.HaveFinalizer("example.com/other"))
+	g.Expect(`{"metadata":{}}`).ShouldNot(k8s.HaveFinalizer("example.com/cleanup"))
+
+	m := k8s.HaveFinalizer("example.com/other")
+	g.Expect(m.Match(&cm)).Should(BeFalse())
+	g.Expect(m.FailureMessage(&cm)).Should(ContainSubstring("example.com/cleanup"))
+}
+
+func TestHaveOwnerReferenceTo(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	owner := appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "app", UID: "1234"},
+	}
+
+	rs := appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "Deployment", Name: "app", UID: "1234"},
+			},
+		},
+	}
+
+	g.Expect(&rs).Should(k8s.HaveOwnerReferenceTo(&owner))
+
+	// matched by kind and name when the owner has no UID
+	g.Expect(&rs).Should(k8s.HaveOwnerReferenceTo(`{"kind":"Deployment","metadata":{"name":"app"}}`))
+	g.Expect(&rs).ShouldNot(k8s.HaveOwnerReferenceTo(`{"kind":"StatefulSet","metadata":{"name":"app"}}`))
+
+	other := owner.DeepCopy()
+	other.UID = "5678"
+
+	m := k8s.HaveOwnerReferenceTo(other)
+	g.Expect(m.Match(&rs)).Should(BeFalse())
+	g.Expect(m.FailureMessage(&rs)).Should(And(ContainSubstring("1234"), ContainSubstring("5678")))
+}
@@ -0,0 +1,115 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// length of the random suffix of the names generated by TestName, as
+	// for the names generated by the API server from metadata.generateName
+	testNameSuffixLength = 5
+)
+
+// TestName returns a name for a resource created by the given test, made of
+// the given prefix, the name of the test and a random suffix, i.e.
+// "cm-testreconcile-with-owner-x7k2p" for the subtest "with owner" of
+// TestReconcile. The name is a valid RFC 1123 label, hence usable for any
+// kind of resource: characters not allowed are replaced by dashes and the
+// test name, then the prefix, are truncated to fit 63 characters, the random
+// suffix always being kept so that tests sharing a cluster do not clash.
+func TestName(t testing.TB, prefix string) string {
+	budget := validation.DNS1123LabelMaxLength - testNameSuffixLength - 1
+
+	p := sanitizeName(prefix)
+	n := sanitizeName(t.Name())
+
+	switch {
+	case len(p) >= budget:
+		p = strings.TrimRight(p[:budget], "-")
+		n = ""
+	case p != "" && len(p)+1+len(n) > budget:
+		n = strings.TrimRight(n[:budget-len(p)-1], "-")
+	case len(n) > budget:
+		n = strings.TrimRight(n[:budget], "-")
+	}
+
+	parts := make([]string, 0, 3)
+
+	for _, s := range []string{p, n, utilrand.String(testNameSuffixLength)} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+
+	return strings.Join(parts, "-")
+}
+
+// sanitizeName lower-cases the given string and replaces the sequences of
+// characters not allowed in an RFC 1123 label by a single dash.
+func sanitizeName(s string) string {
+	var sb strings.Builder
+
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'):
+			sb.WriteRune(r)
+		case sb.Len() > 0 && !strings.HasSuffix(sb.String(), "-"):
+			sb.WriteByte('-')
+		}
+	}
+
+	return strings.TrimRight(sb.String(), "-")
+}
+
+// WithTestNames returns a copy of the Matcher whose Create and ApplyAs give
+// the objects having neither a name nor a generateName one built by
+// TestName, prefixed by their lower-cased kind:
+//
+//	k := k.WithTestNames(t)
+//
+//	cm := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: ns}}
+//	Expect(k.Create(ctx, &cm)).To(Succeed())
+//	// cm.Name is i.e. "configmap-testreconcile-x7k2p"
+func (m *Matcher) WithTestNames(t testing.TB) *Matcher {
+	c := *m
+	c.test = t
+
+	return &c
+}
+
+// Create creates the given object, naming it first if WithTestNames is set
+// and it has no name, and registering its deletion if the Matcher has been
+// created by Tracked. On success, obj is updated with the object returned by
+// the API server.
+func (m *Matcher) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	gvk, err := gvkFor(m.client, obj)
+	if err != nil {
+		return err
+	}
+
+	m.ensureName(obj, gvk.Kind)
+
+	if err := m.client.Create(ctx, obj, opts...); err != nil {
+		return newOpError("create", gvk, client.ObjectKeyFromObject(obj), err)
+	}
+
+	m.track(obj, gvk)
+
+	return nil
+}
+
+// ensureName names the given object with TestName if WithTestNames is set
+// and the object has neither a name nor a generateName.
+func (m *Matcher) ensureName(obj client.Object, kind string) {
+	if m.test == nil || obj.GetName() != "" || obj.GetGenerateName() != "" {
+		return
+	}
+
+	obj.SetName(TestName(m.test, kind))
+}
@@ -0,0 +1,78 @@
+package k8s_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestTestName(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	name := k8s.TestName(t, "cm")
+	g.Expect(name).Should(MatchRegexp(`^cm-testtestname-[a-z0-9]{5}$`))
+	g.Expect(validation.IsDNS1123Label(name)).Should(BeEmpty())
+	g.Expect(k8s.TestName(t, "cm")).ShouldNot(Equal(name))
+
+	g.Expect(k8s.TestName(t, "")).Should(MatchRegexp(`^testtestname-[a-z0-9]{5}$`))
+	g.Expect(k8s.TestName(t, "My_Prefix.")).Should(MatchRegexp(`^my-prefix-testtestname-[a-z0-9]{5}$`))
+
+	t.Run("With Sub_Test/and.more", func(t *testing.T) {
+		t.Parallel()
+
+		g := NewWithT(t)
+
+		g.Expect(k8s.TestName(t, "cm")).Should(MatchRegexp(`^cm-testtestname-with-sub-test-and-more-[a-z0-9]{5}$`))
+	})
+
+	t.Run(strings.Repeat("long", 20), func(t *testing.T) {
+		t.Parallel()
+
+		g := NewWithT(t)
+
+		name := k8s.TestName(t, "cm")
+		g.Expect(name).Should(HavePrefix("cm-testtestname-longlong"))
+		g.Expect(validation.IsDNS1123Label(name)).Should(BeEmpty())
+
+		name = k8s.TestName(t, strings.Repeat("prefix-", 10))
+		g.Expect(name).Should(HavePrefix("prefix-prefix"))
+		g.Expect(name).ShouldNot(ContainSubstring("long"))
+		g.Expect(validation.IsDNS1123Label(name)).Should(BeEmpty())
+	})
+}
+
+func TestWithTestNames(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	k := k8s.NewFake().WithTestNames(t)
+
+	cm := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "ns"}}
+	g.Expect(k.Create(t.Context(), &cm)).Should(Succeed())
+	g.Expect(cm.Name).Should(MatchRegexp(`^configmap-testwithtestnames-[a-z0-9]{5}$`))
+	g.Expect(cm.ResourceVersion).ShouldNot(BeEmpty())
+
+	named := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "named", Namespace: "ns"}}
+	g.Expect(k.Create(t.Context(), &named)).Should(Succeed())
+	g.Expect(named.Name).Should(Equal("named"))
+
+	applied := corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns"},
+	}
+	g.Expect(k.ApplyAs(t.Context(), &applied, "test", false)).Should(Succeed())
+	g.Expect(applied.Name).Should(HavePrefix("configmap-testwithtestnames-"))
+
+	// without WithTestNames, the API server rejects unnamed objects
+	err := k8s.NewFake().Create(t.Context(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "ns"}})
+	g.Expect(err).Should(MatchError(ContainSubstring("unable to create ConfigMap")))
+}
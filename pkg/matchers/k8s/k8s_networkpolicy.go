@@ -0,0 +1,389 @@
+package k8s
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const namespaceNameLabel = "kubernetes.io/metadata.name"
+
+// Endpoint describes one end of a connection evaluated by AllowTraffic.
+type Endpoint struct {
+	// Namespace of the pod.
+	Namespace string
+	// NamespaceLabels are the labels of the namespace of the pod, the
+	// kubernetes.io/metadata.name label is always set to Namespace.
+	NamespaceLabels map[string]string
+	// Labels of the pod.
+	Labels map[string]string
+	// IP of the pod, only used to evaluate ipBlock peers.
+	IP string
+	// NamedPorts maps the names of the container ports of the pod to their
+	// numbers, only used to evaluate named ports when the Endpoint is the
+	// destination of the traffic.
+	NamedPorts map[string]int32
+}
+
+func (e Endpoint) namespaceLabels() labels.Set {
+	l := labels.Set{}
+
+	for k, v := range e.NamespaceLabels {
+		l[k] = v
+	}
+
+	l[namespaceNameLabel] = e.Namespace
+
+	return l
+}
+
+func (e Endpoint) String() string {
+	return fmt.Sprintf("%s/%s", e.Namespace, labels.Set(e.Labels).String())
+}
+
+// AllowTraffic succeeds if the actual set of NetworkPolicies allows TCP
+// traffic from src to port on dst, according to the NetworkPolicy semantics:
+// traffic is allowed if it is allowed both as egress from src (or src is not
+// selected by any egress policy) and as ingress to dst (or dst is not
+// selected by any ingress policy). The actual can be a NetworkPolicy, a
+// slice or a list of NetworkPolicies, either typed or unstructured. Use
+// WithProtocol for other protocols.
+func AllowTraffic(src Endpoint, dst Endpoint, port int32) *TrafficMatcher {
+	return &TrafficMatcher{
+		src:      src,
+		dst:      dst,
+		port:     port,
+		protocol: corev1.ProtocolTCP,
+	}
+}
+
+var _ types.GomegaMatcher = &TrafficMatcher{}
+
+// TrafficMatcher is the matcher returned by AllowTraffic.
+type TrafficMatcher struct {
+	src      Endpoint
+	dst      Endpoint
+	port     int32
+	protocol corev1.Protocol
+
+	reason string
+}
+
+// WithProtocol sets the protocol of the traffic, TCP by default.
+func (matcher *TrafficMatcher) WithProtocol(protocol corev1.Protocol) *TrafficMatcher {
+	matcher.protocol = protocol
+
+	return matcher
+}
+
+func (matcher *TrafficMatcher) Match(actual interface{}) (bool, error) {
+	policies, err := toNetworkPolicies(actual)
+	if err != nil {
+		return false, err
+	}
+
+	egress, err := matcher.evaluate(policies, networkingv1.PolicyTypeEgress)
+	if err != nil {
+		return false, err
+	}
+
+	ingress, err := matcher.evaluate(policies, networkingv1.PolicyTypeIngress)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.reason = strings.Join([]string{egress.reason, ingress.reason}, "\n")
+
+	return egress.allowed && ingress.allowed, nil
+}
+
+func (matcher *TrafficMatcher) FailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected traffic %s to be allowed, but:\n%s", matcher.describe(), format.IndentString(matcher.reason, 1))
+}
+
+func (matcher *TrafficMatcher) NegatedFailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected traffic %s not to be allowed, but:\n%s", matcher.describe(), format.IndentString(matcher.reason, 1))
+}
+
+func (matcher *TrafficMatcher) describe() string {
+	return fmt.Sprintf("from %s to %s on port %d/%s", matcher.src, matcher.dst, matcher.port, matcher.protocol)
+}
+
+type verdict struct {
+	allowed bool
+	reason  string
+}
+
+// evaluate checks whether the traffic is allowed in the given direction, that
+// is as egress from the source or as ingress to the destination.
+func (matcher *TrafficMatcher) evaluate(policies []networkingv1.NetworkPolicy, direction networkingv1.PolicyType) (verdict, error) {
+	subject, peer := matcher.dst, matcher.src
+	if direction == networkingv1.PolicyTypeEgress {
+		subject, peer = matcher.src, matcher.dst
+	}
+
+	var selecting []string
+
+	for i := range policies {
+		p := &policies[i]
+
+		if p.Namespace != subject.Namespace || !hasPolicyType(p, direction) {
+			continue
+		}
+
+		selected, err := selectorMatches(&p.Spec.PodSelector, subject.Labels)
+		if err != nil {
+			return verdict{}, fmt.Errorf("invalid podSelector in NetworkPolicy %s: %w", p.Name, err)
+		}
+
+		if !selected {
+			continue
+		}
+
+		selecting = append(selecting, p.Name)
+
+		allowed, err := matcher.allows(p, direction, peer)
+		if err != nil {
+			return verdict{}, fmt.Errorf("invalid NetworkPolicy %s: %w", p.Name, err)
+		}
+
+		if allowed {
+			return verdict{
+				allowed: true,
+				reason:  fmt.Sprintf("%s of %s is allowed by NetworkPolicy %s", direction, subject, p.Name),
+			}, nil
+		}
+	}
+
+	if len(selecting) == 0 {
+		return verdict{
+			allowed: true,
+			reason:  fmt.Sprintf("%s of %s is not restricted by any NetworkPolicy", direction, subject),
+		}, nil
+	}
+
+	return verdict{
+		allowed: false,
+		reason:  fmt.Sprintf("%s of %s is restricted by NetworkPolicies %v, none of which allows it", direction, subject, selecting),
+	}, nil
+}
+
+func (matcher *TrafficMatcher) allows(p *networkingv1.NetworkPolicy, direction networkingv1.PolicyType, peer Endpoint) (bool, error) {
+	type rule struct {
+		peers []networkingv1.NetworkPolicyPeer
+		ports []networkingv1.NetworkPolicyPort
+	}
+
+	var rules []rule
+
+	if direction == networkingv1.PolicyTypeIngress {
+		for _, r := range p.Spec.Ingress {
+			rules = append(rules, rule{peers: r.From, ports: r.Ports})
+		}
+	} else {
+		for _, r := range p.Spec.Egress {
+			rules = append(rules, rule{peers: r.To, ports: r.Ports})
+		}
+	}
+
+	for _, r := range rules {
+		if !matcher.portMatches(r.ports) {
+			continue
+		}
+
+		if len(r.peers) == 0 {
+			return true, nil
+		}
+
+		for i := range r.peers {
+			ok, err := peerMatches(&r.peers[i], p.Namespace, peer)
+			if err != nil {
+				return false, err
+			}
+
+			if ok {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func (matcher *TrafficMatcher) portMatches(ports []networkingv1.NetworkPolicyPort) bool {
+	if len(ports) == 0 {
+		return true
+	}
+
+	for _, p := range ports {
+		protocol := corev1.ProtocolTCP
+		if p.Protocol != nil {
+			protocol = *p.Protocol
+		}
+
+		if protocol != matcher.protocol {
+			continue
+		}
+
+		if p.Port == nil {
+			return true
+		}
+
+		port := p.Port.IntVal
+
+		if p.Port.Type == intstr.String {
+			// named ports always refer to the ports of the destination
+			named, ok := matcher.dst.NamedPorts[p.Port.StrVal]
+			if !ok {
+				continue
+			}
+
+			port = named
+		}
+
+		end := port
+		if p.EndPort != nil && p.Port.Type == intstr.Int {
+			end = *p.EndPort
+		}
+
+		if matcher.port >= port && matcher.port <= end {
+			return true
+		}
+	}
+
+	return false
+}
+
+func peerMatches(peer *networkingv1.NetworkPolicyPeer, namespace string, endpoint Endpoint) (bool, error) {
+	if peer.IPBlock != nil {
+		return ipBlockMatches(peer.IPBlock, endpoint.IP)
+	}
+
+	if peer.NamespaceSelector != nil {
+		ok, err := selectorMatches(peer.NamespaceSelector, endpoint.namespaceLabels())
+		if err != nil || !ok {
+			return false, err
+		}
+	} else if endpoint.Namespace != namespace {
+		// a podSelector alone selects pods in the namespace of the policy
+		return false, nil
+	}
+
+	if peer.PodSelector != nil {
+		return selectorMatches(peer.PodSelector, endpoint.Labels)
+	}
+
+	return true, nil
+}
+
+func ipBlockMatches(block *networkingv1.IPBlock, ip string) (bool, error) {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false, nil
+	}
+
+	_, cidr, err := net.ParseCIDR(block.CIDR)
+	if err != nil {
+		return false, fmt.Errorf("invalid ipBlock cidr %s: %w", block.CIDR, err)
+	}
+
+	if !cidr.Contains(addr) {
+		return false, nil
+	}
+
+	for _, e := range block.Except {
+		_, except, err := net.ParseCIDR(e)
+		if err != nil {
+			return false, fmt.Errorf("invalid ipBlock except %s: %w", e, err)
+		}
+
+		if except.Contains(addr) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func selectorMatches(selector *metav1.LabelSelector, set labels.Set) (bool, error) {
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false, err
+	}
+
+	return s.Matches(set), nil
+}
+
+// hasPolicyType reports whether the policy applies to the given direction.
+// When policyTypes is not set, policies always apply to ingress, and to
+// egress only if they have egress rules.
+func hasPolicyType(p *networkingv1.NetworkPolicy, t networkingv1.PolicyType) bool {
+	if len(p.Spec.PolicyTypes) > 0 {
+		return slices.Contains(p.Spec.PolicyTypes, t)
+	}
+
+	if t == networkingv1.PolicyTypeIngress {
+		return true
+	}
+
+	return len(p.Spec.Egress) > 0
+}
+
+//nolint:cyclop
+func toNetworkPolicies(in any) ([]networkingv1.NetworkPolicy, error) {
+	switch v := in.(type) {
+	case []networkingv1.NetworkPolicy:
+		return v, nil
+	case networkingv1.NetworkPolicyList:
+		return v.Items, nil
+	case *networkingv1.NetworkPolicyList:
+		return v.Items, nil
+	case networkingv1.NetworkPolicy:
+		return []networkingv1.NetworkPolicy{v}, nil
+	case *networkingv1.NetworkPolicy:
+		return []networkingv1.NetworkPolicy{*v}, nil
+	case []*networkingv1.NetworkPolicy:
+		result := make([]networkingv1.NetworkPolicy, 0, len(v))
+		for _, p := range v {
+			result = append(result, *p)
+		}
+
+		return result, nil
+	case *unstructured.UnstructuredList:
+		return toNetworkPolicies(v.Items)
+	case unstructured.UnstructuredList:
+		return toNetworkPolicies(v.Items)
+	case []unstructured.Unstructured:
+		result := make([]networkingv1.NetworkPolicy, len(v))
+		for i := range v {
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(v[i].Object, &result[i]); err != nil {
+				return nil, &ConversionError{From: v[i].GetName(), To: "NetworkPolicy", Err: err}
+			}
+		}
+
+		return result, nil
+	default:
+		obj, err := toObject(in)
+		if err != nil {
+			return nil, err
+		}
+
+		p := networkingv1.NetworkPolicy{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &p); err != nil {
+			return nil, &ConversionError{From: "unstructured", To: "NetworkPolicy", Err: err}
+		}
+
+		return []networkingv1.NetworkPolicy{p}, nil
+	}
+}
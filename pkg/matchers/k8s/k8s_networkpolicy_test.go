@@ -0,0 +1,92 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestAllowTraffic(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	policies := []networkingv1.NetworkPolicy{
+		{
+			// deny all ingress in the app namespace
+			ObjectMeta: metav1.ObjectMeta{Name: "default-deny", Namespace: "app"},
+			Spec: networkingv1.NetworkPolicySpec{
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			},
+		},
+		{
+			// allow the frontend to reach the api on its http port
+			ObjectMeta: metav1.ObjectMeta{Name: "allow-frontend", Namespace: "app"},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+				Ingress: []networkingv1.NetworkPolicyIngressRule{{
+					From: []networkingv1.NetworkPolicyPeer{
+						{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "frontend"}}},
+						{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"monitoring": "true"}}},
+						{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8", Except: []string{"10.1.0.0/16"}}},
+					},
+					Ports: []networkingv1.NetworkPolicyPort{
+						{Port: ptrTo(intstr.FromString("http"))},
+						{Port: ptrTo(intstr.FromInt32(9000)), EndPort: ptrTo[int32](9010)},
+					},
+				}},
+			},
+		},
+		{
+			// the frontend can only talk to pods in its own namespace
+			ObjectMeta: metav1.ObjectMeta{Name: "frontend-egress", Namespace: "app"},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "frontend"}},
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+				Egress: []networkingv1.NetworkPolicyEgressRule{{
+					To: []networkingv1.NetworkPolicyPeer{
+						{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "app"}}},
+					},
+				}},
+			},
+		},
+	}
+
+	api := k8s.Endpoint{Namespace: "app", Labels: map[string]string{"app": "api"}, NamedPorts: map[string]int32{"http": 8080}}
+	frontend := k8s.Endpoint{Namespace: "app", Labels: map[string]string{"app": "frontend"}}
+	db := k8s.Endpoint{Namespace: "app", Labels: map[string]string{"app": "db"}}
+	prometheus := k8s.Endpoint{Namespace: "monitoring", NamespaceLabels: map[string]string{"monitoring": "true"}, Labels: map[string]string{"app": "prometheus"}}
+	external := k8s.Endpoint{Namespace: "other", IP: "10.2.3.4"}
+	excluded := k8s.Endpoint{Namespace: "other", IP: "10.1.3.4"}
+	outside := k8s.Endpoint{Namespace: "outside", Labels: map[string]string{"app": "web"}}
+
+	g.Expect(policies).Should(k8s.AllowTraffic(frontend, api, 8080))
+	g.Expect(policies).Should(k8s.AllowTraffic(frontend, api, 9005))
+	g.Expect(policies).ShouldNot(k8s.AllowTraffic(frontend, api, 9011))
+	g.Expect(policies).ShouldNot(k8s.AllowTraffic(frontend, api, 8080).WithProtocol(corev1.ProtocolUDP))
+	g.Expect(policies).ShouldNot(k8s.AllowTraffic(db, api, 8080))
+	g.Expect(policies).ShouldNot(k8s.AllowTraffic(frontend, db, 5432))
+	g.Expect(policies).Should(k8s.AllowTraffic(prometheus, api, 8080))
+	g.Expect(policies).Should(k8s.AllowTraffic(external, api, 8080))
+	g.Expect(policies).ShouldNot(k8s.AllowTraffic(excluded, api, 8080))
+
+	// egress from the frontend is restricted to its namespace
+	g.Expect(policies).ShouldNot(k8s.AllowTraffic(frontend, outside, 80))
+
+	// nothing restricts traffic in other namespaces
+	g.Expect(policies).Should(k8s.AllowTraffic(api, outside, 80))
+	g.Expect(&networkingv1.NetworkPolicyList{Items: policies}).ShouldNot(k8s.AllowTraffic(db, db, 5432))
+
+	m := k8s.AllowTraffic(db, api, 8080)
+	g.Expect(m.Match(policies)).Should(BeFalse())
+	g.Expect(m.FailureMessage(policies)).Should(And(
+		ContainSubstring("Egress of app/app=db is not restricted by any NetworkPolicy"),
+		ContainSubstring("Ingress of app/app=api is restricted by NetworkPolicies [default-deny allow-frontend], none of which allows it"),
+	))
+}
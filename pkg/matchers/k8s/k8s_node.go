@@ -0,0 +1,134 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Nodes returns a pollable function listing the cluster nodes matching the
+// given options, i.e. MatchingLabels{"node-role.kubernetes.io/worker": ""}.
+func (m *Matcher) Nodes(opts ...client.ListOption) func(ctx context.Context) (*corev1.NodeList, error) {
+	return func(ctx context.Context) (*corev1.NodeList, error) {
+		list := corev1.NodeList{}
+
+		if err := listAll(ctx, m.client, &list, opts); err != nil {
+			return nil, fmt.Errorf("unable to list nodes: %w", err)
+		}
+
+		return &list, nil
+	}
+}
+
+// NodeReady succeeds if the actual Node reports the Ready condition with
+// status True.
+func NodeReady() types.GomegaMatcher {
+	return &nodeReadyMatcher{}
+}
+
+var _ types.GomegaMatcher = &nodeReadyMatcher{}
+
+type nodeReadyMatcher struct {
+	condition map[string]any
+}
+
+func (matcher *nodeReadyMatcher) Match(actual interface{}) (bool, error) {
+	obj, err := toObject(actual)
+	if err != nil {
+		return false, err
+	}
+
+	c, ok := findCondition(obj, string(corev1.NodeReady))
+	if !ok {
+		matcher.condition = nil
+
+		return false, nil
+	}
+
+	matcher.condition = c
+
+	return c["status"] == string(corev1.ConditionTrue), nil
+}
+
+func (matcher *nodeReadyMatcher) FailureMessage(_ interface{}) string {
+	if matcher.condition == nil {
+		return "Expected node to be ready, but it has no Ready condition"
+	}
+
+	return format.Message(matcher.condition, "to report the node as ready")
+}
+
+func (matcher *nodeReadyMatcher) NegatedFailureMessage(_ interface{}) string {
+	return format.Message(matcher.condition, "not to report the node as ready")
+}
+
+// HaveTaint succeeds if the .spec.taints of the actual Node contain a taint
+// with the given key and effect. An empty effect matches any effect.
+func HaveTaint(key string, effect corev1.TaintEffect) types.GomegaMatcher {
+	return &taintMatcher{
+		key:    key,
+		effect: effect,
+	}
+}
+
+var _ types.GomegaMatcher = &taintMatcher{}
+
+type taintMatcher struct {
+	key    string
+	effect corev1.TaintEffect
+	taints []string
+}
+
+func (matcher *taintMatcher) Match(actual interface{}) (bool, error) {
+	obj, err := toObject(actual)
+	if err != nil {
+		return false, err
+	}
+
+	taints, _, err := unstructured.NestedSlice(obj, "spec", "taints")
+	if err != nil {
+		return false, fmt.Errorf("unable to read .spec.taints: %w", err)
+	}
+
+	matcher.taints = make([]string, 0, len(taints))
+	found := false
+
+	for i := range taints {
+		t, ok := taints[i].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		key, _, _ := unstructured.NestedString(t, "key")
+		effect, _, _ := unstructured.NestedString(t, "effect")
+
+		matcher.taints = append(matcher.taints, key+":"+effect)
+
+		if key == matcher.key && (matcher.effect == "" || effect == string(matcher.effect)) {
+			found = true
+		}
+	}
+
+	return found, nil
+}
+
+func (matcher *taintMatcher) FailureMessage(_ interface{}) string {
+	return format.Message(matcher.taints, "to contain taint", matcher.expected())
+}
+
+func (matcher *taintMatcher) NegatedFailureMessage(_ interface{}) string {
+	return format.Message(matcher.taints, "not to contain taint", matcher.expected())
+}
+
+func (matcher *taintMatcher) expected() string {
+	if matcher.effect == "" {
+		return matcher.key
+	}
+
+	return matcher.key + ":" + string(matcher.effect)
+}
@@ -0,0 +1,91 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNodeReady(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	node := func(status corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{
+					{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse},
+					{Type: corev1.NodeReady, Status: status},
+				},
+			},
+		}
+	}
+
+	g.Expect(node(corev1.ConditionTrue)).Should(k8s.NodeReady())
+	g.Expect(node(corev1.ConditionFalse)).ShouldNot(k8s.NodeReady())
+	g.Expect(node(corev1.ConditionUnknown)).ShouldNot(k8s.NodeReady())
+	g.Expect(&corev1.Node{}).ShouldNot(k8s.NodeReady())
+
+	m := k8s.NodeReady()
+	g.Expect(m.Match(&corev1.Node{})).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(ContainSubstring("it has no Ready condition"))
+}
+
+func TestHaveTaint(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	node := corev1.Node{
+		Spec: corev1.NodeSpec{
+			Taints: []corev1.Taint{
+				{Key: "node-role.kubernetes.io/control-plane", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoExecute},
+			},
+		},
+	}
+
+	g.Expect(&node).Should(k8s.HaveTaint("node-role.kubernetes.io/control-plane", corev1.TaintEffectNoSchedule))
+	g.Expect(&node).Should(k8s.HaveTaint("dedicated", ""))
+	g.Expect(&node).ShouldNot(k8s.HaveTaint("dedicated", corev1.TaintEffectNoSchedule))
+	g.Expect(&corev1.Node{}).ShouldNot(k8s.HaveTaint("dedicated", ""))
+
+	m := k8s.HaveTaint("dedicated", corev1.TaintEffectNoSchedule)
+	g.Expect(m.Match(&node)).Should(BeFalse())
+	g.Expect(m.FailureMessage(&node)).Should(And(
+		ContainSubstring("to contain taint"),
+		ContainSubstring("dedicated:NoSchedule"),
+		ContainSubstring("dedicated:NoExecute"),
+	))
+}
+
+func TestNodes(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "cp", Labels: map[string]string{"role": "control-plane"}}},
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "w1", Labels: map[string]string{"role": "worker"}}},
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "w2", Labels: map[string]string{"role": "worker"}}},
+		).
+		Build()
+
+	k := k8s.New(cli, scheme)
+
+	g.Expect(k.Nodes()(t.Context())).Should(k8s.HaveItems(3))
+	g.Expect(k.Nodes(k8s.MatchingLabels{"role": "worker"})(t.Context())).Should(k8s.HaveItems(2))
+}
@@ -0,0 +1,51 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// IgnoreNotFound decorates a function, i.e. UnstructuredMatcher.Delete, so
+// that it succeeds when the object it operates on does not exist, which makes
+// cleanup idempotent:
+//
+//	Expect(k8s.IgnoreNotFound(u.Delete("v1/ConfigMap", key))(ctx)).To(Succeed())
+func IgnoreNotFound(fn func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if err := fn(ctx); err != nil && !isNotFound(err) {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// OnlyNotFound decorates a pollable function, i.e. UnstructuredMatcher.Get,
+// so that it only succeeds once the object it fetches does not exist, which
+// is what waiting for a deletion is about:
+//
+//	Eventually(k8s.OnlyNotFound(u.Get("v1/ConfigMap", key))).
+//	    WithContext(ctx).
+//	    Should(Succeed())
+//
+// Errors other than NotFound are returned as they are.
+func OnlyNotFound[T any](fn func(ctx context.Context) (T, error)) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := fn(ctx)
+
+		switch {
+		case err == nil:
+			return errors.New("expected the object not to be found, but it exists")
+		case isNotFound(err):
+			return nil
+		default:
+			return err
+		}
+	}
+}
+
+func isNotFound(err error) bool {
+	return errors.Is(err, &NotFoundError{}) || apierrors.IsNotFound(err)
+}
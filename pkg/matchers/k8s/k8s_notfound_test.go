@@ -0,0 +1,81 @@
+package k8s_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestIgnoreNotFound(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"},
+	}
+
+	k := k8s.New(fake.NewClientBuilder().WithScheme(scheme).WithObjects(cm).Build(), scheme)
+	u := k.Unstructured()
+	key := client.ObjectKeyFromObject(cm)
+
+	del := k8s.IgnoreNotFound(u.Delete("v1/ConfigMap", key))
+
+	g.Expect(del(t.Context())).Should(Succeed())
+	g.Expect(del(t.Context())).Should(Succeed())
+	g.Expect(u.Delete("v1/ConfigMap", key)(t.Context())).ShouldNot(Succeed())
+
+	failing := k8s.IgnoreNotFound(func(context.Context) error {
+		return errors.New("boom")
+	})
+
+	g.Expect(failing(t.Context())).Should(MatchError("boom"))
+}
+
+func TestOnlyNotFound(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"},
+	}
+
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cm).Build()
+	k := k8s.New(cli, scheme)
+
+	gone := k8s.OnlyNotFound(k.Unstructured().Get("v1/ConfigMap", client.ObjectKeyFromObject(cm)))
+
+	g.Expect(gone(t.Context())).Should(MatchError(ContainSubstring("expected the object not to be found")))
+
+	g.Expect(cli.Delete(t.Context(), cm)).Should(Succeed())
+	g.Eventually(gone).WithContext(t.Context()).Should(Succeed())
+
+	// raw API errors are recognized as well
+	raw := k8s.OnlyNotFound(func(context.Context) (any, error) {
+		return nil, apierrors.NewNotFound(corev1.Resource("configmaps"), "cm")
+	})
+	g.Expect(raw(t.Context())).Should(Succeed())
+
+	failing := k8s.OnlyNotFound(func(context.Context) (any, error) {
+		return nil, apierrors.NewForbidden(corev1.Resource("configmaps"), "cm", errors.New("denied"))
+	})
+	g.Expect(failing(t.Context())).Should(MatchError(apierrors.IsForbidden, "IsForbidden"))
+}
@@ -0,0 +1,82 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// HavePhase succeeds if the .status.phase of the actual object equals the
+// expected one. It works with core kinds reporting a phase (i.e. Pod,
+// Namespace, PersistentVolume, PersistentVolumeClaim) as well as with any
+// custom resource following the same convention.
+func HavePhase(expected string) *PhaseMatcher {
+	return &PhaseMatcher{
+		expected: expected,
+	}
+}
+
+var _ types.GomegaMatcher = &PhaseMatcher{}
+
+// PhaseMatcher is the matcher returned by HavePhase.
+type PhaseMatcher struct {
+	expected   string
+	ignoreCase bool
+	kind       string
+	phase      string
+	found      bool
+}
+
+// IgnoringCase makes the comparison case-insensitive.
+func (matcher *PhaseMatcher) IgnoringCase() *PhaseMatcher {
+	matcher.ignoreCase = true
+
+	return matcher
+}
+
+func (matcher *PhaseMatcher) Match(actual interface{}) (bool, error) {
+	obj, err := toObject(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.kind, _, _ = unstructured.NestedString(obj, "kind")
+
+	matcher.phase, matcher.found, err = unstructured.NestedString(obj, "status", "phase")
+	if err != nil {
+		return false, fmt.Errorf("unable to read .status.phase: %w", err)
+	}
+
+	if !matcher.found {
+		return false, nil
+	}
+
+	if matcher.ignoreCase {
+		return strings.EqualFold(matcher.phase, matcher.expected), nil
+	}
+
+	return matcher.phase == matcher.expected, nil
+}
+
+func (matcher *PhaseMatcher) FailureMessage(_ interface{}) string {
+	if !matcher.found {
+		return fmt.Sprintf("Expected %s to have phase %q, but it has no .status.phase", matcher.subject(), matcher.expected)
+	}
+
+	return format.Message(matcher.phase, fmt.Sprintf("to be the phase of %s, equal to", matcher.subject()), matcher.expected)
+}
+
+func (matcher *PhaseMatcher) NegatedFailureMessage(_ interface{}) string {
+	return format.Message(matcher.phase, fmt.Sprintf("not to be the phase of %s, equal to", matcher.subject()), matcher.expected)
+}
+
+func (matcher *PhaseMatcher) subject() string {
+	if matcher.kind == "" {
+		return "object"
+	}
+
+	return matcher.kind
+}
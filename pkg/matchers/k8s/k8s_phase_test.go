@@ -0,0 +1,49 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestHavePhase(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	pod := corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodRunning}}
+
+	g.Expect(&pod).Should(k8s.HavePhase("Running"))
+	g.Expect(&pod).ShouldNot(k8s.HavePhase("running"))
+	g.Expect(&pod).Should(k8s.HavePhase("running").IgnoringCase())
+
+	g.Expect(&corev1.Namespace{Status: corev1.NamespaceStatus{Phase: corev1.NamespaceTerminating}}).
+		Should(k8s.HavePhase("Terminating"))
+	g.Expect(&corev1.PersistentVolumeClaim{Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound}}).
+		Should(k8s.HavePhase("Bound"))
+
+	cr := unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "example.com/v1",
+		"kind":       "Foo",
+		"status":     map[string]any{"phase": "Ready"},
+	}}
+
+	g.Expect(cr).Should(k8s.HavePhase("Ready"))
+	g.Expect(`{"kind":"Foo","status":{"phase":"Ready"}}`).Should(k8s.HavePhase("Ready"))
+
+	m := k8s.HavePhase("Ready")
+	g.Expect(m.Match(`{"kind":"Foo","status":{}}`)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(Equal(`Expected Foo to have phase "Ready", but it has no .status.phase`))
+
+	m = k8s.HavePhase("Ready")
+	g.Expect(m.Match(`{"kind":"Foo","status":{"phase":"Pending"}}`)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(ContainSubstring("to be the phase of Foo"))
+	g.Expect(m.FailureMessage(nil)).Should(ContainSubstring("Pending"))
+
+	_, err := k8s.HavePhase("Ready").Match(`{"status":{"phase":1}}`)
+	g.Expect(err).Should(HaveOccurred())
+}
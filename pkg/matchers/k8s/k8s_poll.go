@@ -0,0 +1,39 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+)
+
+// Transform converts a value, i.e. jq.Extract, yq.Extract or jq.FromTOML.
+type Transform func(in any) (any, error)
+
+// Poll wraps a pollable function with a chain of transforms, applied in
+// order to its result, producing a single pollable function that can be fed
+// to Eventually/Consistently, i.e.:
+//
+//	Eventually(k8s.Poll(u.Get("deploy", key), jq.Extract(`.status`))).
+//	    WithContext(ctx).
+//	    Should(jq.Match(`.readyReplicas == 3`))
+//
+// rather than nesting WithTransform. Errors returned by the function or by
+// any of the transforms are returned, so that Eventually keeps polling.
+func Poll[T any](fn func(ctx context.Context) (T, error), transforms ...Transform) func(ctx context.Context) (any, error) {
+	return func(ctx context.Context) (any, error) {
+		v, err := fn(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var result any = v
+
+		for i, t := range transforms {
+			result, err = t(result)
+			if err != nil {
+				return nil, fmt.Errorf("failure applying transform %d: %w", i, err)
+			}
+		}
+
+		return result, nil
+	}
+}
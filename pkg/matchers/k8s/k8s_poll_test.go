@@ -0,0 +1,55 @@
+package k8s_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestPoll(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"},
+			Data:       map[string]string{"config": `{"replicas": 3}`},
+		}).
+		Build()
+
+	u := k8s.New(cli, scheme).Unstructured()
+	key := client.ObjectKey{Namespace: "ns", Name: "cm"}
+
+	g.Eventually(k8s.Poll(u.Get("v1/ConfigMap", key), jq.Extract(`.data.config`), jq.Extract(`.replicas`))).
+		WithContext(t.Context()).
+		Should(BeNumerically("==", 3))
+
+	g.Expect(k8s.Poll(u.Get("v1/ConfigMap", key))(t.Context())).
+		Should(jq.Match(`.metadata.name == "cm"`))
+
+	_, err := k8s.Poll(u.Get("v1/ConfigMap", key), jq.Extract(`.data | }`))(t.Context())
+	g.Expect(err).Should(MatchError(ContainSubstring("failure applying transform 0")))
+
+	failing := func(_ context.Context) (string, error) {
+		return "", errors.New("boom")
+	}
+
+	_, err = k8s.Poll(failing, jq.Extract(`.`))(t.Context())
+	g.Expect(err).Should(MatchError("boom"))
+}
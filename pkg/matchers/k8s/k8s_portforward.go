@@ -0,0 +1,193 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	portForwardAddress = "127.0.0.1"
+)
+
+// PortForward establishes a port-forward to the given port of a Pod or of a
+// Service, in which case the port is resolved against the service ports and
+// traffic is forwarded to one of the running pods backing the service. The
+// target is identified by the name and namespace of the given object, which
+// must be either a *corev1.Pod or a *corev1.Service.
+//
+// It returns the local address (host:port) the forward is listening on and a
+// function to tear the forward down. The forward is also torn down when the
+// given context is done.
+func PortForward(ctx context.Context, cfg *rest.Config, obj client.Object, port int) (string, func(), error) {
+	cs, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to create clientset: %w", err)
+	}
+
+	pod, targetPort, err := resolvePortForwardTarget(ctx, cs, obj, port)
+	if err != nil {
+		return "", nil, err
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(cfg)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to create round tripper: %w", err)
+	}
+
+	u := cs.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("portforward").
+		URL()
+
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, u)
+
+	stopCh := make(chan struct{})
+	readyCh := make(chan struct{})
+	errCh := make(chan error, 1)
+
+	once := sync.Once{}
+	cleanup := func() {
+		once.Do(func() { close(stopCh) })
+	}
+
+	fw, err := portforward.NewOnAddresses(
+		dialer,
+		[]string{portForwardAddress},
+		[]string{fmt.Sprintf("0:%d", targetPort)},
+		stopCh,
+		readyCh,
+		io.Discard,
+		io.Discard,
+	)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to create port forward: %w", err)
+	}
+
+	go func() {
+		errCh <- fw.ForwardPorts()
+	}()
+
+	select {
+	case <-readyCh:
+	case err := <-errCh:
+		return "", nil, fmt.Errorf("unable to forward ports: %w", err)
+	case <-ctx.Done():
+		cleanup()
+
+		return "", nil, fmt.Errorf("unable to forward ports: %w", ctx.Err())
+	}
+
+	ports, err := fw.GetPorts()
+	if err != nil {
+		cleanup()
+
+		return "", nil, fmt.Errorf("unable to retrieve forwarded ports: %w", err)
+	}
+
+	if len(ports) == 0 {
+		cleanup()
+
+		return "", nil, errors.New("no port has been forwarded")
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			cleanup()
+		case <-stopCh:
+		}
+	}()
+
+	return net.JoinHostPort(portForwardAddress, strconv.Itoa(int(ports[0].Local))), cleanup, nil
+}
+
+func resolvePortForwardTarget(ctx context.Context, cs kubernetes.Interface, obj client.Object, port int) (*corev1.Pod, int, error) {
+	switch obj.(type) {
+	case *corev1.Pod:
+		pod, err := cs.CoreV1().Pods(obj.GetNamespace()).Get(ctx, obj.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return nil, 0, fmt.Errorf("unable to get pod %s/%s: %w", obj.GetNamespace(), obj.GetName(), err)
+		}
+
+		return pod, port, nil
+	case *corev1.Service:
+		svc, err := cs.CoreV1().Services(obj.GetNamespace()).Get(ctx, obj.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return nil, 0, fmt.Errorf("unable to get service %s/%s: %w", obj.GetNamespace(), obj.GetName(), err)
+		}
+
+		if len(svc.Spec.Selector) == 0 {
+			return nil, 0, fmt.Errorf("service %s/%s has no selector", svc.Namespace, svc.Name)
+		}
+
+		pods, err := cs.CoreV1().Pods(svc.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String(),
+		})
+		if err != nil {
+			return nil, 0, fmt.Errorf("unable to list pods for service %s/%s: %w", svc.Namespace, svc.Name, err)
+		}
+
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+				continue
+			}
+
+			targetPort, err := resolveServiceTargetPort(svc, pod, port)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			return pod, targetPort, nil
+		}
+
+		return nil, 0, fmt.Errorf("no running pod found for service %s/%s", svc.Namespace, svc.Name)
+	default:
+		return nil, 0, fmt.Errorf("unsupported type %T, only *corev1.Pod and *corev1.Service are supported", obj)
+	}
+}
+
+func resolveServiceTargetPort(svc *corev1.Service, pod *corev1.Pod, port int) (int, error) {
+	for _, sp := range svc.Spec.Ports {
+		if int(sp.Port) != port {
+			continue
+		}
+
+		switch {
+		case sp.TargetPort.Type == intstr.Int && sp.TargetPort.IntValue() == 0:
+			return port, nil
+		case sp.TargetPort.Type == intstr.Int:
+			return sp.TargetPort.IntValue(), nil
+		}
+
+		for _, c := range pod.Spec.Containers {
+			for _, cp := range c.Ports {
+				if cp.Name == sp.TargetPort.StrVal {
+					return int(cp.ContainerPort), nil
+				}
+			}
+		}
+
+		return 0, fmt.Errorf("unable to find container port %q in pod %s/%s", sp.TargetPort.StrVal, pod.Namespace, pod.Name)
+	}
+
+	return 0, fmt.Errorf("service %s/%s does not expose port %d", svc.Namespace, svc.Name, port)
+}
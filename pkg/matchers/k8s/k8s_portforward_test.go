@@ -0,0 +1,75 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestResolvePortForwardTarget(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	pending := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-0", Namespace: "ns", Labels: map[string]string{"app": "foo"}},
+		Status:     corev1.PodStatus{Phase: corev1.PodPending},
+	}
+
+	running := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-1", Namespace: "ns", Labels: map[string]string{"app": "foo"}},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  "app",
+				Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "foo"},
+			Ports: []corev1.ServicePort{
+				{Port: 80, TargetPort: intstr.FromString("http")},
+				{Port: 443, TargetPort: intstr.FromInt32(8443)},
+				{Port: 9090},
+			},
+		},
+	}
+
+	cs := fake.NewClientset(pending, running, svc)
+
+	pod, port, err := k8s.ResolvePortForwardTarget(t.Context(), cs, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "app-0", Namespace: "ns"}}, 1234)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(pod.Name).Should(Equal("app-0"))
+	g.Expect(port).Should(Equal(1234))
+
+	key := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"}}
+
+	pod, port, err = k8s.ResolvePortForwardTarget(t.Context(), cs, key, 80)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(pod.Name).Should(Equal("app-1"))
+	g.Expect(port).Should(Equal(8080))
+
+	_, port, err = k8s.ResolvePortForwardTarget(t.Context(), cs, key, 443)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(port).Should(Equal(8443))
+
+	_, port, err = k8s.ResolvePortForwardTarget(t.Context(), cs, key, 9090)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(port).Should(Equal(9090))
+
+	_, _, err = k8s.ResolvePortForwardTarget(t.Context(), cs, key, 8888)
+	g.Expect(err).Should(HaveOccurred())
+
+	_, _, err = k8s.ResolvePortForwardTarget(t.Context(), cs, &corev1.ConfigMap{}, 80)
+	g.Expect(err).Should(HaveOccurred())
+}
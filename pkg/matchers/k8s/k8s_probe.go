@@ -0,0 +1,235 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// defaults applied by the API server to unset probe fields
+	defaultProbePeriodSeconds = 10
+)
+
+// HaveReadinessProbe succeeds if a container (or init container) of the pod
+// spec of the actual object defines a readiness probe matching the given
+// matcher, which receives the probe as a map[string]any, i.e.:
+//
+//	Expect(deployment).Should(k8s.HaveReadinessProbe(jq.Match(`.httpGet.path == "/readyz"`)))
+//
+// A nil matcher only requires the probe to be defined. It works with Pods and
+// any kind embedding a PodTemplateSpec.
+func HaveReadinessProbe(matcher types.GomegaMatcher) *ProbeMatcher {
+	return newProbeMatcher("readinessProbe", "readiness", matcher)
+}
+
+// HaveLivenessProbe is like HaveReadinessProbe, for liveness probes.
+func HaveLivenessProbe(matcher types.GomegaMatcher) *ProbeMatcher {
+	return newProbeMatcher("livenessProbe", "liveness", matcher)
+}
+
+// HaveStartupProbe is like HaveReadinessProbe, for startup probes.
+func HaveStartupProbe(matcher types.GomegaMatcher) *ProbeMatcher {
+	return newProbeMatcher("startupProbe", "startup", matcher)
+}
+
+func newProbeMatcher(field string, kind string, matcher types.GomegaMatcher) *ProbeMatcher {
+	return &ProbeMatcher{
+		field:   field,
+		kind:    kind,
+		matcher: matcher,
+	}
+}
+
+var _ types.GomegaMatcher = &ProbeMatcher{}
+
+// ProbeMatcher is the matcher returned by HaveReadinessProbe,
+// HaveLivenessProbe and HaveStartupProbe.
+type ProbeMatcher struct {
+	field     string
+	kind      string
+	matcher   types.GomegaMatcher
+	container string
+
+	failure string
+}
+
+// InContainer only considers the container (or init container) with the
+// given name, rather than any container of the pod spec.
+func (matcher *ProbeMatcher) InContainer(name string) *ProbeMatcher {
+	matcher.container = name
+
+	return matcher
+}
+
+func (matcher *ProbeMatcher) Match(actual interface{}) (bool, error) {
+	spec, err := toPodSpec(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.failure = ""
+
+	var containers []string
+
+	for _, field := range []string{"containers", "initContainers"} {
+		items, _ := spec[field].([]any)
+
+		for i := range items {
+			c, ok := items[i].(map[string]any)
+			if !ok {
+				continue
+			}
+
+			n, _ := c["name"].(string)
+			if matcher.container != "" && n != matcher.container {
+				continue
+			}
+
+			containers = append(containers, n)
+
+			probe, ok := c[matcher.field].(map[string]any)
+			if !ok {
+				continue
+			}
+
+			if matcher.matcher == nil {
+				return true, nil
+			}
+
+			ok, err := matcher.matcher.Match(probe)
+			if err != nil {
+				return false, err
+			}
+
+			if ok {
+				return true, nil
+			}
+
+			if matcher.failure == "" {
+				matcher.failure = fmt.Sprintf("Expected %s probe of container %s to match:\n%s", matcher.kind, n, matcher.matcher.FailureMessage(probe))
+			}
+		}
+	}
+
+	if matcher.failure != "" {
+		return false, nil
+	}
+
+	switch {
+	case matcher.container != "" && len(containers) == 0:
+		matcher.failure = fmt.Sprintf("Expected container %s to define a %s probe, but there is no such container", matcher.container, matcher.kind)
+	case matcher.container != "":
+		matcher.failure = fmt.Sprintf("Expected container %s to define a %s probe", matcher.container, matcher.kind)
+	default:
+		matcher.failure = format.Message(containers, fmt.Sprintf("to define a %s probe in any container", matcher.kind))
+	}
+
+	return false, nil
+}
+
+func (matcher *ProbeMatcher) FailureMessage(_ interface{}) string {
+	return matcher.failure
+}
+
+func (matcher *ProbeMatcher) NegatedFailureMessage(_ interface{}) string {
+	msg := fmt.Sprintf("Expected not to define a %s probe", matcher.kind)
+
+	if matcher.container != "" {
+		msg += " in container " + matcher.container
+	}
+
+	if matcher.matcher != nil {
+		msg += " matching the given matcher"
+	}
+
+	return msg
+}
+
+// ProbeWithin succeeds if the actual probe, i.e. the one passed by
+// HaveReadinessProbe, starts within the given initial delay and is run at
+// least once per the given period, the API server defaults being taken into
+// account for unset fields. It guards against generated manifests delaying
+// rollouts or the detection of failures:
+//
+//	Expect(deployment).Should(k8s.HaveLivenessProbe(k8s.ProbeWithin(30*time.Second, 10*time.Second)))
+func ProbeWithin(initialDelay time.Duration, period time.Duration) types.GomegaMatcher {
+	return &probeWithinMatcher{
+		initialDelay: initialDelay,
+		period:       period,
+	}
+}
+
+var _ types.GomegaMatcher = &probeWithinMatcher{}
+
+type probeWithinMatcher struct {
+	initialDelay time.Duration
+	period       time.Duration
+
+	violations []string
+}
+
+func (matcher *probeWithinMatcher) Match(actual interface{}) (bool, error) {
+	probe, err := toProbe(actual)
+	if err != nil {
+		return false, err
+	}
+
+	initialDelay := time.Duration(probe.InitialDelaySeconds) * time.Second
+
+	period := time.Duration(probe.PeriodSeconds) * time.Second
+	if period == 0 {
+		period = defaultProbePeriodSeconds * time.Second
+	}
+
+	matcher.violations = nil
+
+	if initialDelay > matcher.initialDelay {
+		matcher.violations = append(matcher.violations, fmt.Sprintf("initialDelaySeconds is %s, more than %s", initialDelay, matcher.initialDelay))
+	}
+
+	if period > matcher.period {
+		matcher.violations = append(matcher.violations, fmt.Sprintf("periodSeconds is %s, more than %s", period, matcher.period))
+	}
+
+	return len(matcher.violations) == 0, nil
+}
+
+func (matcher *probeWithinMatcher) FailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected probe to start within %s and to run at least every %s, but %s",
+		matcher.initialDelay, matcher.period, strings.Join(matcher.violations, " and "))
+}
+
+func (matcher *probeWithinMatcher) NegatedFailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected probe not to start within %s and to run at least every %s", matcher.initialDelay, matcher.period)
+}
+
+// toProbe converts the given value, i.e. a probe in unstructured form, to a
+// typed Probe.
+func toProbe(in any) (*corev1.Probe, error) {
+	switch v := in.(type) {
+	case *corev1.Probe:
+		if v == nil {
+			return nil, errors.New("a probe is expected, got nil")
+		}
+
+		return v, nil
+	case corev1.Probe:
+		return &v, nil
+	case map[string]any:
+		var probe corev1.Probe
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(v, &probe); err != nil {
+			return nil, &ConversionError{From: "unstructured", To: "Probe", Err: err}
+		}
+
+		return &probe, nil
+	default:
+		return nil, fmt.Errorf("a probe is expected, got:\n%s", format.Object(in, 1))
+	}
+}
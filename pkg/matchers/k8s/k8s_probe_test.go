@@ -0,0 +1,104 @@
+package k8s_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	. "github.com/onsi/gomega"
+)
+
+func newProbeDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "app",
+					ReadinessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							HTTPGet: &corev1.HTTPGetAction{Path: "/readyz", Port: intstr.FromInt32(8080)},
+						},
+						PeriodSeconds: 5,
+					},
+					LivenessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt32(8080)},
+						},
+						InitialDelaySeconds: 60,
+					},
+				},
+				{
+					Name: "sidecar",
+					ReadinessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(15000)},
+						},
+					},
+				},
+			},
+		}}},
+	}
+}
+
+func TestHaveProbe(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	d := newProbeDeployment()
+
+	g.Expect(d).Should(k8s.HaveReadinessProbe(nil))
+	g.Expect(d).Should(k8s.HaveReadinessProbe(HaveKey("tcpSocket")))
+	g.Expect(d).Should(k8s.HaveReadinessProbe(jq.Match(`.httpGet.path == "/readyz"`)).InContainer("app"))
+	g.Expect(d).Should(k8s.HaveLivenessProbe(jq.Match(`.httpGet.path == "/healthz"`)))
+
+	g.Expect(d).ShouldNot(k8s.HaveStartupProbe(nil))
+	g.Expect(d).ShouldNot(k8s.HaveLivenessProbe(nil).InContainer("sidecar"))
+	g.Expect(d).ShouldNot(k8s.HaveReadinessProbe(HaveKey("tcpSocket")).InContainer("app"))
+
+	g.Expect(d).Should(k8s.HaveReadinessProbe(k8s.ProbeWithin(0, 5*time.Second)).InContainer("app"))
+	g.Expect(d).Should(k8s.HaveReadinessProbe(k8s.ProbeWithin(0, 10*time.Second)).InContainer("sidecar"))
+	g.Expect(d).ShouldNot(k8s.HaveReadinessProbe(k8s.ProbeWithin(0, 5*time.Second)).InContainer("sidecar"))
+	g.Expect(d).ShouldNot(k8s.HaveLivenessProbe(k8s.ProbeWithin(30*time.Second, 10*time.Second)))
+
+	g.Expect(&corev1.Probe{PeriodSeconds: 1}).Should(k8s.ProbeWithin(0, time.Second))
+
+	_, err := k8s.ProbeWithin(0, time.Second).Match("probe")
+	g.Expect(err).Should(MatchError(ContainSubstring("a probe is expected")))
+}
+
+func TestHaveProbeFailureMessage(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	d := newProbeDeployment()
+
+	m := k8s.HaveStartupProbe(nil)
+	g.Expect(m.Match(d)).Should(BeFalse())
+	g.Expect(m.FailureMessage(d)).Should(And(
+		ContainSubstring("to define a startup probe in any container"),
+		ContainSubstring("sidecar"),
+	))
+
+	m = k8s.HaveLivenessProbe(nil).InContainer("sidecar")
+	g.Expect(m.Match(d)).Should(BeFalse())
+	g.Expect(m.FailureMessage(d)).Should(Equal("Expected container sidecar to define a liveness probe"))
+
+	m = k8s.HaveLivenessProbe(nil).InContainer("proxy")
+	g.Expect(m.Match(d)).Should(BeFalse())
+	g.Expect(m.FailureMessage(d)).Should(ContainSubstring("there is no such container"))
+
+	m = k8s.HaveLivenessProbe(k8s.ProbeWithin(30*time.Second, 5*time.Second))
+	g.Expect(m.Match(d)).Should(BeFalse())
+	g.Expect(m.FailureMessage(d)).Should(Equal("Expected liveness probe of container app to match:\n" +
+		"Expected probe to start within 30s and to run at least every 5s, but initialDelaySeconds is 1m0s, more than 30s and periodSeconds is 10s, more than 5s"))
+
+	m = k8s.HaveReadinessProbe(nil).InContainer("app")
+	g.Expect(m.NegatedFailureMessage(d)).Should(Equal("Expected not to define a readiness probe in container app"))
+}
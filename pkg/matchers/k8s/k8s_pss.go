@@ -0,0 +1,461 @@
+package k8s
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// BeRestrictedPSSCompliant succeeds if the pod spec of the actual object
+// complies with the "restricted" Pod Security Standard, which includes the
+// "baseline" one, i.e. it runs as non root, with privilege escalation
+// disabled, all capabilities dropped and the RuntimeDefault seccomp profile.
+// Each violation is listed in the failure message, in the same terms as the
+// PodSecurity admission controller. It works with Pods and any kind embedding
+// a PodTemplateSpec:
+//
+//	Expect(deployment).Should(k8s.BeRestrictedPSSCompliant())
+//
+// Only the pod spec is evaluated, AppArmor profiles set through annotations
+// are not taken into account.
+func BeRestrictedPSSCompliant() types.GomegaMatcher {
+	return &pssMatcher{}
+}
+
+var _ types.GomegaMatcher = &pssMatcher{}
+
+type pssMatcher struct {
+	violations []string
+}
+
+func (matcher *pssMatcher) Match(actual interface{}) (bool, error) {
+	in, err := toPodSpec(actual)
+	if err != nil {
+		return false, err
+	}
+
+	var spec corev1.PodSpec
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(in, &spec); err != nil {
+		return false, &ConversionError{From: "unstructured", To: "PodSpec", Err: err}
+	}
+
+	matcher.violations = nil
+
+	for _, check := range restrictedChecks {
+		matcher.violations = append(matcher.violations, check(&spec, in)...)
+	}
+
+	return len(matcher.violations) == 0, nil
+}
+
+func (matcher *pssMatcher) FailureMessage(_ interface{}) string {
+	return "Expected pod spec to comply with the restricted Pod Security Standard, but:\n  - " + strings.Join(matcher.violations, "\n  - ")
+}
+
+func (matcher *pssMatcher) NegatedFailureMessage(_ interface{}) string {
+	return "Expected pod spec not to comply with the restricted Pod Security Standard"
+}
+
+// pssContainer is the part of containers, init containers and ephemeral
+// containers the Pod Security Standards are about.
+type pssContainer struct {
+	name  string
+	sc    *corev1.SecurityContext
+	ports []corev1.ContainerPort
+}
+
+func pssContainers(spec *corev1.PodSpec) []pssContainer {
+	var containers []pssContainer
+
+	for _, c := range slices.Concat(spec.InitContainers, spec.Containers) {
+		containers = append(containers, pssContainer{name: c.Name, sc: c.SecurityContext, ports: c.Ports})
+	}
+
+	for _, c := range spec.EphemeralContainers {
+		containers = append(containers, pssContainer{name: c.Name, sc: c.SecurityContext, ports: c.Ports})
+	}
+
+	return containers
+}
+
+// pssCheck returns the violations of a Pod Security Standards rule, the pod
+// spec is also given in unstructured form for rules about the presence of
+// fields.
+type pssCheck func(spec *corev1.PodSpec, in map[string]any) []string
+
+//nolint:gochecknoglobals
+var restrictedChecks = []pssCheck{
+	checkHostNamespaces,
+	checkPrivileged,
+	checkHostPathVolumes,
+	checkHostPorts,
+	checkAppArmor,
+	checkSELinux,
+	checkProcMount,
+	checkSysctls,
+	checkHostProcess,
+	checkVolumeTypes,
+	checkAllowPrivilegeEscalation,
+	checkRunAsNonRoot,
+	checkRunAsUser,
+	checkSeccomp,
+	checkCapabilities,
+}
+
+//nolint:gochecknoglobals
+var (
+	// sysctls allowed by the baseline profile
+	safeSysctls = []string{
+		"kernel.shm_rmid_forced",
+		"net.ipv4.ip_local_port_range",
+		"net.ipv4.ip_unprivileged_port_start",
+		"net.ipv4.tcp_syncookies",
+		"net.ipv4.ping_group_range",
+		"net.ipv4.ip_local_reserved_ports",
+		"net.ipv4.tcp_keepalive_time",
+		"net.ipv4.tcp_fin_timeout",
+		"net.ipv4.tcp_keepalive_intvl",
+		"net.ipv4.tcp_keepalive_probes",
+	}
+
+	// SELinux types allowed by the baseline profile
+	safeSELinuxTypes = []string{"", "container_t", "container_init_t", "container_kvm_t", "container_engine_t"}
+
+	// volume types allowed by the restricted profile
+	safeVolumeTypes = []string{"configMap", "csi", "downwardAPI", "emptyDir", "ephemeral", "persistentVolumeClaim", "projected", "secret"}
+)
+
+func checkHostNamespaces(spec *corev1.PodSpec, _ map[string]any) []string {
+	var fields []string
+
+	if spec.HostNetwork {
+		fields = append(fields, "hostNetwork=true")
+	}
+
+	if spec.HostPID {
+		fields = append(fields, "hostPID=true")
+	}
+
+	if spec.HostIPC {
+		fields = append(fields, "hostIPC=true")
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return []string{fmt.Sprintf("host namespaces (%s)", strings.Join(fields, ", "))}
+}
+
+func checkPrivileged(spec *corev1.PodSpec, _ map[string]any) []string {
+	var names []string
+
+	for _, c := range pssContainers(spec) {
+		if c.sc != nil && c.sc.Privileged != nil && *c.sc.Privileged {
+			names = append(names, c.name)
+		}
+	}
+
+	return violation("privileged", names, "must not set securityContext.privileged=true")
+}
+
+func checkHostPathVolumes(spec *corev1.PodSpec, _ map[string]any) []string {
+	var names []string
+
+	for _, v := range spec.Volumes {
+		if v.HostPath != nil {
+			names = append(names, v.Name)
+		}
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	return []string{fmt.Sprintf("hostPath volumes (volumes %s)", quoted(names))}
+}
+
+func checkHostPorts(spec *corev1.PodSpec, _ map[string]any) []string {
+	var names []string
+
+	for _, c := range pssContainers(spec) {
+		if slices.ContainsFunc(c.ports, func(p corev1.ContainerPort) bool { return p.HostPort != 0 }) {
+			names = append(names, c.name)
+		}
+	}
+
+	return violation("hostPort", names, "must not use hostPort")
+}
+
+func checkAppArmor(spec *corev1.PodSpec, _ map[string]any) []string {
+	allowed := func(p *corev1.AppArmorProfile) bool {
+		return p == nil || p.Type == corev1.AppArmorProfileTypeRuntimeDefault || p.Type == corev1.AppArmorProfileTypeLocalhost
+	}
+
+	var violations []string
+
+	if spec.SecurityContext != nil && !allowed(spec.SecurityContext.AppArmorProfile) {
+		violations = append(violations, fmt.Sprintf("forbidden AppArmor profile (pod must not set securityContext.appArmorProfile.type=%s)", spec.SecurityContext.AppArmorProfile.Type))
+	}
+
+	var names []string
+
+	for _, c := range pssContainers(spec) {
+		if c.sc != nil && !allowed(c.sc.AppArmorProfile) {
+			names = append(names, c.name)
+		}
+	}
+
+	return append(violations, violation("forbidden AppArmor profile", names, "must not set securityContext.appArmorProfile.type to a value other than RuntimeDefault or Localhost")...)
+}
+
+func checkSELinux(spec *corev1.PodSpec, _ map[string]any) []string {
+	allowed := func(o *corev1.SELinuxOptions) bool {
+		return o == nil || (slices.Contains(safeSELinuxTypes, o.Type) && o.User == "" && o.Role == "")
+	}
+
+	var violations []string
+
+	if spec.SecurityContext != nil && !allowed(spec.SecurityContext.SELinuxOptions) {
+		violations = append(violations, "seLinuxOptions (pod must not set securityContext.seLinuxOptions user, role or a custom type)")
+	}
+
+	var names []string
+
+	for _, c := range pssContainers(spec) {
+		if c.sc != nil && !allowed(c.sc.SELinuxOptions) {
+			names = append(names, c.name)
+		}
+	}
+
+	return append(violations, violation("seLinuxOptions", names, "must not set securityContext.seLinuxOptions user, role or a custom type")...)
+}
+
+func checkProcMount(spec *corev1.PodSpec, _ map[string]any) []string {
+	var names []string
+
+	for _, c := range pssContainers(spec) {
+		if c.sc != nil && c.sc.ProcMount != nil && *c.sc.ProcMount != corev1.DefaultProcMount {
+			names = append(names, c.name)
+		}
+	}
+
+	return violation("procMount", names, "must not set securityContext.procMount")
+}
+
+func checkSysctls(spec *corev1.PodSpec, _ map[string]any) []string {
+	if spec.SecurityContext == nil {
+		return nil
+	}
+
+	var names []string
+
+	for _, s := range spec.SecurityContext.Sysctls {
+		if !slices.Contains(safeSysctls, s.Name) {
+			names = append(names, s.Name)
+		}
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	return []string{fmt.Sprintf("forbidden sysctls (%s)", strings.Join(names, ", "))}
+}
+
+func checkHostProcess(spec *corev1.PodSpec, _ map[string]any) []string {
+	hostProcess := func(o *corev1.WindowsSecurityContextOptions) bool {
+		return o != nil && o.HostProcess != nil && *o.HostProcess
+	}
+
+	var violations []string
+
+	if spec.SecurityContext != nil && hostProcess(spec.SecurityContext.WindowsOptions) {
+		violations = append(violations, "hostProcess (pod must not set securityContext.windowsOptions.hostProcess=true)")
+	}
+
+	var names []string
+
+	for _, c := range pssContainers(spec) {
+		if c.sc != nil && hostProcess(c.sc.WindowsOptions) {
+			names = append(names, c.name)
+		}
+	}
+
+	return append(violations, violation("hostProcess", names, "must not set securityContext.windowsOptions.hostProcess=true")...)
+}
+
+func checkVolumeTypes(_ *corev1.PodSpec, in map[string]any) []string {
+	volumes, _ := in["volumes"].([]any)
+
+	var found []string
+
+	for i := range volumes {
+		v, ok := volumes[i].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		for k := range v {
+			// host paths are already reported by the baseline rule
+			if k == "name" || k == "hostPath" || slices.Contains(safeVolumeTypes, k) {
+				continue
+			}
+
+			found = append(found, fmt.Sprintf("%q (%s)", v["name"], k))
+		}
+	}
+
+	if len(found) == 0 {
+		return nil
+	}
+
+	slices.Sort(found)
+
+	return []string{fmt.Sprintf("restricted volume types (volumes %s)", strings.Join(found, ", "))}
+}
+
+func checkAllowPrivilegeEscalation(spec *corev1.PodSpec, _ map[string]any) []string {
+	var names []string
+
+	for _, c := range pssContainers(spec) {
+		if c.sc == nil || c.sc.AllowPrivilegeEscalation == nil || *c.sc.AllowPrivilegeEscalation {
+			names = append(names, c.name)
+		}
+	}
+
+	return violation("allowPrivilegeEscalation != false", names, "must set securityContext.allowPrivilegeEscalation=false")
+}
+
+func checkRunAsNonRoot(spec *corev1.PodSpec, _ map[string]any) []string {
+	pod := spec.SecurityContext != nil && spec.SecurityContext.RunAsNonRoot != nil && *spec.SecurityContext.RunAsNonRoot
+
+	var violations []string
+
+	if spec.SecurityContext != nil && spec.SecurityContext.RunAsNonRoot != nil && !*spec.SecurityContext.RunAsNonRoot {
+		violations = append(violations, "runAsNonRoot != true (pod must not set securityContext.runAsNonRoot=false)")
+	}
+
+	var names []string
+
+	for _, c := range pssContainers(spec) {
+		switch {
+		case c.sc != nil && c.sc.RunAsNonRoot != nil:
+			if !*c.sc.RunAsNonRoot {
+				names = append(names, c.name)
+			}
+		case !pod:
+			names = append(names, c.name)
+		}
+	}
+
+	return append(violations, violation("runAsNonRoot != true", names, "must set securityContext.runAsNonRoot=true")...)
+}
+
+func checkRunAsUser(spec *corev1.PodSpec, _ map[string]any) []string {
+	var violations []string
+
+	if spec.SecurityContext != nil && spec.SecurityContext.RunAsUser != nil && *spec.SecurityContext.RunAsUser == 0 {
+		violations = append(violations, "runAsUser=0 (pod must not set runAsUser=0)")
+	}
+
+	var names []string
+
+	for _, c := range pssContainers(spec) {
+		if c.sc != nil && c.sc.RunAsUser != nil && *c.sc.RunAsUser == 0 {
+			names = append(names, c.name)
+		}
+	}
+
+	return append(violations, violation("runAsUser=0", names, "must not set runAsUser=0")...)
+}
+
+func checkSeccomp(spec *corev1.PodSpec, _ map[string]any) []string {
+	allowed := func(p *corev1.SeccompProfile) bool {
+		return p != nil && (p.Type == corev1.SeccompProfileTypeRuntimeDefault || p.Type == corev1.SeccompProfileTypeLocalhost)
+	}
+
+	var (
+		pod        *corev1.SeccompProfile
+		violations []string
+	)
+
+	if spec.SecurityContext != nil {
+		pod = spec.SecurityContext.SeccompProfile
+	}
+
+	if pod != nil && !allowed(pod) {
+		violations = append(violations, fmt.Sprintf("seccompProfile (pod must not set securityContext.seccompProfile.type to %q)", pod.Type))
+	}
+
+	var names []string
+
+	for _, c := range pssContainers(spec) {
+		switch {
+		case c.sc != nil && c.sc.SeccompProfile != nil:
+			if !allowed(c.sc.SeccompProfile) {
+				names = append(names, c.name)
+			}
+		case pod == nil:
+			names = append(names, c.name)
+		}
+	}
+
+	return append(violations, violation("seccompProfile", names, `must set securityContext.seccompProfile.type to "RuntimeDefault" or "Localhost"`)...)
+}
+
+func checkCapabilities(spec *corev1.PodSpec, _ map[string]any) []string {
+	var (
+		notDropped []string
+		added      []string
+		violations []string
+	)
+
+	for _, c := range pssContainers(spec) {
+		var caps *corev1.Capabilities
+		if c.sc != nil {
+			caps = c.sc.Capabilities
+		}
+
+		if caps == nil || !slices.Contains(caps.Drop, "ALL") {
+			notDropped = append(notDropped, c.name)
+		}
+
+		if caps != nil && slices.ContainsFunc(caps.Add, func(c corev1.Capability) bool { return c != "NET_BIND_SERVICE" }) {
+			added = append(added, c.name)
+		}
+	}
+
+	violations = append(violations, violation("unrestricted capabilities", notDropped, `must set securityContext.capabilities.drop=["ALL"]`)...)
+	violations = append(violations, violation("unrestricted capabilities", added, `must not include capabilities other than "NET_BIND_SERVICE" in securityContext.capabilities.add`)...)
+
+	return violations
+}
+
+// violation describes the violation of the given rule by the given
+// containers, if any, i.e.:
+//
+//	allowPrivilegeEscalation != false (container "app" must set securityContext.allowPrivilegeEscalation=false)
+func violation(rule string, containers []string, reason string) []string {
+	switch len(containers) {
+	case 0:
+		return nil
+	case 1:
+		return []string{fmt.Sprintf("%s (container %s %s)", rule, quoted(containers), reason)}
+	default:
+		return []string{fmt.Sprintf("%s (containers %s %s)", rule, quoted(containers), reason)}
+	}
+}
+
+func quoted(names []string) string {
+	q := make([]string, len(names))
+	for i, n := range names {
+		q[i] = fmt.Sprintf("%q", n)
+	}
+
+	return strings.Join(q, ", ")
+}
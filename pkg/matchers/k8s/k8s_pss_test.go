@@ -0,0 +1,113 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func newRestrictedPodSpec() corev1.PodSpec {
+	return corev1.PodSpec{
+		SecurityContext: &corev1.PodSecurityContext{
+			RunAsNonRoot:   ptrTo(true),
+			SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		},
+		Volumes: []corev1.Volume{
+			{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}}},
+		},
+		Containers: []corev1.Container{{
+			Name: "app",
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: ptrTo(false),
+				Capabilities: &corev1.Capabilities{
+					Drop: []corev1.Capability{"ALL"},
+					Add:  []corev1.Capability{"NET_BIND_SERVICE"},
+				},
+			},
+		}},
+	}
+}
+
+func TestBeRestrictedPSSCompliant(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	spec := newRestrictedPodSpec()
+
+	g.Expect(&corev1.Pod{Spec: spec}).Should(k8s.BeRestrictedPSSCompliant())
+	g.Expect(&appsv1.Deployment{Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: spec}}}).Should(k8s.BeRestrictedPSSCompliant())
+
+	// container level settings are enough
+	spec = newRestrictedPodSpec()
+	spec.SecurityContext = nil
+	spec.Containers[0].SecurityContext.RunAsNonRoot = ptrTo(true)
+	spec.Containers[0].SecurityContext.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeLocalhost}
+
+	g.Expect(&corev1.Pod{Spec: spec}).Should(k8s.BeRestrictedPSSCompliant())
+
+	mutations := map[string]func(*corev1.PodSpec){
+		"hostNetwork": func(s *corev1.PodSpec) { s.HostNetwork = true },
+		"privileged":  func(s *corev1.PodSpec) { s.Containers[0].SecurityContext.Privileged = ptrTo(true) },
+		"hostPath": func(s *corev1.PodSpec) {
+			s.Volumes = append(s.Volumes, corev1.Volume{Name: "host", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/"}}})
+		},
+		"hostPort": func(s *corev1.PodSpec) {
+			s.Containers[0].Ports = []corev1.ContainerPort{{ContainerPort: 80, HostPort: 80}}
+		},
+		"sysctls": func(s *corev1.PodSpec) {
+			s.SecurityContext.Sysctls = []corev1.Sysctl{{Name: "kernel.msgmax", Value: "1"}}
+		},
+		"volume type": func(s *corev1.PodSpec) {
+			s.Volumes = append(s.Volumes, corev1.Volume{Name: "nfs", VolumeSource: corev1.VolumeSource{NFS: &corev1.NFSVolumeSource{}}})
+		},
+		"runAsNonRoot": func(s *corev1.PodSpec) { s.Containers[0].SecurityContext.RunAsNonRoot = ptrTo(false) },
+		"runAsUser":    func(s *corev1.PodSpec) { s.SecurityContext.RunAsUser = ptrTo(int64(0)) },
+		"seccomp": func(s *corev1.PodSpec) {
+			s.SecurityContext.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined}
+		},
+		"capabilities": func(s *corev1.PodSpec) {
+			s.Containers[0].SecurityContext.Capabilities.Add = []corev1.Capability{"SYS_ADMIN"}
+		},
+		"init container": func(s *corev1.PodSpec) {
+			s.InitContainers = []corev1.Container{{Name: "init"}}
+		},
+	}
+
+	for name, mutate := range mutations {
+		spec := newRestrictedPodSpec()
+		mutate(&spec)
+
+		g.Expect(&corev1.Pod{Spec: spec}).ShouldNot(k8s.BeRestrictedPSSCompliant(), name)
+	}
+}
+
+func TestBeRestrictedPSSCompliantFailureMessage(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		HostPID: true,
+		Containers: []corev1.Container{
+			{Name: "app"},
+			{Name: "sidecar", SecurityContext: &corev1.SecurityContext{RunAsUser: ptrTo(int64(0))}},
+		},
+	}}
+
+	m := k8s.BeRestrictedPSSCompliant()
+	g.Expect(m.Match(pod)).Should(BeFalse())
+	g.Expect(m.FailureMessage(pod)).Should(Equal(`Expected pod spec to comply with the restricted Pod Security Standard, but:
+  - host namespaces (hostPID=true)
+  - allowPrivilegeEscalation != false (containers "app", "sidecar" must set securityContext.allowPrivilegeEscalation=false)
+  - runAsNonRoot != true (containers "app", "sidecar" must set securityContext.runAsNonRoot=true)
+  - runAsUser=0 (container "sidecar" must not set runAsUser=0)
+  - seccompProfile (containers "app", "sidecar" must set securityContext.seccompProfile.type to "RuntimeDefault" or "Localhost")
+  - unrestricted capabilities (containers "app", "sidecar" must set securityContext.capabilities.drop=["ALL"])`))
+
+	g.Expect(m.NegatedFailureMessage(pod)).Should(Equal("Expected pod spec not to comply with the restricted Pod Security Standard"))
+}
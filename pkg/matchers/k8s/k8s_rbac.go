@@ -0,0 +1,115 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Can returns a pollable function that creates a SelfSubjectAccessReview to
+// check whether the identity used by the Matcher is allowed to perform the
+// given verb on the given resource, and returns the resulting review so that
+// it can be asserted with BeAllowed:
+//
+//	Expect(k.Can("list", corev1.SchemeGroupVersion.WithResource("pods"), ns)(ctx)).Should(k8s.BeAllowed())
+func (m *Matcher) Can(
+	verb string,
+	gvr schema.GroupVersionResource,
+	namespace string,
+) func(ctx context.Context) (*authorizationv1.SelfSubjectAccessReview, error) {
+	return func(ctx context.Context) (*authorizationv1.SelfSubjectAccessReview, error) {
+		review := authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: resourceAttributes(verb, gvr, namespace),
+			},
+		}
+
+		if err := m.client.Create(ctx, &review); err != nil {
+			return nil, fmt.Errorf("unable to create SelfSubjectAccessReview: %w", err)
+		}
+
+		return &review, nil
+	}
+}
+
+// UserCan is like Can but creates a SubjectAccessReview to check whether the
+// given user, member of the given groups, is allowed to perform the given
+// verb on the given resource.
+func (m *Matcher) UserCan(
+	user string,
+	verb string,
+	gvr schema.GroupVersionResource,
+	namespace string,
+	groups ...string,
+) func(ctx context.Context) (*authorizationv1.SubjectAccessReview, error) {
+	return func(ctx context.Context) (*authorizationv1.SubjectAccessReview, error) {
+		review := authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				User:               user,
+				Groups:             groups,
+				ResourceAttributes: resourceAttributes(verb, gvr, namespace),
+			},
+		}
+
+		if err := m.client.Create(ctx, &review); err != nil {
+			return nil, fmt.Errorf("unable to create SubjectAccessReview: %w", err)
+		}
+
+		return &review, nil
+	}
+}
+
+func resourceAttributes(verb string, gvr schema.GroupVersionResource, namespace string) *authorizationv1.ResourceAttributes {
+	return &authorizationv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      verb,
+		Group:     gvr.Group,
+		Version:   gvr.Version,
+		Resource:  gvr.Resource,
+	}
+}
+
+// BeAllowed succeeds if the actual SelfSubjectAccessReview or
+// SubjectAccessReview reports the request as allowed.
+func BeAllowed() types.GomegaMatcher {
+	return &allowedMatcher{}
+}
+
+var _ types.GomegaMatcher = &allowedMatcher{}
+
+type allowedMatcher struct {
+	status authorizationv1.SubjectAccessReviewStatus
+}
+
+func (matcher *allowedMatcher) Match(actual interface{}) (bool, error) {
+	switch v := actual.(type) {
+	case *authorizationv1.SelfSubjectAccessReview:
+		matcher.status = v.Status
+	case authorizationv1.SelfSubjectAccessReview:
+		matcher.status = v.Status
+	case *authorizationv1.SubjectAccessReview:
+		matcher.status = v.Status
+	case authorizationv1.SubjectAccessReview:
+		matcher.status = v.Status
+	case *authorizationv1.SubjectAccessReviewStatus:
+		matcher.status = *v
+	case authorizationv1.SubjectAccessReviewStatus:
+		matcher.status = v
+	default:
+		return false, fmt.Errorf("expected an access review, got:\n%s", format.Object(actual, 1))
+	}
+
+	return matcher.status.Allowed, nil
+}
+
+func (matcher *allowedMatcher) FailureMessage(_ interface{}) string {
+	return format.Message(matcher.status, "to be allowed")
+}
+
+func (matcher *allowedMatcher) NegatedFailureMessage(_ interface{}) string {
+	return format.Message(matcher.status, "not to be allowed")
+}
@@ -0,0 +1,64 @@
+package k8s_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestCan(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	// emulates an authorizer that only grants read access to pods, and only to
+	// members of the "readers" group when checking other users
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+				readPods := func(attrs *authorizationv1.ResourceAttributes) bool {
+					return attrs.Resource == "pods" && (attrs.Verb == "get" || attrs.Verb == "list")
+				}
+
+				switch review := obj.(type) {
+				case *authorizationv1.SelfSubjectAccessReview:
+					review.Status.Allowed = readPods(review.Spec.ResourceAttributes)
+				case *authorizationv1.SubjectAccessReview:
+					review.Status.Allowed = readPods(review.Spec.ResourceAttributes) && len(review.Spec.Groups) == 1 && review.Spec.Groups[0] == "readers"
+				}
+
+				return nil
+			},
+		}).
+		Build()
+
+	k := k8s.New(cli, scheme)
+	pods := corev1.SchemeGroupVersion.WithResource("pods")
+
+	g.Expect(k.Can("list", pods, "ns")(t.Context())).Should(k8s.BeAllowed())
+	g.Expect(k.Can("delete", pods, "ns")(t.Context())).ShouldNot(k8s.BeAllowed())
+
+	g.Eventually(k.Can("get", pods, "ns")).
+		WithContext(t.Context()).
+		Should(k8s.BeAllowed())
+
+	g.Expect(k.UserCan("jdoe", "get", pods, "ns", "readers")(t.Context())).Should(k8s.BeAllowed())
+	g.Expect(k.UserCan("jdoe", "get", pods, "ns")(t.Context())).ShouldNot(k8s.BeAllowed())
+
+	_, err := k8s.BeAllowed().Match(&corev1.Pod{})
+	g.Expect(err).Should(HaveOccurred())
+}
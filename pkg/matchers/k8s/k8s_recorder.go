@@ -0,0 +1,121 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Operation describes an API call performed through a Matcher.
+type Operation struct {
+	Time    time.Time
+	Verb    string
+	GVK     schema.GroupVersionKind
+	Key     client.ObjectKey
+	Err     error
+	Latency time.Duration
+}
+
+func (o Operation) String() string {
+	result := "ok"
+	if o.Err != nil {
+		result = o.Err.Error()
+	}
+
+	target := o.Key.String()
+	if o.Key.Name == "" {
+		target = o.Key.Namespace
+	}
+
+	return fmt.Sprintf("%s %s %s %s (%s): %s",
+		o.Time.Format(time.RFC3339Nano),
+		o.Verb,
+		formatGVK(o.GVK),
+		target,
+		o.Latency,
+		result,
+	)
+}
+
+// Recorder keeps track of the API calls performed through a Matcher, so
+// that the timeline of a failed test can be reconstructed.
+type Recorder struct {
+	lock       sync.Mutex
+	operations []Operation
+}
+
+// NewRecorder creates an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// WithRecorder records every API call performed through the Matcher and all
+// its copies in the given Recorder.
+func WithRecorder(rec *Recorder) Option {
+	return func(m *Matcher) {
+		m.recorder = rec
+	}
+}
+
+// Record appends the given operation to the trail.
+func (r *Recorder) Record(op Operation) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.operations = append(r.operations, op)
+}
+
+// Operations returns a copy of the recorded operations, in the order they
+// have been performed.
+func (r *Recorder) Operations() []Operation {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return append([]Operation(nil), r.operations...)
+}
+
+// Reset discards the recorded operations.
+func (r *Recorder) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.operations = nil
+}
+
+func (r *Recorder) String() string {
+	ops := r.Operations()
+
+	items := make([]string, 0, len(ops))
+	for _, op := range ops {
+		items = append(items, op.String())
+	}
+
+	return strings.Join(items, "\n")
+}
+
+// LogOnFailure registers a cleanup function that logs the recorded
+// operations if the test has failed.
+func (r *Recorder) LogOnFailure(t testing.TB) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Logf("api calls:\n%s", r)
+		}
+	})
+}
+
+// ReportOnFailure adds the recorded operations as a Ginkgo report entry if
+// the current spec has failed. It is meant to be invoked from an AfterEach
+// node.
+func (r *Recorder) ReportOnFailure() {
+	if ginkgo.CurrentSpecReport().Failed() {
+		ginkgo.AddReportEntry("api calls", r.String(), ginkgo.ReportEntryVisibilityFailureOrVerbose)
+	}
+}
@@ -0,0 +1,73 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"},
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(cm).
+		Build()
+
+	rec := k8s.NewRecorder()
+	k := k8s.New(cli, scheme, k8s.WithRecorder(rec)).WithContext(t.Context())
+
+	g.Expect(k.Client().Get(t.Context(), client.ObjectKeyFromObject(cm), &corev1.ConfigMap{})).Should(Succeed())
+	g.Expect(k.Client().List(t.Context(), &corev1.ConfigMapList{}, client.InNamespace("ns"))).Should(Succeed())
+	g.Expect(k.Client().Delete(t.Context(), cm)).Should(Succeed())
+	g.Expect(k.Client().Get(t.Context(), client.ObjectKeyFromObject(cm), &corev1.ConfigMap{})).ShouldNot(Succeed())
+
+	cmGVK := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+
+	ops := rec.Operations()
+	g.Expect(ops).Should(HaveLen(4))
+	g.Expect(ops).Should(HaveEach(HaveField("Time", Not(BeZero()))))
+	g.Expect(ops[0]).Should(haveOperation(cmGVK, "get", client.ObjectKey{Namespace: "ns", Name: "cm"}, false))
+	g.Expect(ops[1]).Should(haveOperation(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMapList"}, "list", client.ObjectKey{}, false))
+	g.Expect(ops[2]).Should(haveOperation(cmGVK, "delete", client.ObjectKey{Namespace: "ns", Name: "cm"}, false))
+	g.Expect(ops[3]).Should(haveOperation(cmGVK, "get", client.ObjectKey{Namespace: "ns", Name: "cm"}, true))
+
+	g.Expect(rec.String()).Should(ContainSubstring("get v1/ConfigMap ns/cm"))
+	g.Expect(rec.String()).Should(ContainSubstring(`configmaps "cm" not found`))
+
+	rec.Reset()
+	g.Expect(rec.Operations()).Should(BeEmpty())
+}
+
+func haveOperation(gvk schema.GroupVersionKind, verb string, key client.ObjectKey, failed bool) types.GomegaMatcher {
+	err := BeNil()
+	if failed {
+		err = HaveOccurred()
+	}
+
+	return And(
+		HaveField("GVK", gvk),
+		HaveField("Verb", verb),
+		HaveField("Key", key),
+		HaveField("Err", err),
+	)
+}
@@ -0,0 +1,273 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+const (
+	maxSuggestionDistance = 2
+	maxSuggestions        = 5
+
+	// defaultRefreshInterval bounds how often a miss refreshes the resources
+	defaultRefreshInterval = 10 * time.Second
+)
+
+type apiResource struct {
+	gvk   schema.GroupVersionKind
+	names []string
+}
+
+// resolver maps resource strings to GroupVersionKinds, caching the result of
+// the discovery calls. The cache is refreshed upon a miss, so resources
+// installed after the first lookup (i.e. CRDs) can be resolved too; cached
+// discovery clients are invalidated beforehand, as they would otherwise keep
+// serving the stale resources. Misses refresh the cache at most once per
+// interval, so that polling an unknown resource does not re-discover the
+// whole API on every attempt.
+type resolver struct {
+	discovery discovery.DiscoveryInterface
+	interval  time.Duration
+
+	lock      sync.Mutex
+	resources []apiResource
+	refreshed time.Time
+}
+
+func newResolver(dc discovery.DiscoveryInterface, interval time.Duration) *resolver {
+	return &resolver{
+		discovery: dc,
+		interval:  interval,
+	}
+}
+
+func (r *resolver) resolve(resource string) (schema.GroupVersionKind, error) {
+	if resource == "" {
+		return schema.GroupVersionKind{}, &NoGVKError{Resource: `""`, Err: errors.New("an empty resource cannot be resolved")}
+	}
+
+	if strings.Contains(resource, "/") {
+		return parseGVK(resource)
+	}
+
+	if r.discovery == nil {
+		return schema.GroupVersionKind{}, &NoGVKError{
+			Resource: strconv.Quote(resource),
+			Err:      errors.New("no discovery client configured, use the group/version/Kind form (i.e. apps/v1/Deployment)"),
+		}
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	refreshed := false
+
+	if r.resources == nil {
+		if err := r.refresh(); err != nil {
+			return schema.GroupVersionKind{}, err
+		}
+
+		refreshed = true
+	}
+
+	if gvk, ok := r.lookup(resource); ok {
+		return gvk, nil
+	}
+
+	if !refreshed && time.Since(r.refreshed) >= r.interval {
+		if cached, ok := r.discovery.(discovery.CachedDiscoveryInterface); ok {
+			cached.Invalidate()
+		}
+
+		if err := r.refresh(); err != nil {
+			return schema.GroupVersionKind{}, err
+		}
+
+		if gvk, ok := r.lookup(resource); ok {
+			return gvk, nil
+		}
+	}
+
+	suggestions := r.suggest(resource)
+	if len(suggestions) == 0 {
+		return schema.GroupVersionKind{}, &NoGVKError{
+			Resource: strconv.Quote(resource),
+			Err:      errors.New("no matching resource found"),
+		}
+	}
+
+	return schema.GroupVersionKind{}, &NoGVKError{
+		Resource: strconv.Quote(resource),
+		Err:      fmt.Errorf("no matching resource found, did you mean one of: %s", strings.Join(suggestions, ", ")),
+	}
+}
+
+func (r *resolver) refresh() error {
+	// failed attempts count too, so an unreachable server is not hammered
+	r.refreshed = time.Now()
+
+	lists, err := discovery.ServerPreferredResources(r.discovery)
+
+	// partial discovery failures (i.e. an unavailable aggregated API) should
+	// not prevent resolving the resources that have been discovered
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return fmt.Errorf("unable to discover API resources: %w", err)
+	}
+
+	resources := make([]apiResource, 0)
+
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+
+		for _, res := range list.APIResources {
+			// skip subresources, i.e. deployments/scale
+			if strings.Contains(res.Name, "/") {
+				continue
+			}
+
+			resources = append(resources, newAPIResource(gv, res))
+		}
+	}
+
+	r.resources = resources
+
+	return nil
+}
+
+func (r *resolver) lookup(resource string) (schema.GroupVersionKind, bool) {
+	name := strings.ToLower(resource)
+
+	for _, res := range r.resources {
+		for _, n := range res.names {
+			if n == name {
+				return res.gvk, true
+			}
+		}
+	}
+
+	return schema.GroupVersionKind{}, false
+}
+
+func (r *resolver) suggest(resource string) []string {
+	name := strings.ToLower(resource)
+	seen := make(map[string]struct{})
+	suggestions := make([]string, 0)
+
+	for _, res := range r.resources {
+		for _, n := range res.names {
+			if levenshtein(name, n) > maxSuggestionDistance && !strings.HasPrefix(n, name) {
+				continue
+			}
+
+			s := fmt.Sprintf("%s (%s)", n, formatGVK(res.gvk))
+			if _, ok := seen[s]; ok {
+				continue
+			}
+
+			seen[s] = struct{}{}
+			suggestions = append(suggestions, s)
+		}
+	}
+
+	sort.Strings(suggestions)
+
+	if len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+
+	return suggestions
+}
+
+func newAPIResource(gv schema.GroupVersion, res metav1.APIResource) apiResource {
+	kind := strings.ToLower(res.Kind)
+	names := []string{kind, res.Name}
+
+	if res.SingularName != "" {
+		names = append(names, res.SingularName)
+	}
+
+	names = append(names, res.ShortNames...)
+
+	if gv.Group != "" {
+		names = append(names, kind+"."+gv.Group, res.Name+"."+gv.Group)
+	}
+
+	return apiResource{
+		gvk:   gv.WithKind(res.Kind),
+		names: names,
+	}
+}
+
+// parseGVK parses resources in the group/version/Kind or version/Kind form.
+func parseGVK(resource string) (schema.GroupVersionKind, error) {
+	parts := strings.Split(resource, "/")
+
+	var gvk schema.GroupVersionKind
+
+	switch len(parts) {
+	case 2:
+		gvk = schema.GroupVersionKind{Version: parts[0], Kind: parts[1]}
+	case 3:
+		gvk = schema.GroupVersionKind{Group: parts[0], Version: parts[1], Kind: parts[2]}
+	default:
+		return schema.GroupVersionKind{}, &NoGVKError{
+			Resource: strconv.Quote(resource),
+			Err:      errors.New("expected group/version/Kind or version/Kind"),
+		}
+	}
+
+	if gvk.Version == "" || gvk.Kind == "" {
+		return schema.GroupVersionKind{}, &NoGVKError{
+			Resource: strconv.Quote(resource),
+			Err:      errors.New("expected group/version/Kind or version/Kind"),
+		}
+	}
+
+	return gvk, nil
+}
+
+func formatGVK(gvk schema.GroupVersionKind) string {
+	if gvk.Group == "" {
+		return gvk.Version + "/" + gvk.Kind
+	}
+
+	return gvk.Group + "/" + gvk.Version + "/" + gvk.Kind
+}
+
+func levenshtein(a string, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
@@ -0,0 +1,169 @@
+package k8s
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	resourceRequests = "requests"
+	resourceLimits   = "limits"
+)
+
+// HaveResourceRequests succeeds if the resource requests of the pod template
+// of the actual workload, summed over all its containers, are equal to the
+// expected ones. Quantities are compared semantically (i.e. "1000m" equals
+// "1") and only the resources listed in expected are taken into account.
+func HaveResourceRequests(expected corev1.ResourceList) types.GomegaMatcher {
+	return &resourcesMatcher{
+		field:    resourceRequests,
+		expected: expected,
+	}
+}
+
+// HaveResourceLimits is like HaveResourceRequests, for resource limits.
+func HaveResourceLimits(expected corev1.ResourceList) types.GomegaMatcher {
+	return &resourcesMatcher{
+		field:    resourceLimits,
+		expected: expected,
+	}
+}
+
+// WithResourceRequests requires the resource requests of the container to be
+// equal to the expected ones, see HaveResourceRequests.
+func (matcher *ContainerMatcher) WithResourceRequests(expected corev1.ResourceList) *ContainerMatcher {
+	matcher.resources = append(matcher.resources, resourcesMatcher{
+		field:    resourceRequests,
+		expected: expected,
+	})
+
+	return matcher
+}
+
+// WithResourceLimits requires the resource limits of the container to be
+// equal to the expected ones, see HaveResourceRequests.
+func (matcher *ContainerMatcher) WithResourceLimits(expected corev1.ResourceList) *ContainerMatcher {
+	matcher.resources = append(matcher.resources, resourcesMatcher{
+		field:    resourceLimits,
+		expected: expected,
+	})
+
+	return matcher
+}
+
+var _ types.GomegaMatcher = &resourcesMatcher{}
+
+type resourcesMatcher struct {
+	field    string
+	expected corev1.ResourceList
+	actual   corev1.ResourceList
+}
+
+func (matcher *resourcesMatcher) Match(actual interface{}) (bool, error) {
+	obj, err := toObject(actual)
+	if err != nil {
+		return false, err
+	}
+
+	spec, err := podSpec(obj)
+	if err != nil {
+		return false, err
+	}
+
+	containers, _ := spec["containers"].([]any)
+
+	matcher.actual = corev1.ResourceList{}
+
+	for i := range containers {
+		c, ok := containers[i].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		list, err := containerResources(c, matcher.field)
+		if err != nil {
+			return false, err
+		}
+
+		for name, q := range list {
+			total := matcher.actual[name]
+			total.Add(q)
+			matcher.actual[name] = total
+		}
+	}
+
+	return matcher.matches(), nil
+}
+
+func (matcher *resourcesMatcher) matches() bool {
+	for name, q := range matcher.expected {
+		a, ok := matcher.actual[name]
+		if !ok || a.Cmp(q) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (matcher *resourcesMatcher) FailureMessage(_ interface{}) string {
+	return format.Message(formatResources(matcher.actual), "to have resource "+matcher.field, formatResources(matcher.expected))
+}
+
+func (matcher *resourcesMatcher) NegatedFailureMessage(_ interface{}) string {
+	return format.Message(formatResources(matcher.actual), "not to have resource "+matcher.field, formatResources(matcher.expected))
+}
+
+// containerResources parses the resource requests or limits of the given
+// container.
+func containerResources(container map[string]any, field string) (corev1.ResourceList, error) {
+	resources, _ := container["resources"].(map[string]any)
+	values, _ := resources[field].(map[string]any)
+
+	list := corev1.ResourceList{}
+
+	for name, v := range values {
+		q, err := parseQuantity(v)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse %s %s of container %v: %w", field, name, container["name"], err)
+		}
+
+		list[corev1.ResourceName(name)] = q
+	}
+
+	return list, nil
+}
+
+// parseQuantity parses a quantity decoded from JSON or YAML, formatting
+// numbers without the exponent form fmt.Sprint uses for large ones (i.e.
+// 1e+06), which resource.ParseQuantity rejects.
+func parseQuantity(v any) (resource.Quantity, error) {
+	switch q := v.(type) {
+	case string:
+		return resource.ParseQuantity(q)
+	case float64:
+		return resource.ParseQuantity(strconv.FormatFloat(q, 'f', -1, 64))
+	case int64:
+		return resource.ParseQuantity(strconv.FormatInt(q, 10))
+	default:
+		return resource.ParseQuantity(fmt.Sprint(v))
+	}
+}
+
+func formatResources(list corev1.ResourceList) string {
+	items := make([]string, 0, len(list))
+	for name, q := range list {
+		items = append(items, fmt.Sprintf("%s=%s", name, q.String()))
+	}
+
+	slices.Sort(items)
+
+	return strings.Join(items, ", ")
+}
@@ -0,0 +1,110 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestHaveResources(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	deployment := appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "manager",
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("500m"),
+									corev1.ResourceMemory: resource.MustParse("128Mi"),
+								},
+								Limits: corev1.ResourceList{
+									corev1.ResourceCPU: resource.MustParse("1000m"),
+								},
+							},
+						},
+						{
+							Name: "proxy",
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU: resource.MustParse("0.5"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	g.Expect(&deployment).Should(k8s.HaveResourceRequests(corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1"),
+		corev1.ResourceMemory: resource.MustParse("134217728"),
+	}))
+
+	g.Expect(&deployment).Should(k8s.HaveResourceLimits(corev1.ResourceList{
+		corev1.ResourceCPU: resource.MustParse("1"),
+	}))
+
+	g.Expect(&deployment).ShouldNot(k8s.HaveResourceLimits(corev1.ResourceList{
+		corev1.ResourceMemory: resource.MustParse("128Mi"),
+	}))
+
+	g.Expect(&deployment).Should(
+		k8s.HaveContainer("manager").
+			WithResourceRequests(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("0.5")}).
+			WithResourceLimits(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}),
+	)
+
+	m := k8s.HaveResourceRequests(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")})
+	g.Expect(m.Match(&deployment)).Should(BeFalse())
+	g.Expect(m.FailureMessage(&deployment)).Should(And(ContainSubstring("cpu=1, memory=128Mi"), ContainSubstring("cpu=2")))
+
+	c := k8s.HaveContainer("proxy").WithResourceRequests(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")})
+	g.Expect(c.Match(&deployment)).Should(BeFalse())
+	g.Expect(c.FailureMessage(&deployment)).Should(ContainSubstring("Expected container proxy to match"))
+}
+
+func TestHaveResourcesNumbers(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	// numeric quantities, as decoded from JSON or YAML manifests
+	pod := unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"spec": map[string]any{
+			"containers": []any{
+				map[string]any{
+					"name": "app",
+					"resources": map[string]any{
+						"requests": map[string]any{"cpu": 0.5, "memory": 1e6},
+						"limits":   map[string]any{"cpu": int64(2), "memory": 1.5e9},
+					},
+				},
+			},
+		},
+	}}
+
+	g.Expect(&pod).Should(k8s.HaveResourceRequests(corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("500m"),
+		corev1.ResourceMemory: resource.MustParse("1M"),
+	}))
+
+	g.Expect(&pod).Should(k8s.HaveResourceLimits(corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("2"),
+		corev1.ResourceMemory: resource.MustParse("1500M"),
+	}))
+}
@@ -0,0 +1,233 @@
+package k8s
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// HaveNodeSelector succeeds if the nodeSelector of the pod spec of the
+// actual object contains all the given entries. It works with Pods and any
+// kind embedding a PodTemplateSpec, as HaveContainer does.
+func HaveNodeSelector(expected map[string]string) types.GomegaMatcher {
+	return &nodeSelectorMatcher{
+		expected: expected,
+	}
+}
+
+var _ types.GomegaMatcher = &nodeSelectorMatcher{}
+
+type nodeSelectorMatcher struct {
+	expected map[string]string
+	selector map[string]string
+}
+
+func (matcher *nodeSelectorMatcher) Match(actual interface{}) (bool, error) {
+	spec, err := toPodSpec(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.selector, _, err = unstructured.NestedStringMap(spec, "nodeSelector")
+	if err != nil {
+		return false, fmt.Errorf("unable to read .nodeSelector: %w", err)
+	}
+
+	for k, v := range matcher.expected {
+		if actual, ok := matcher.selector[k]; !ok || actual != v {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (matcher *nodeSelectorMatcher) FailureMessage(_ interface{}) string {
+	return format.Message(matcher.selector, "to be a node selector containing", matcher.expected)
+}
+
+func (matcher *nodeSelectorMatcher) NegatedFailureMessage(_ interface{}) string {
+	return format.Message(matcher.selector, "not to be a node selector containing", matcher.expected)
+}
+
+// HaveToleration succeeds if the tolerations of the pod spec of the actual
+// object contain a toleration with the given key and effect. An empty effect
+// matches any effect, as does a toleration without effect.
+func HaveToleration(key string, effect corev1.TaintEffect) types.GomegaMatcher {
+	return &tolerationMatcher{
+		key:    key,
+		effect: effect,
+	}
+}
+
+var _ types.GomegaMatcher = &tolerationMatcher{}
+
+type tolerationMatcher struct {
+	key         string
+	effect      corev1.TaintEffect
+	tolerations []string
+}
+
+func (matcher *tolerationMatcher) Match(actual interface{}) (bool, error) {
+	spec, err := toPodSpec(actual)
+	if err != nil {
+		return false, err
+	}
+
+	tolerations, _, err := unstructured.NestedSlice(spec, "tolerations")
+	if err != nil {
+		return false, fmt.Errorf("unable to read .tolerations: %w", err)
+	}
+
+	matcher.tolerations = make([]string, 0, len(tolerations))
+	found := false
+
+	for i := range tolerations {
+		t, ok := tolerations[i].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		key, _, _ := unstructured.NestedString(t, "key")
+		effect, _, _ := unstructured.NestedString(t, "effect")
+
+		matcher.tolerations = append(matcher.tolerations, key+":"+effect)
+
+		if key == matcher.key && (matcher.effect == "" || effect == "" || effect == string(matcher.effect)) {
+			found = true
+		}
+	}
+
+	return found, nil
+}
+
+func (matcher *tolerationMatcher) FailureMessage(_ interface{}) string {
+	return format.Message(matcher.tolerations, "to contain toleration", matcher.expected())
+}
+
+func (matcher *tolerationMatcher) NegatedFailureMessage(_ interface{}) string {
+	return format.Message(matcher.tolerations, "not to contain toleration", matcher.expected())
+}
+
+func (matcher *tolerationMatcher) expected() string {
+	if matcher.effect == "" {
+		return matcher.key
+	}
+
+	return matcher.key + ":" + string(matcher.effect)
+}
+
+// HaveAffinityMatching succeeds if the affinity of the pod spec of the actual
+// object, as an unstructured map, matches the given matcher, i.e.:
+//
+//	Expect(deploy).Should(k8s.HaveAffinityMatching(jq.Match(
+//	    `.podAntiAffinity.requiredDuringSchedulingIgnoredDuringExecution[0].topologyKey == "kubernetes.io/hostname"`,
+//	)))
+func HaveAffinityMatching(matcher types.GomegaMatcher) types.GomegaMatcher {
+	return &affinityMatcher{
+		matcher: matcher,
+	}
+}
+
+var _ types.GomegaMatcher = &affinityMatcher{}
+
+type affinityMatcher struct {
+	matcher  types.GomegaMatcher
+	affinity map[string]any
+	kind     string
+}
+
+func (matcher *affinityMatcher) Match(actual interface{}) (bool, error) {
+	obj, err := toObject(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.kind = kindOf(obj)
+
+	spec, err := podSpec(obj)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.affinity, _, err = unstructured.NestedMap(spec, "affinity")
+	if err != nil {
+		return false, fmt.Errorf("unable to read .affinity: %w", err)
+	}
+
+	if matcher.affinity == nil {
+		return false, nil
+	}
+
+	return matcher.matcher.Match(matcher.affinity)
+}
+
+func (matcher *affinityMatcher) FailureMessage(_ interface{}) string {
+	if matcher.affinity == nil {
+		return fmt.Sprintf("Expected %s to define an affinity, but it has none", matcher.kind)
+	}
+
+	return fmt.Sprintf("Expected affinity of %s to match:\n%s", matcher.kind, matcher.matcher.FailureMessage(matcher.affinity))
+}
+
+func (matcher *affinityMatcher) NegatedFailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected affinity of %s not to match:\n%s", matcher.kind, matcher.matcher.NegatedFailureMessage(matcher.affinity))
+}
+
+// BeScheduledOn succeeds if the actual Pod has been scheduled on a node whose
+// name matches the given value, which can be either a string or a matcher,
+// i.e. BeScheduledOn(HavePrefix("worker-")).
+func BeScheduledOn(expected any) types.GomegaMatcher {
+	return &scheduledMatcher{
+		expected: toMatcher(expected),
+	}
+}
+
+var _ types.GomegaMatcher = &scheduledMatcher{}
+
+type scheduledMatcher struct {
+	expected types.GomegaMatcher
+	node     string
+}
+
+func (matcher *scheduledMatcher) Match(actual interface{}) (bool, error) {
+	obj, err := toObject(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.node, _, err = unstructured.NestedString(obj, "spec", "nodeName")
+	if err != nil {
+		return false, fmt.Errorf("unable to read .spec.nodeName: %w", err)
+	}
+
+	if matcher.node == "" {
+		return false, nil
+	}
+
+	return matcher.expected.Match(matcher.node)
+}
+
+func (matcher *scheduledMatcher) FailureMessage(_ interface{}) string {
+	if matcher.node == "" {
+		return "Expected pod to be scheduled, but it has no .spec.nodeName"
+	}
+
+	return fmt.Sprintf("Expected pod to be scheduled on a matching node:\n%s", matcher.expected.FailureMessage(matcher.node))
+}
+
+func (matcher *scheduledMatcher) NegatedFailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected pod not to be scheduled on a matching node:\n%s", matcher.expected.NegatedFailureMessage(matcher.node))
+}
+
+func toPodSpec(in any) (map[string]any, error) {
+	obj, err := toObject(in)
+	if err != nil {
+		return nil, err
+	}
+
+	return podSpec(obj)
+}
@@ -0,0 +1,100 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSchedulingMatchers(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	deploy := appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers:   []corev1.Container{{Name: "app", Image: "app"}},
+					NodeSelector: map[string]string{"kubernetes.io/os": "linux", "tier": "web"},
+					Tolerations: []corev1.Toleration{
+						{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "web", Effect: corev1.TaintEffectNoSchedule},
+						{Key: "node.kubernetes.io/not-ready", Operator: corev1.TolerationOpExists},
+					},
+					Affinity: &corev1.Affinity{
+						PodAntiAffinity: &corev1.PodAntiAffinity{
+							RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
+								TopologyKey: "kubernetes.io/hostname",
+							}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	g.Expect(&deploy).Should(k8s.HaveNodeSelector(map[string]string{"kubernetes.io/os": "linux"}))
+	g.Expect(&deploy).ShouldNot(k8s.HaveNodeSelector(map[string]string{"tier": "db"}))
+
+	g.Expect(&deploy).Should(k8s.HaveToleration("dedicated", corev1.TaintEffectNoSchedule))
+	g.Expect(&deploy).Should(k8s.HaveToleration("dedicated", ""))
+	g.Expect(&deploy).Should(k8s.HaveToleration("node.kubernetes.io/not-ready", corev1.TaintEffectNoExecute))
+	g.Expect(&deploy).ShouldNot(k8s.HaveToleration("dedicated", corev1.TaintEffectNoExecute))
+	g.Expect(&deploy).ShouldNot(k8s.HaveToleration("gpu", ""))
+
+	g.Expect(&deploy).Should(k8s.HaveAffinityMatching(jq.Match(
+		`.podAntiAffinity.requiredDuringSchedulingIgnoredDuringExecution[0].topologyKey == "kubernetes.io/hostname"`,
+	)))
+	g.Expect(&deploy).ShouldNot(k8s.HaveAffinityMatching(jq.Match(`.nodeAffinity != null`)))
+
+	pod := corev1.Pod{Spec: corev1.PodSpec{NodeName: "worker-1", Containers: []corev1.Container{{Name: "app"}}}}
+
+	g.Expect(&pod).Should(k8s.BeScheduledOn("worker-1"))
+	g.Expect(&pod).Should(k8s.BeScheduledOn(HavePrefix("worker-")))
+	g.Expect(&pod).ShouldNot(k8s.BeScheduledOn("worker-2"))
+	g.Expect(&corev1.Pod{}).ShouldNot(k8s.BeScheduledOn(BeEmpty()))
+}
+
+func TestSchedulingMatchersFailureMessage(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	pod := corev1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		Spec: corev1.PodSpec{
+			Containers:  []corev1.Container{{Name: "app"}},
+			Tolerations: []corev1.Toleration{{Key: "dedicated", Effect: corev1.TaintEffectNoSchedule}},
+		},
+	}
+
+	m := k8s.HaveToleration("gpu", corev1.TaintEffectNoSchedule)
+	g.Expect(m.Match(&pod)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(And(
+		ContainSubstring("dedicated:NoSchedule"),
+		ContainSubstring("to contain toleration"),
+		ContainSubstring("gpu:NoSchedule"),
+	))
+
+	m = k8s.HaveAffinityMatching(jq.Match(`true`))
+	g.Expect(m.Match(&pod)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(Equal("Expected Pod to define an affinity, but it has none"))
+
+	m = k8s.BeScheduledOn("worker-1")
+	g.Expect(m.Match(&pod)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(Equal("Expected pod to be scheduled, but it has no .spec.nodeName"))
+
+	pod.Spec.NodeName = "worker-2"
+
+	g.Expect(m.Match(&pod)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(And(
+		HavePrefix("Expected pod to be scheduled on a matching node:\n"),
+		ContainSubstring("worker-2"),
+	))
+}
@@ -0,0 +1,196 @@
+package k8s
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	defaultServiceAccount = "default"
+
+	// defaultTokenExpiration is the expiration the API server applies to
+	// projected service account tokens not setting expirationSeconds.
+	defaultTokenExpiration = time.Hour
+)
+
+// UseServiceAccount succeeds if the pod spec of the actual workload runs as
+// the given ServiceAccount. Pod specs not setting a ServiceAccount use the
+// "default" one.
+func UseServiceAccount(name string) types.GomegaMatcher {
+	return &serviceAccountMatcher{
+		name: name,
+	}
+}
+
+var _ types.GomegaMatcher = &serviceAccountMatcher{}
+
+type serviceAccountMatcher struct {
+	name   string
+	actual string
+}
+
+func (matcher *serviceAccountMatcher) Match(actual interface{}) (bool, error) {
+	obj, err := toObject(actual)
+	if err != nil {
+		return false, err
+	}
+
+	spec, err := podSpec(obj)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.actual = defaultServiceAccount
+
+	// serviceAccount is the deprecated alias of serviceAccountName
+	for _, field := range []string{"serviceAccount", "serviceAccountName"} {
+		if name, ok := spec[field].(string); ok && name != "" {
+			matcher.actual = name
+		}
+	}
+
+	return matcher.actual == matcher.name, nil
+}
+
+func (matcher *serviceAccountMatcher) FailureMessage(_ interface{}) string {
+	return format.Message(matcher.actual, "to be the ServiceAccount of the workload, equal to", matcher.name)
+}
+
+func (matcher *serviceAccountMatcher) NegatedFailureMessage(_ interface{}) string {
+	return format.Message(matcher.actual, "not to be the ServiceAccount of the workload, equal to", matcher.name)
+}
+
+// HaveProjectedToken succeeds if the pod spec of the actual workload mounts a
+// projected ServiceAccount token volume with the given audience. Further
+// expectations on the token can be added with WithExpiration and WithPath.
+func HaveProjectedToken(audience string) *ProjectedTokenMatcher {
+	return &ProjectedTokenMatcher{
+		audience: audience,
+	}
+}
+
+var _ types.GomegaMatcher = &ProjectedTokenMatcher{}
+
+// ProjectedTokenMatcher is the matcher returned by HaveProjectedToken.
+type ProjectedTokenMatcher struct {
+	audience   string
+	expiration time.Duration
+	path       string
+
+	tokens []map[string]any
+}
+
+// WithExpiration requires the token to expire after the given duration.
+func (matcher *ProjectedTokenMatcher) WithExpiration(expiration time.Duration) *ProjectedTokenMatcher {
+	matcher.expiration = expiration
+
+	return matcher
+}
+
+// WithPath requires the token to be projected at the given path, relative
+// to the mount point of the volume.
+func (matcher *ProjectedTokenMatcher) WithPath(path string) *ProjectedTokenMatcher {
+	matcher.path = path
+
+	return matcher
+}
+
+func (matcher *ProjectedTokenMatcher) Match(actual interface{}) (bool, error) {
+	obj, err := toObject(actual)
+	if err != nil {
+		return false, err
+	}
+
+	spec, err := podSpec(obj)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.tokens = nil
+
+	volumes, _ := spec["volumes"].([]any)
+
+	for i := range volumes {
+		v, ok := volumes[i].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		sources, _, _ := unstructured.NestedSlice(v, "projected", "sources")
+
+		for j := range sources {
+			source, ok := sources[j].(map[string]any)
+			if !ok {
+				continue
+			}
+
+			token, ok, _ := unstructured.NestedMap(source, "serviceAccountToken")
+			if !ok {
+				continue
+			}
+
+			token["volume"] = v["name"]
+			matcher.tokens = append(matcher.tokens, token)
+		}
+	}
+
+	for _, token := range matcher.tokens {
+		if matcher.matches(token) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (matcher *ProjectedTokenMatcher) matches(token map[string]any) bool {
+	if audience, _ := token["audience"].(string); audience != matcher.audience {
+		return false
+	}
+
+	if matcher.path != "" {
+		if path, _ := token["path"].(string); path != matcher.path {
+			return false
+		}
+	}
+
+	if matcher.expiration != 0 {
+		expiration := defaultTokenExpiration
+
+		if seconds, ok, _ := unstructured.NestedInt64(token, "expirationSeconds"); ok {
+			expiration = time.Duration(seconds) * time.Second
+		}
+
+		if expiration != matcher.expiration {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (matcher *ProjectedTokenMatcher) FailureMessage(_ interface{}) string {
+	return format.Message(matcher.tokens, "to contain a projected ServiceAccount token "+matcher.describe())
+}
+
+func (matcher *ProjectedTokenMatcher) NegatedFailureMessage(_ interface{}) string {
+	return format.Message(matcher.tokens, "not to contain a projected ServiceAccount token "+matcher.describe())
+}
+
+func (matcher *ProjectedTokenMatcher) describe() string {
+	d := fmt.Sprintf("with audience %q", matcher.audience)
+
+	if matcher.expiration != 0 {
+		d += fmt.Sprintf(", expiration %s", matcher.expiration)
+	}
+
+	if matcher.path != "" {
+		d += fmt.Sprintf(", path %q", matcher.path)
+	}
+
+	return d
+}
@@ -0,0 +1,94 @@
+package k8s_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestUseServiceAccount(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	deployment := appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					ServiceAccountName: "controller-sa",
+					Containers:         []corev1.Container{{Name: "manager"}},
+				},
+			},
+		},
+	}
+
+	g.Expect(&deployment).Should(k8s.UseServiceAccount("controller-sa"))
+	g.Expect(&deployment).ShouldNot(k8s.UseServiceAccount("default"))
+
+	pod := corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}}
+	g.Expect(&pod).Should(k8s.UseServiceAccount("default"))
+
+	m := k8s.UseServiceAccount("other")
+	g.Expect(m.Match(&deployment)).Should(BeFalse())
+	g.Expect(m.FailureMessage(&deployment)).Should(ContainSubstring("controller-sa"))
+}
+
+func TestHaveProjectedToken(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app"}},
+			Volumes: []corev1.Volume{
+				{
+					Name: "config",
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{},
+					},
+				},
+				{
+					Name: "vault-token",
+					VolumeSource: corev1.VolumeSource{
+						Projected: &corev1.ProjectedVolumeSource{
+							Sources: []corev1.VolumeProjection{
+								{
+									ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+										Audience:          "vault",
+										ExpirationSeconds: ptrTo[int64](600),
+										Path:              "token",
+									},
+								},
+								{
+									ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+										Audience: "sts.amazonaws.com",
+										Path:     "aws-token",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	g.Expect(&pod).Should(k8s.HaveProjectedToken("vault"))
+	g.Expect(&pod).Should(k8s.HaveProjectedToken("vault").WithExpiration(10 * time.Minute).WithPath("token"))
+	g.Expect(&pod).Should(k8s.HaveProjectedToken("sts.amazonaws.com").WithExpiration(time.Hour))
+	g.Expect(&pod).ShouldNot(k8s.HaveProjectedToken("vault").WithExpiration(time.Hour))
+	g.Expect(&pod).ShouldNot(k8s.HaveProjectedToken("other"))
+
+	m := k8s.HaveProjectedToken("vault").WithPath("other")
+	g.Expect(m.Match(&pod)).Should(BeFalse())
+	g.Expect(m.FailureMessage(&pod)).Should(And(
+		ContainSubstring(`with audience "vault", path "other"`),
+		ContainSubstring("sts.amazonaws.com"),
+	))
+}
@@ -0,0 +1,276 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//nolint:gochecknoglobals
+var (
+	identifier = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+	// fields changing on every write, which are not reported by DiffSnapshots
+	volatileFields = []string{
+		".metadata.resourceVersion",
+		".metadata.managedFields",
+		".metadata.generation",
+	}
+)
+
+// Snapshot holds the state of a set of objects at a given time, keyed by
+// "<apiVersion>/<Kind> <namespace>/<name>".
+type Snapshot struct {
+	Objects map[string]*unstructured.Unstructured
+}
+
+// SnapshotNamespace captures the state of all the objects of the given kinds
+// in the given namespace, so that it can be compared with a later one with
+// DiffSnapshots.
+func (m *Matcher) SnapshotNamespace(ctx context.Context, ns string, gvks ...schema.GroupVersionKind) (*Snapshot, error) {
+	s := Snapshot{
+		Objects: make(map[string]*unstructured.Unstructured),
+	}
+
+	for _, gvk := range gvks {
+		list := unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+		if err := listAll(ctx, m.client, &list, []client.ListOption{client.InNamespace(ns)}); err != nil {
+			return nil, fmt.Errorf("unable to list %s: %w", gvk.Kind, err)
+		}
+
+		for i := range list.Items {
+			obj := list.Items[i].DeepCopy()
+			obj.SetGroupVersionKind(gvk)
+
+			s.Objects[snapshotKey(obj)] = obj
+		}
+	}
+
+	return &s, nil
+}
+
+// FieldChange describes the change of a single field, identified by its jq
+// path, i.e. `.spec.replicas` or `.metadata.labels["app.kubernetes.io/name"]`.
+type FieldChange struct {
+	Path string
+	Old  any
+	New  any
+}
+
+func (c FieldChange) String() string {
+	return fmt.Sprintf("%s: %v -> %v", c.Path, c.Old, c.New)
+}
+
+// ObjectChange describes the changes of an object present in both
+// snapshots.
+type ObjectChange struct {
+	Object string
+	Fields []FieldChange
+}
+
+// SnapshotDiff reports the differences between two snapshots.
+type SnapshotDiff struct {
+	Created []string
+	Deleted []string
+	Updated []ObjectChange
+}
+
+// Empty returns true if the snapshots are equal.
+func (d *SnapshotDiff) Empty() bool {
+	return len(d.Created) == 0 && len(d.Deleted) == 0 && len(d.Updated) == 0
+}
+
+func (d *SnapshotDiff) String() string {
+	sb := strings.Builder{}
+
+	for _, o := range d.Created {
+		sb.WriteString("+ " + o + "\n")
+	}
+
+	for _, o := range d.Deleted {
+		sb.WriteString("- " + o + "\n")
+	}
+
+	for _, o := range d.Updated {
+		sb.WriteString("~ " + o.Object + "\n")
+
+		for _, f := range o.Fields {
+			sb.WriteString("    " + f.String() + "\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// DiffSnapshots compares two snapshots, reporting the objects created,
+// deleted and updated from a to b along with their changed fields. Fields
+// changing on every write (resourceVersion, managedFields and generation)
+// are ignored.
+func DiffSnapshots(a *Snapshot, b *Snapshot) *SnapshotDiff {
+	d := SnapshotDiff{}
+
+	for key, before := range a.Objects {
+		after, ok := b.Objects[key]
+		if !ok {
+			d.Deleted = append(d.Deleted, key)
+
+			continue
+		}
+
+		var changes []FieldChange
+
+		diffValues("", before.Object, after.Object, &changes)
+
+		changes = slices.DeleteFunc(changes, func(c FieldChange) bool {
+			return slices.ContainsFunc(volatileFields, func(p string) bool { return hasPathPrefix(c.Path, p) })
+		})
+
+		if len(changes) > 0 {
+			slices.SortFunc(changes, func(x FieldChange, y FieldChange) int { return strings.Compare(x.Path, y.Path) })
+			d.Updated = append(d.Updated, ObjectChange{Object: key, Fields: changes})
+		}
+	}
+
+	for key := range b.Objects {
+		if _, ok := a.Objects[key]; !ok {
+			d.Created = append(d.Created, key)
+		}
+	}
+
+	slices.Sort(d.Created)
+	slices.Sort(d.Deleted)
+	slices.SortFunc(d.Updated, func(x ObjectChange, y ObjectChange) int { return strings.Compare(x.Object, y.Object) })
+
+	return &d
+}
+
+// HaveOnlyChanged succeeds if the actual SnapshotDiff reports no created or
+// deleted object, and only changes to fields matching the given jq paths,
+// or nested in them, i.e. ".spec.replicas" or ".status".
+func HaveOnlyChanged(paths ...string) types.GomegaMatcher {
+	return &onlyChangedMatcher{
+		paths: paths,
+	}
+}
+
+var _ types.GomegaMatcher = &onlyChangedMatcher{}
+
+type onlyChangedMatcher struct {
+	paths      []string
+	unexpected SnapshotDiff
+}
+
+func (matcher *onlyChangedMatcher) Match(actual interface{}) (bool, error) {
+	var d *SnapshotDiff
+
+	switch v := actual.(type) {
+	case *SnapshotDiff:
+		d = v
+	case SnapshotDiff:
+		d = &v
+	default:
+		return false, fmt.Errorf("expected a SnapshotDiff, got:\n%s", format.Object(actual, 1))
+	}
+
+	matcher.unexpected = SnapshotDiff{
+		Created: d.Created,
+		Deleted: d.Deleted,
+	}
+
+	for _, o := range d.Updated {
+		fields := slices.DeleteFunc(slices.Clone(o.Fields), func(c FieldChange) bool {
+			return slices.ContainsFunc(matcher.paths, func(p string) bool { return hasPathPrefix(c.Path, p) })
+		})
+
+		if len(fields) > 0 {
+			matcher.unexpected.Updated = append(matcher.unexpected.Updated, ObjectChange{Object: o.Object, Fields: fields})
+		}
+	}
+
+	return matcher.unexpected.Empty(), nil
+}
+
+func (matcher *onlyChangedMatcher) FailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected only %v to have changed, but found unexpected changes:\n%s", matcher.paths, matcher.unexpected.String())
+}
+
+func (matcher *onlyChangedMatcher) NegatedFailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected changes other than %v, but found none", matcher.paths)
+}
+
+func snapshotKey(obj *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s/%s %s", obj.GetAPIVersion(), obj.GetKind(), client.ObjectKeyFromObject(obj))
+}
+
+// diffValues appends to changes the differences between a and b, recursing
+// into objects and equally sized arrays.
+func diffValues(path string, a any, b any, changes *[]FieldChange) {
+	am, aok := a.(map[string]any)
+	bm, bok := b.(map[string]any)
+
+	if aok && bok {
+		for k, av := range am {
+			bv, ok := bm[k]
+			if !ok {
+				*changes = append(*changes, FieldChange{Path: fieldPath(path, k), Old: av})
+
+				continue
+			}
+
+			diffValues(fieldPath(path, k), av, bv, changes)
+		}
+
+		for k, bv := range bm {
+			if _, ok := am[k]; !ok {
+				*changes = append(*changes, FieldChange{Path: fieldPath(path, k), New: bv})
+			}
+		}
+
+		return
+	}
+
+	as, aok := a.([]any)
+	bs, bok := b.([]any)
+
+	if aok && bok && len(as) == len(bs) {
+		for i := range as {
+			diffValues(fmt.Sprintf("%s[%d]", path, i), as[i], bs[i], changes)
+		}
+
+		return
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		*changes = append(*changes, FieldChange{Path: path, Old: a, New: b})
+	}
+}
+
+func fieldPath(parent string, key string) string {
+	if identifier.MatchString(key) {
+		return parent + "." + key
+	}
+
+	return fmt.Sprintf("%s[%q]", parent, key)
+}
+
+// hasPathPrefix returns true if path equals prefix or is nested in it.
+func hasPathPrefix(path string, prefix string) bool {
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+
+	rest := path[len(prefix):]
+
+	return rest == "" || rest[0] == '.' || rest[0] == '['
+}
@@ -0,0 +1,91 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSnapshots(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns", Labels: map[string]string{"app.kubernetes.io/name": "app"}},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptrTo[int32](1)},
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			deploy,
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"}, Data: map[string]string{"foo": "bar"}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other"}},
+		).
+		Build()
+
+	k := k8s.New(cli, scheme)
+	gvks := []schema.GroupVersionKind{
+		appsv1.SchemeGroupVersion.WithKind("Deployment"),
+		corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+	}
+
+	before, err := k.SnapshotNamespace(t.Context(), "ns", gvks...)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(before.Objects).Should(HaveLen(2))
+
+	unchanged, err := k.SnapshotNamespace(t.Context(), "ns", gvks...)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(k8s.DiffSnapshots(before, unchanged).Empty()).Should(BeTrue())
+	g.Expect(k8s.DiffSnapshots(before, unchanged)).Should(k8s.HaveOnlyChanged())
+
+	deploy.Spec.Replicas = ptrTo[int32](3)
+	deploy.Labels["app.kubernetes.io/version"] = "v1"
+	g.Expect(cli.Update(t.Context(), deploy)).Should(Succeed())
+	g.Expect(cli.Create(t.Context(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "ns"}})).Should(Succeed())
+
+	after, err := k.SnapshotNamespace(t.Context(), "ns", gvks...)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	diff := k8s.DiffSnapshots(before, after)
+	g.Expect(diff.Created).Should(ConsistOf("v1/ConfigMap ns/new"))
+	g.Expect(diff.Deleted).Should(BeEmpty())
+	g.Expect(diff.Updated).Should(HaveLen(1))
+	g.Expect(diff.Updated[0].Object).Should(Equal("apps/v1/Deployment ns/app"))
+	g.Expect(diff.Updated[0].Fields).Should(ConsistOf(
+		k8s.FieldChange{Path: `.metadata.labels["app.kubernetes.io/version"]`, New: "v1"},
+		k8s.FieldChange{Path: ".spec.replicas", Old: int64(1), New: int64(3)},
+	))
+
+	g.Expect(diff).ShouldNot(k8s.HaveOnlyChanged(".spec.replicas", ".metadata.labels"))
+
+	m := k8s.HaveOnlyChanged(".spec")
+	g.Expect(m.Match(diff)).Should(BeFalse())
+	g.Expect(m.FailureMessage(diff)).Should(And(
+		ContainSubstring("+ v1/ConfigMap ns/new"),
+		ContainSubstring(`.metadata.labels["app.kubernetes.io/version"]: <nil> -> v1`),
+		Not(ContainSubstring(".spec.replicas")),
+	))
+
+	g.Expect(k8s.DiffSnapshots(after, before).Deleted).Should(ConsistOf("v1/ConfigMap ns/new"))
+
+	g.Expect(cli.Delete(t.Context(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "ns"}})).Should(Succeed())
+
+	last, err := k.SnapshotNamespace(t.Context(), "ns", gvks...)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(k8s.DiffSnapshots(before, last)).Should(k8s.HaveOnlyChanged(".spec.replicas", ".metadata.labels"))
+	g.Expect(k8s.DiffSnapshots(before, last)).ShouldNot(k8s.HaveOnlyChanged(".spec.replicasCount"))
+}
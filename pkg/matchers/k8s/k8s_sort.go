@@ -0,0 +1,121 @@
+package k8s
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/itchyny/gojq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SortItems returns a transform sorting the items of a list by the result of
+// the given jq expression, i.e. `.metadata.name` or
+// `[.metadata.namespace, .metadata.name]`, using the jq ordering. The order
+// of the items returned by the API server is not guaranteed, so lists should
+// be sorted before being compared to golden files or expected values.
+//
+// Typed lists and UnstructuredLists are returned as sorted copies of the
+// same type, other values holding an items array in their unstructured form.
+func SortItems(expression string) Transform {
+	key := jq.Extract(expression)
+
+	return func(in any) (any, error) {
+		if list, ok := in.(client.ObjectList); ok {
+			return sortObjectList(list, key)
+		}
+
+		obj, err := toObject(in)
+		if err != nil {
+			return nil, err
+		}
+
+		items, err := toItems(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		sorted, err := sortByKey(items, func(item map[string]any) (any, error) {
+			return key(item)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		result := make(map[string]any, len(obj))
+		for k, v := range obj {
+			result[k] = v
+		}
+
+		values := make([]any, 0, len(sorted))
+		for _, item := range sorted {
+			values = append(values, item)
+		}
+
+		result["items"] = values
+
+		return result, nil
+	}
+}
+
+func sortObjectList(list client.ObjectList, key Transform) (any, error) {
+	list, ok := list.DeepCopyObject().(client.ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("unable to copy %T", list)
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, fmt.Errorf("unable to extract items: %w", err)
+	}
+
+	sorted, err := sortByKey(items, func(item runtime.Object) (any, error) {
+		obj, err := toObject(item)
+		if err != nil {
+			return nil, err
+		}
+
+		return key(obj)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := meta.SetList(list, sorted); err != nil {
+		return nil, fmt.Errorf("unable to set items: %w", err)
+	}
+
+	return list, nil
+}
+
+// sortByKey returns a copy of items, stably sorted by the given key.
+func sortByKey[T any](items []T, key func(T) (any, error)) ([]T, error) {
+	type keyed struct {
+		key  any
+		item T
+	}
+
+	entries := make([]keyed, 0, len(items))
+
+	for i, item := range items {
+		k, err := key(item)
+		if err != nil {
+			return nil, fmt.Errorf("unable to compute the sort key of item %d: %w", i, err)
+		}
+
+		entries = append(entries, keyed{key: k, item: item})
+	}
+
+	slices.SortStableFunc(entries, func(a keyed, b keyed) int {
+		return gojq.Compare(a.key, b.key)
+	})
+
+	result := make([]T, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, e.item)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,51 @@
+package k8s_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSortItems(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	list := corev1.ConfigMapList{Items: []corev1.ConfigMap{
+		{ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "ns1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ns2"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "ns1"}},
+	}}
+
+	sorted, err := k8s.SortItems(`.metadata.name`)(&list)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(sorted).Should(BeAssignableToTypeOf(&corev1.ConfigMapList{}))
+	g.Expect(sorted).Should(WithTransform(json.Marshal, jq.Match(`[.items[].metadata.name] == ["a","b","c"]`)))
+
+	// the actual is left untouched
+	g.Expect(list.Items[0].Name).Should(Equal("c"))
+
+	g.Expect(&list).Should(WithTransform(k8s.SortItems(`[.metadata.namespace, .metadata.name]`),
+		WithTransform(json.Marshal, jq.Match(`[.items[].metadata.name] == ["b","c","a"]`)),
+	))
+
+	u := unstructured.UnstructuredList{Items: []unstructured.Unstructured{
+		{Object: map[string]any{"metadata": map[string]any{"name": "x"}, "spec": map[string]any{"priority": int64(10)}}},
+		{Object: map[string]any{"metadata": map[string]any{"name": "y"}, "spec": map[string]any{"priority": int64(9)}}},
+	}}
+
+	g.Expect(&u).Should(WithTransform(k8s.SortItems(`.spec.priority`),
+		jq.Match(`[.items[].metadata.name] == ["y","x"]`),
+	))
+
+	g.Expect(`{"kind":"List","items":[{"n":2},{"n":1}]}`).Should(WithTransform(k8s.SortItems(`.n`),
+		jq.Match(`.kind == "List" and [.items[].n] == [1,2]`),
+	))
+}
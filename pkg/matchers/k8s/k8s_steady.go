@@ -0,0 +1,70 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/onsi/gomega/types"
+)
+
+// defaultSteadyPolling is the polling interval used by Steady when none is
+// given.
+const defaultSteadyPolling = 100 * time.Millisecond
+
+// Steady returns a function that samples fn for the given duration, every
+// polling interval (100ms if not given), and fails as soon as a sample
+// matches the given matcher, i.e. to assert that a Deployment never reports
+// Progressing=False while an upgrade is rolled out:
+//
+//	Expect(k8s.Steady(
+//	    u.Get("apps/v1/Deployment", key),
+//	    jq.Match(`.status.conditions[] | select(.type == "Progressing") | .status == "False"`),
+//	    time.Minute,
+//	)(ctx)).To(Succeed())
+//
+// This is the negative counterpart of Consistently(...).ShouldNot(...) whose
+// error reports the first violating sample along with the time it was taken
+// at. An error is also returned if fn or the matcher fail, or if ctx is done
+// before the duration elapses, as the window has then not been observed as a
+// whole.
+func Steady[T any](fn func(ctx context.Context) (T, error), matcher types.GomegaMatcher, duration time.Duration, polling ...time.Duration) func(ctx context.Context) error {
+	interval := defaultSteadyPolling
+	if len(polling) > 0 && polling[0] > 0 {
+		interval = polling[0]
+	}
+
+	return func(ctx context.Context) error {
+		start := time.Now()
+		deadline := time.NewTimer(duration)
+		defer deadline.Stop()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for samples := 1; ; samples++ {
+			v, err := fn(ctx)
+			if err != nil {
+				return fmt.Errorf("unable to take sample %d after %s: %w", samples, time.Since(start).Round(time.Millisecond), err)
+			}
+
+			match, err := matcher.Match(v)
+			if err != nil {
+				return fmt.Errorf("unable to match sample %d after %s: %w", samples, time.Since(start).Round(time.Millisecond), err)
+			}
+
+			if match {
+				return fmt.Errorf("sample %d, taken after %s, matched the undesired condition:\n%s",
+					samples, time.Since(start).Round(time.Millisecond), matcher.NegatedFailureMessage(v))
+			}
+
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("context done after %d samples, %s before the end of the window: %w", samples, time.Until(start.Add(duration)).Round(time.Millisecond), ctx.Err())
+			case <-deadline.C:
+				return nil
+			case <-ticker.C:
+			}
+		}
+	}
+}
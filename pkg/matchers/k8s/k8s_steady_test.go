@@ -0,0 +1,91 @@
+package k8s_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSteady(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"},
+		Status: appsv1.DeploymentStatus{
+			Conditions: []appsv1.DeploymentCondition{{
+				Type:   appsv1.DeploymentProgressing,
+				Status: corev1.ConditionTrue,
+			}},
+		},
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(deploy).
+		WithStatusSubresource(deploy).
+		Build()
+
+	k := k8s.New(cli, scheme)
+	key := client.ObjectKeyFromObject(deploy)
+
+	stalled := jq.Match(`.status.conditions[] | select(.type == "Progressing") | .status == "False"`)
+
+	g.Expect(k8s.Steady(k.Unstructured().Get("apps/v1/Deployment", key), stalled, 100*time.Millisecond, 10*time.Millisecond)(t.Context())).
+		Should(Succeed())
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+
+		d := appsv1.Deployment{}
+		if err := cli.Get(context.Background(), key, &d); err == nil {
+			d.Status.Conditions[0].Status = corev1.ConditionFalse
+			_ = cli.Status().Update(context.Background(), &d)
+		}
+	}()
+
+	err := k8s.Steady(k.Unstructured().Get("apps/v1/Deployment", key), stalled, 5*time.Second, 10*time.Millisecond)(t.Context())
+	g.Expect(err).Should(MatchError(And(
+		ContainSubstring("matched the undesired condition"),
+		ContainSubstring("status:False type:Progressing"),
+	)))
+}
+
+func TestSteadyErrors(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	failure := errors.New("boom")
+
+	err := k8s.Steady(func(_ context.Context) (any, error) {
+		return nil, failure
+	}, BeNil(), time.Second)(t.Context())
+	g.Expect(err).Should(MatchError(failure))
+	g.Expect(err).Should(MatchError(ContainSubstring("unable to take sample 1")))
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	err = k8s.Steady(func(_ context.Context) (int, error) {
+		return 1, nil
+	}, Equal(2), time.Minute, 10*time.Millisecond)(ctx)
+	g.Expect(err).Should(MatchError(context.DeadlineExceeded))
+}
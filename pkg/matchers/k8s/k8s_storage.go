@@ -0,0 +1,142 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// storageClassAnnotation is the legacy annotation used to set the storage
+// class before .spec.storageClassName was introduced.
+const storageClassAnnotation = "volume.beta.kubernetes.io/storage-class"
+
+// BeBound succeeds if the actual PersistentVolumeClaim is in the Bound phase
+// and the capacity of the bound volume satisfies the requested storage.
+func BeBound() types.GomegaMatcher {
+	return &boundMatcher{}
+}
+
+var _ types.GomegaMatcher = &boundMatcher{}
+
+type boundMatcher struct {
+	phase     string
+	requested *resource.Quantity
+	capacity  *resource.Quantity
+}
+
+func (matcher *boundMatcher) Match(actual interface{}) (bool, error) {
+	obj, err := toObject(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.phase, _, _ = unstructured.NestedString(obj, "status", "phase")
+
+	matcher.requested, err = storageQuantity(obj, "spec", "resources", "requests")
+	if err != nil {
+		return false, err
+	}
+
+	matcher.capacity, err = storageQuantity(obj, "status", "capacity")
+	if err != nil {
+		return false, err
+	}
+
+	if matcher.phase != string(corev1.ClaimBound) {
+		return false, nil
+	}
+
+	if matcher.requested == nil {
+		return true, nil
+	}
+
+	return matcher.capacity != nil && matcher.capacity.Cmp(*matcher.requested) >= 0, nil
+}
+
+func (matcher *boundMatcher) FailureMessage(_ interface{}) string {
+	if matcher.phase != string(corev1.ClaimBound) {
+		return format.Message(matcher.phase, "to be the phase of a bound claim, equal to", string(corev1.ClaimBound))
+	}
+
+	return fmt.Sprintf("Expected the capacity of the bound claim (%s) to satisfy the requested storage (%s)",
+		formatQuantity(matcher.capacity), formatQuantity(matcher.requested))
+}
+
+func (matcher *boundMatcher) NegatedFailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected claim not to be bound, but it is bound with capacity %s", formatQuantity(matcher.capacity))
+}
+
+// HaveStorageClass succeeds if the actual PersistentVolumeClaim or
+// PersistentVolume uses the given storage class, as set by
+// .spec.storageClassName or by the legacy beta annotation.
+func HaveStorageClass(name string) types.GomegaMatcher {
+	return &storageClassMatcher{
+		name: name,
+	}
+}
+
+var _ types.GomegaMatcher = &storageClassMatcher{}
+
+type storageClassMatcher struct {
+	name   string
+	actual string
+}
+
+func (matcher *storageClassMatcher) Match(actual interface{}) (bool, error) {
+	obj, err := toObject(actual)
+	if err != nil {
+		return false, err
+	}
+
+	className, found, err := unstructured.NestedString(obj, "spec", "storageClassName")
+	if err != nil {
+		return false, fmt.Errorf("unable to read .spec.storageClassName: %w", err)
+	}
+
+	if !found {
+		className, _, _ = unstructured.NestedString(obj, "metadata", "annotations", storageClassAnnotation)
+	}
+
+	matcher.actual = className
+
+	return matcher.actual == matcher.name, nil
+}
+
+func (matcher *storageClassMatcher) FailureMessage(_ interface{}) string {
+	return format.Message(matcher.actual, "to be the storage class, equal to", matcher.name)
+}
+
+func (matcher *storageClassMatcher) NegatedFailureMessage(_ interface{}) string {
+	return format.Message(matcher.actual, "not to be the storage class, equal to", matcher.name)
+}
+
+// storageQuantity parses the storage quantity held by the resource list at
+// the given path, returning nil if not set.
+func storageQuantity(obj map[string]any, fields ...string) (*resource.Quantity, error) {
+	values, _, _ := unstructured.NestedMap(obj, fields...)
+
+	v, ok := values[string(corev1.ResourceStorage)]
+	if !ok {
+		return nil, nil //nolint:nilnil
+	}
+
+	q, err := parseQuantity(v)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse storage of .%s: %w", strings.Join(fields, "."), err)
+	}
+
+	return &q, nil
+}
+
+func formatQuantity(q *resource.Quantity) string {
+	if q == nil {
+		return "<none>"
+	}
+
+	return q.String()
+}
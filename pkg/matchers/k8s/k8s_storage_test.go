@@ -0,0 +1,74 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestBeBound(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	pvc := func(phase corev1.PersistentVolumeClaimPhase, capacity string) *corev1.PersistentVolumeClaim {
+		claim := corev1.PersistentVolumeClaim{
+			Spec: corev1.PersistentVolumeClaimSpec{
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+				},
+			},
+			Status: corev1.PersistentVolumeClaimStatus{Phase: phase},
+		}
+
+		if capacity != "" {
+			claim.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(capacity)}
+		}
+
+		return &claim
+	}
+
+	g.Expect(pvc(corev1.ClaimBound, "1Gi")).Should(k8s.BeBound())
+	g.Expect(pvc(corev1.ClaimBound, "2Gi")).Should(k8s.BeBound())
+	g.Expect(pvc(corev1.ClaimBound, "512Mi")).ShouldNot(k8s.BeBound())
+	g.Expect(pvc(corev1.ClaimBound, "")).ShouldNot(k8s.BeBound())
+	g.Expect(pvc(corev1.ClaimPending, "")).ShouldNot(k8s.BeBound())
+	g.Expect(`{"status":{"phase":"Bound"}}`).Should(k8s.BeBound())
+
+	m := k8s.BeBound()
+	g.Expect(m.Match(pvc(corev1.ClaimPending, ""))).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(ContainSubstring(`<string>: Pending`))
+
+	g.Expect(m.Match(pvc(corev1.ClaimBound, "512Mi"))).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(ContainSubstring("(512Mi) to satisfy the requested storage (1Gi)"))
+}
+
+func TestHaveStorageClass(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	pvc := corev1.PersistentVolumeClaim{
+		Spec: corev1.PersistentVolumeClaimSpec{StorageClassName: ptrTo("fast")},
+	}
+
+	g.Expect(&pvc).Should(k8s.HaveStorageClass("fast"))
+	g.Expect(&pvc).ShouldNot(k8s.HaveStorageClass("slow"))
+
+	pv := corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{"volume.beta.kubernetes.io/storage-class": "slow"},
+		},
+	}
+
+	g.Expect(&pv).Should(k8s.HaveStorageClass("slow"))
+
+	m := k8s.HaveStorageClass("slow")
+	g.Expect(m.Match(&pvc)).Should(BeFalse())
+	g.Expect(m.FailureMessage(&pvc)).Should(ContainSubstring(`to be the storage class, equal to`))
+}
@@ -0,0 +1,53 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetSubresource fetches the given subresource, i.e. status, scale or any
+// custom subresource, of the object identified by the name, namespace and
+// kind of obj and returns it as unstructured, so that subresources of custom
+// kinds can be asserted without registering their types or writing REST
+// client code:
+//
+//	scale, err := k.GetSubresource(ctx, &widget, "scale")
+//	Expect(scale).Should(jq.Match(`.status.replicas == 3`))
+func (m *Matcher) GetSubresource(ctx context.Context, obj client.Object, subresource string) (*unstructured.Unstructured, error) {
+	gvk, err := gvkFor(m.client, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := unstructured.Unstructured{}
+	ref.SetGroupVersionKind(gvk)
+	ref.SetName(obj.GetName())
+	ref.SetNamespace(obj.GetNamespace())
+
+	out := unstructured.Unstructured{}
+
+	if err := m.client.SubResource(subresource).Get(ctx, &ref, &out); err != nil {
+		return nil, newOpError("get subresource "+subresource+" of", gvk, client.ObjectKeyFromObject(obj), err)
+	}
+
+	return &out, nil
+}
+
+// UpdateSubresource updates the given subresource of obj. When body is nil
+// obj itself is sent, as for the status subresource, otherwise body is, as
+// for the scale subresource. Both obj and body can be typed or unstructured.
+func (m *Matcher) UpdateSubresource(ctx context.Context, obj client.Object, subresource string, body client.Object) error {
+	var opts []client.SubResourceUpdateOption
+	if body != nil {
+		opts = append(opts, client.WithSubResourceBody(body))
+	}
+
+	if err := m.client.SubResource(subresource).Update(ctx, obj, opts...); err != nil {
+		return fmt.Errorf("unable to update subresource %s of %s: %w", subresource, client.ObjectKeyFromObject(obj), err)
+	}
+
+	return nil
+}
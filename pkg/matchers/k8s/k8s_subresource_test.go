@@ -0,0 +1,100 @@
+package k8s_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSubresource(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"},
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(deploy).
+		WithStatusSubresource(deploy).
+		Build()
+
+	var updated client.Object
+
+	// the fake client only knows about the scale subresource of built-in
+	// kinds, so custom subresources are served by interceptors
+	k := k8s.New(cli, scheme, k8s.WithInterceptors(interceptor.Funcs{
+		SubResourceGet: func(ctx context.Context, c client.Client, sub string, obj client.Object, out client.Object, opts ...client.SubResourceGetOption) error {
+			if sub != "scale" {
+				return c.SubResource(sub).Get(ctx, obj, out, opts...)
+			}
+
+			u, ok := out.(*unstructured.Unstructured)
+			g.Expect(ok).Should(BeTrue())
+			g.Expect(obj.GetObjectKind().GroupVersionKind().Kind).Should(Equal("Widget"))
+
+			u.SetAPIVersion("autoscaling/v1")
+			u.SetKind("Scale")
+			u.SetName(obj.GetName())
+
+			return unstructured.SetNestedField(u.Object, int64(3), "status", "replicas")
+		},
+		SubResourceUpdate: func(ctx context.Context, c client.Client, sub string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+			if sub != "scale" {
+				return c.SubResource(sub).Update(ctx, obj, opts...)
+			}
+
+			o := client.SubResourceUpdateOptions{}
+			o.ApplyOptions(opts)
+			updated = o.SubResourceBody
+
+			return nil
+		},
+	}))
+
+	widget := &unstructured.Unstructured{}
+	widget.SetAPIVersion("example.com/v1")
+	widget.SetKind("Widget")
+	widget.SetName("w")
+	widget.SetNamespace("ns")
+
+	scale, err := k.GetSubresource(t.Context(), widget, "scale")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(scale).Should(jq.Match(`.kind == "Scale" and .status.replicas == 3`))
+
+	body := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "autoscaling/v1",
+		"kind":       "Scale",
+		"spec":       map[string]any{"replicas": int64(5)},
+	}}
+
+	g.Expect(k.UpdateSubresource(t.Context(), widget, "scale", body)).Should(Succeed())
+	g.Expect(updated).Should(BeIdenticalTo(body))
+
+	deploy.Status.Replicas = 2
+	g.Expect(k.UpdateSubresource(t.Context(), deploy, "status", nil)).Should(Succeed())
+
+	out := appsv1.Deployment{}
+	g.Expect(cli.Get(t.Context(), client.ObjectKeyFromObject(deploy), &out)).Should(Succeed())
+	g.Expect(out.Status.Replicas).Should(BeEquivalentTo(2))
+
+	_, err = k.GetSubresource(t.Context(), deploy, "status")
+	g.Expect(err).Should(MatchError(ContainSubstring("unable to get subresource status of Deployment ns/app")))
+}
@@ -0,0 +1,126 @@
+package k8s
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// toObject converts the given actual to its unstructured representation.
+//
+//nolint:cyclop
+func toObject(in any) (map[string]any, error) {
+	switch v := in.(type) {
+	case nil:
+		return nil, errors.New("a Kubernetes object is expected, got nil")
+	case map[string]any:
+		return v, nil
+	case unstructured.Unstructured:
+		return v.Object, nil
+	case *unstructured.Unstructured:
+		if v == nil {
+			return nil, errors.New("a Kubernetes object is expected, got nil")
+		}
+
+		return v.Object, nil
+	case string:
+		return bytesToObject([]byte(v))
+	case []byte:
+		return bytesToObject(v)
+	case json.RawMessage:
+		return bytesToObject(v)
+	case runtime.Object:
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(v)
+		if err != nil {
+			return nil, &ConversionError{From: fmt.Sprintf("%T", v), To: "unstructured", Err: err}
+		}
+
+		return u, nil
+	default:
+		return nil, fmt.Errorf("unsupported type:\n%s", format.Object(in, 1))
+	}
+}
+
+func bytesToObject(in []byte) (map[string]any, error) {
+	data := make(map[string]any)
+	if err := json.Unmarshal(in, &data); err != nil {
+		return nil, &ConversionError{From: "JSON", To: "unstructured", Err: err}
+	}
+
+	return data, nil
+}
+
+// findCondition looks up the condition with the given type in the
+// .status.conditions of the given object.
+func findCondition(obj map[string]any, conditionType string) (map[string]any, bool) {
+	conditions, ok, err := unstructured.NestedSlice(obj, "status", "conditions")
+	if err != nil || !ok {
+		return nil, false
+	}
+
+	for i := range conditions {
+		c, ok := conditions[i].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if c["type"] == conditionType {
+			return c, true
+		}
+	}
+
+	return nil, false
+}
+
+// podSpec locates the pod spec of the given object: the .spec of a Pod, the
+// .spec.jobTemplate.spec.template.spec of a CronJob, the .template.spec of
+// a PodTemplate or the .spec.template.spec of any other kind embedding a
+// PodTemplateSpec (i.e. Deployment, StatefulSet, DaemonSet, Job).
+func podSpec(obj map[string]any) (map[string]any, error) {
+	kind, _, _ := unstructured.NestedString(obj, "kind")
+
+	var paths [][]string
+
+	switch kind {
+	case "Pod":
+		paths = [][]string{{"spec"}}
+	case "CronJob":
+		paths = [][]string{{"spec", "jobTemplate", "spec", "template", "spec"}}
+	case "PodTemplate":
+		paths = [][]string{{"template", "spec"}}
+	default:
+		// the kind is not known for typed objects without TypeMeta, hence
+		// fall back to any path a pod spec can be found at
+		paths = [][]string{
+			{"spec", "template", "spec"},
+			{"spec", "jobTemplate", "spec", "template", "spec"},
+			{"template", "spec"},
+			{"spec"},
+		}
+	}
+
+	for _, path := range paths {
+		spec, ok, err := unstructured.NestedMap(obj, path...)
+		if err != nil || !ok {
+			continue
+		}
+
+		if _, ok := spec["containers"]; ok {
+			return spec, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unable to find a pod spec in %s", kindOf(obj))
+}
+
+func kindOf(obj map[string]any) string {
+	if kind, ok, _ := unstructured.NestedString(obj, "kind"); ok && kind != "" {
+		return kind
+	}
+
+	return "object"
+}
@@ -0,0 +1,245 @@
+package k8s
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const alphaNum = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// Template renders the YAML files matching the given glob pattern (or held
+// by the given directory) as Go templates and decodes the resulting objects,
+// so that parametrized manifests can be generated inline rather than kept as
+// near-duplicate files. Values are merged in order, later ones overriding
+// earlier ones, which allows per-test overrides of a common set of values:
+//
+//	objects, err := k8s.Template("testdata/app.yaml", defaults, map[string]any{"Image": image})
+//
+// Besides the Go template builtins, templates can use a subset of the sprig
+// functions, see TemplateFuncs.
+func Template(pattern string, values ...map[string]any) ([]*unstructured.Unstructured, error) {
+	files, err := fixtureFiles(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	data := mergeValues(values...)
+
+	var objects []*unstructured.Unstructured
+
+	for _, file := range files {
+		o, err := loadFixture(file, data)
+		if err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, o...)
+	}
+
+	return objects, nil
+}
+
+// TemplateFuncs returns the functions available to the templates rendered by
+// Template and ApplyFixtures, a subset of the sprig ones:
+//
+//   - strings: upper, lower, trim, trimPrefix, trimSuffix, replace, contains,
+//     hasPrefix, hasSuffix, quote, squote, indent, nindent, join, split
+//   - defaults: default, required, empty, coalesce, ternary
+//   - encoding: b64enc, b64dec, toJson, toYaml
+//   - collections: list, dict
+//   - misc: env, randAlphaNum
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"upper":        strings.ToUpper,
+		"lower":        strings.ToLower,
+		"trim":         strings.TrimSpace,
+		"trimPrefix":   func(prefix string, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trimSuffix":   func(suffix string, s string) string { return strings.TrimSuffix(s, suffix) },
+		"replace":      func(old string, replacement string, s string) string { return strings.ReplaceAll(s, old, replacement) },
+		"contains":     func(substr string, s string) bool { return strings.Contains(s, substr) },
+		"hasPrefix":    func(prefix string, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix":    func(suffix string, s string) bool { return strings.HasSuffix(s, suffix) },
+		"quote":        func(v any) string { return fmt.Sprintf("%q", fmt.Sprint(v)) },
+		"squote":       func(v any) string { return "'" + fmt.Sprint(v) + "'" },
+		"indent":       indent,
+		"nindent":      func(spaces int, s string) string { return "\n" + indent(spaces, s) },
+		"join":         join,
+		"split":        func(sep string, s string) []string { return strings.Split(s, sep) },
+		"default":      func(def any, v any) any { return ternary(v, def, !empty(v)) },
+		"required":     required,
+		"empty":        empty,
+		"coalesce":     coalesce,
+		"ternary":      ternary,
+		"b64enc":       func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) },
+		"b64dec":       b64dec,
+		"toJson":       toJSONString,
+		"toYaml":       toYAMLString,
+		"list":         func(v ...any) []any { return v },
+		"dict":         dict,
+		"env":          os.Getenv,
+		"randAlphaNum": randAlphaNum,
+	}
+}
+
+// mergeValues deep merges the given values, later ones overriding earlier
+// ones.
+func mergeValues(values ...map[string]any) map[string]any {
+	out := make(map[string]any)
+
+	for _, v := range values {
+		mergeInto(out, v)
+	}
+
+	return out
+}
+
+func mergeInto(dst map[string]any, src map[string]any) {
+	for k, v := range src {
+		s, ok := v.(map[string]any)
+		if !ok {
+			dst[k] = v
+
+			continue
+		}
+
+		d, ok := dst[k].(map[string]any)
+		if !ok {
+			d = make(map[string]any, len(s))
+		} else {
+			d = maps.Clone(d)
+		}
+
+		mergeInto(d, s)
+		dst[k] = d
+	}
+}
+
+func indent(spaces int, s string) string {
+	pad := strings.Repeat(" ", spaces)
+
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+}
+
+func join(sep string, v any) string {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return fmt.Sprint(v)
+	}
+
+	items := make([]string, 0, rv.Len())
+	for i := range rv.Len() {
+		items = append(items, fmt.Sprint(rv.Index(i).Interface()))
+	}
+
+	return strings.Join(items, sep)
+}
+
+func required(message string, v any) (any, error) {
+	if empty(v) {
+		return nil, errors.New(message)
+	}
+
+	return v, nil
+}
+
+func empty(v any) bool {
+	if v == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return rv.IsNil()
+	default:
+		return rv.IsZero()
+	}
+}
+
+func coalesce(v ...any) any {
+	for _, item := range v {
+		if !empty(item) {
+			return item
+		}
+	}
+
+	return nil
+}
+
+func ternary(whenTrue any, whenFalse any, condition bool) any {
+	if condition {
+		return whenTrue
+	}
+
+	return whenFalse
+}
+
+func b64dec(s string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode base64 value: %w", err)
+	}
+
+	return string(data), nil
+}
+
+func toJSONString(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal value to JSON: %w", err)
+	}
+
+	return string(data), nil
+}
+
+func toYAMLString(v any) (string, error) {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal value to YAML: %w", err)
+	}
+
+	return strings.TrimSuffix(string(data), "\n"), nil
+}
+
+func dict(v ...any) (map[string]any, error) {
+	if len(v)%2 != 0 {
+		return nil, errors.New("dict requires an even number of arguments")
+	}
+
+	out := make(map[string]any, len(v)/2)
+	for i := 0; i < len(v); i += 2 {
+		out[fmt.Sprint(v[i])] = v[i+1]
+	}
+
+	return out, nil
+}
+
+func randAlphaNum(n int) (string, error) {
+	out := make([]byte, n)
+
+	for i := range out {
+		idx, err := rand.Int(rand.Reader, big.NewInt(int64(len(alphaNum))))
+		if err != nil {
+			return "", fmt.Errorf("unable to generate random string: %w", err)
+		}
+
+		out[i] = alphaNum[idx.Int64()]
+	}
+
+	return string(out), nil
+}
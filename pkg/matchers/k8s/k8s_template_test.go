@@ -0,0 +1,73 @@
+package k8s_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+
+	. "github.com/onsi/gomega"
+)
+
+const templateApp = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Name }}-{{ randAlphaNum 5 }}
+  namespace: {{ .Namespace | lower }}
+  labels: {{ dict "app" .Name "tier" (.Tier | default "backend") | toJson }}
+spec:
+  replicas: {{ .Replicas }}
+  template:
+    spec:
+      containers:
+      - name: {{ .Name }}
+        image: {{ printf "%s:%s" (required "image is required" .Image.Repository) (.Image.Tag | default "latest") | quote }}
+        args: {{ list "--verbose" (printf "--name=%s" .Name) | toJson }}
+        env:
+        - name: TOKEN
+          value: {{ "secret" | b64enc }}
+`
+
+func TestTemplate(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	file := filepath.Join(t.TempDir(), "app.yaml")
+	g.Expect(os.WriteFile(file, []byte(templateApp), 0o600)).Should(Succeed())
+
+	defaults := map[string]any{
+		"Name":      "app",
+		"Namespace": "NS",
+		"Tier":      "",
+		"Replicas":  1,
+		"Image":     map[string]any{"Repository": "quay.io/app", "Tag": ""},
+	}
+
+	objects, err := k8s.Template(file, defaults, map[string]any{
+		"Replicas": 3,
+		"Image":    map[string]any{"Tag": "v1"},
+	})
+
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(objects).Should(HaveLen(1))
+	g.Expect(objects[0]).Should(And(
+		jq.Match(`.metadata.name | test("^app-[a-z0-9]{5}$")`),
+		jq.Match(`.metadata.namespace == "ns"`),
+		jq.Match(`.metadata.labels == {"app": "app", "tier": "backend"}`),
+		jq.Match(`.spec.replicas == 3`),
+		jq.Match(`.spec.template.spec.containers[0].image == "quay.io/app:v1"`),
+		jq.Match(`.spec.template.spec.containers[0].args == ["--verbose", "--name=app"]`),
+		jq.Match(`.spec.template.spec.containers[0].env[0].value == "c2VjcmV0"`),
+	))
+
+	objects, err = k8s.Template(file, defaults)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(objects[0]).Should(jq.Match(`.spec.template.spec.containers[0].image == "quay.io/app:latest"`))
+
+	_, err = k8s.Template(file, defaults, map[string]any{"Image": map[string]any{"Repository": ""}})
+	g.Expect(err).Should(MatchError(ContainSubstring("image is required")))
+}
@@ -0,0 +1,209 @@
+package k8s
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// HaveValidTLSCert succeeds if the actual Secret holds, in its tls.crt and
+// tls.key entries, a certificate and a matching private key, and if the
+// certificate is currently valid. The requirements can be extended with
+// ValidFor, WithDNSNames and WithIPAddresses, i.e.:
+//
+//	Eventually(u.Get("v1/Secret", key)).
+//	    WithContext(ctx).
+//	    Should(k8s.HaveValidTLSCert().ValidFor(24 * time.Hour).WithDNSNames("app.ns.svc"))
+func HaveValidTLSCert() *TLSCertMatcher {
+	return &TLSCertMatcher{}
+}
+
+var _ types.GomegaMatcher = &TLSCertMatcher{}
+
+// TLSCertMatcher is the matcher returned by HaveValidTLSCert.
+type TLSCertMatcher struct {
+	margin      time.Duration
+	dnsNames    []string
+	ipAddresses []string
+
+	failure string
+}
+
+// ValidFor requires the certificate not to expire within the given margin.
+func (matcher *TLSCertMatcher) ValidFor(margin time.Duration) *TLSCertMatcher {
+	matcher.margin = margin
+
+	return matcher
+}
+
+// WithDNSNames requires the certificate to include the given DNS names among
+// its subject alternative names.
+func (matcher *TLSCertMatcher) WithDNSNames(names ...string) *TLSCertMatcher {
+	matcher.dnsNames = append(matcher.dnsNames, names...)
+
+	return matcher
+}
+
+// WithIPAddresses requires the certificate to include the given IP
+// addresses among its subject alternative names.
+func (matcher *TLSCertMatcher) WithIPAddresses(ips ...string) *TLSCertMatcher {
+	matcher.ipAddresses = append(matcher.ipAddresses, ips...)
+
+	return matcher
+}
+
+//nolint:cyclop
+func (matcher *TLSCertMatcher) Match(actual interface{}) (bool, error) {
+	obj, err := toObject(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.failure = ""
+
+	crt, err := secretValue(obj, corev1.TLSCertKey)
+	if err != nil {
+		return false, err
+	}
+
+	key, err := secretValue(obj, corev1.TLSPrivateKeyKey)
+	if err != nil {
+		return false, err
+	}
+
+	if crt == "" || key == "" {
+		matcher.failure = fmt.Sprintf("Expected Secret to hold a TLS certificate, but %s or %s is missing", corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+
+		return false, nil
+	}
+
+	pair, err := tls.X509KeyPair([]byte(crt), []byte(key))
+	if err != nil {
+		matcher.failure = "Expected Secret to hold a valid TLS key pair, but: " + err.Error()
+
+		return false, nil
+	}
+
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return false, fmt.Errorf("unable to parse %s: %w", corev1.TLSCertKey, err)
+	}
+
+	now := time.Now()
+
+	if now.Before(cert.NotBefore) {
+		matcher.failure = fmt.Sprintf("Expected certificate %q to be valid, but it is not valid before %s", cert.Subject, cert.NotBefore.Format(time.RFC3339))
+
+		return false, nil
+	}
+
+	if now.Add(matcher.margin).After(cert.NotAfter) {
+		matcher.failure = fmt.Sprintf("Expected certificate %q to be valid for %s, but it expires at %s", cert.Subject, matcher.margin, cert.NotAfter.Format(time.RFC3339))
+
+		return false, nil
+	}
+
+	if missing := missingNames(matcher.dnsNames, cert.DNSNames); len(missing) > 0 {
+		matcher.failure = fmt.Sprintf("Expected certificate %q to include DNS names %q, but it only includes %q", cert.Subject, missing, cert.DNSNames)
+
+		return false, nil
+	}
+
+	ips := make([]string, 0, len(cert.IPAddresses))
+	for _, ip := range cert.IPAddresses {
+		ips = append(ips, ip.String())
+	}
+
+	expectedIPs := make([]string, 0, len(matcher.ipAddresses))
+	for _, ip := range matcher.ipAddresses {
+		parsed := net.ParseIP(ip)
+		if parsed == nil {
+			return false, fmt.Errorf("invalid IP address %s", ip)
+		}
+
+		expectedIPs = append(expectedIPs, parsed.String())
+	}
+
+	if missing := missingNames(expectedIPs, ips); len(missing) > 0 {
+		matcher.failure = fmt.Sprintf("Expected certificate %q to include IP addresses %q, but it only includes %q", cert.Subject, missing, ips)
+
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (matcher *TLSCertMatcher) FailureMessage(_ interface{}) string {
+	return matcher.failure
+}
+
+func (matcher *TLSCertMatcher) NegatedFailureMessage(_ interface{}) string {
+	return "Expected Secret not to hold a valid TLS certificate" + matcher.describe()
+}
+
+func (matcher *TLSCertMatcher) describe() string {
+	var constraints []string
+
+	if matcher.margin > 0 {
+		constraints = append(constraints, "valid for "+matcher.margin.String())
+	}
+
+	if len(matcher.dnsNames) > 0 {
+		constraints = append(constraints, fmt.Sprintf("DNS names %q", matcher.dnsNames))
+	}
+
+	if len(matcher.ipAddresses) > 0 {
+		constraints = append(constraints, fmt.Sprintf("IP addresses %q", matcher.ipAddresses))
+	}
+
+	if len(constraints) == 0 {
+		return ""
+	}
+
+	return " (" + strings.Join(constraints, ", ") + ")"
+}
+
+// secretValue returns the value of the given key of a Secret, looking it up
+// in .data, base64-decoded, and in .stringData. It returns an empty string if
+// the key is not found.
+func secretValue(obj map[string]any, key string) (string, error) {
+	for _, field := range []string{"data", "stringData"} {
+		v, ok, err := unstructured.NestedFieldNoCopy(obj, field, key)
+		if err != nil {
+			return "", fmt.Errorf("unable to read .%s: %w", field, err)
+		}
+
+		if !ok {
+			continue
+		}
+
+		value, err := decodeData(v, field == "data")
+		if err != nil {
+			return "", fmt.Errorf("unable to decode .%s.%s: %w", field, key, err)
+		}
+
+		return value, nil
+	}
+
+	return "", nil
+}
+
+func missingNames(expected []string, actual []string) []string {
+	var missing []string
+
+	for _, name := range expected {
+		if !slices.Contains(actual, name) {
+			missing = append(missing, name)
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,110 @@
+package k8s_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func newTLSSecret(g *WithT, notBefore time.Time, notAfter time.Time) *corev1.Secret {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "app"},
+		DNSNames:     []string{"app.ns.svc", "app.ns.svc.cluster.local"},
+		IPAddresses:  []net.IP{net.ParseIP("10.0.0.1")},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: "ns"},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		},
+	}
+}
+
+func TestHaveValidTLSCert(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	now := time.Now()
+	secret := newTLSSecret(g, now.Add(-time.Hour), now.Add(48*time.Hour))
+
+	g.Expect(secret).Should(k8s.HaveValidTLSCert())
+	g.Expect(secret).Should(k8s.HaveValidTLSCert().ValidFor(24 * time.Hour))
+	g.Expect(secret).Should(k8s.HaveValidTLSCert().WithDNSNames("app.ns.svc").WithIPAddresses("10.0.0.1"))
+	g.Expect(secret).ShouldNot(k8s.HaveValidTLSCert().ValidFor(72 * time.Hour))
+	g.Expect(secret).ShouldNot(k8s.HaveValidTLSCert().WithDNSNames("other.ns.svc"))
+	g.Expect(secret).ShouldNot(k8s.HaveValidTLSCert().WithIPAddresses("10.0.0.2"))
+
+	// stringData is honored as well
+	g.Expect(&corev1.Secret{StringData: map[string]string{
+		corev1.TLSCertKey:       string(secret.Data[corev1.TLSCertKey]),
+		corev1.TLSPrivateKeyKey: string(secret.Data[corev1.TLSPrivateKeyKey]),
+	}}).Should(k8s.HaveValidTLSCert())
+
+	g.Expect(newTLSSecret(g, now.Add(-2*time.Hour), now.Add(-time.Hour))).ShouldNot(k8s.HaveValidTLSCert())
+	g.Expect(newTLSSecret(g, now.Add(time.Hour), now.Add(2*time.Hour))).ShouldNot(k8s.HaveValidTLSCert())
+}
+
+func TestHaveValidTLSCertFailureMessage(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	now := time.Now()
+	secret := newTLSSecret(g, now.Add(-time.Hour), now.Add(time.Hour))
+	other := newTLSSecret(g, now.Add(-time.Hour), now.Add(time.Hour))
+
+	m := k8s.HaveValidTLSCert().ValidFor(24 * time.Hour)
+	g.Expect(m.Match(secret)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(HavePrefix(`Expected certificate "CN=app" to be valid for 24h0m0s, but it expires at`))
+
+	m = k8s.HaveValidTLSCert().WithDNSNames("app.ns.svc", "other.ns.svc")
+	g.Expect(m.Match(secret)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(Equal(`Expected certificate "CN=app" to include DNS names ["other.ns.svc"], but it only includes ["app.ns.svc" "app.ns.svc.cluster.local"]`))
+
+	mismatched := secret.DeepCopy()
+	mismatched.Data[corev1.TLSPrivateKeyKey] = other.Data[corev1.TLSPrivateKeyKey]
+
+	m = k8s.HaveValidTLSCert()
+	g.Expect(m.Match(mismatched)).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(HavePrefix("Expected Secret to hold a valid TLS key pair, but: "))
+
+	m = k8s.HaveValidTLSCert()
+	g.Expect(m.Match(&corev1.Secret{})).Should(BeFalse())
+	g.Expect(m.FailureMessage(nil)).Should(Equal("Expected Secret to hold a TLS certificate, but tls.crt or tls.key is missing"))
+
+	m = k8s.HaveValidTLSCert().ValidFor(time.Minute)
+	g.Expect(m.Match(secret)).Should(BeTrue())
+	g.Expect(m.NegatedFailureMessage(nil)).Should(Equal("Expected Secret not to hold a valid TLS certificate (valid for 1m0s)"))
+
+	_, err := k8s.HaveValidTLSCert().WithIPAddresses("not-an-ip").Match(secret)
+	g.Expect(err).Should(MatchError("invalid IP address not-an-ip"))
+}
@@ -0,0 +1,83 @@
+package k8s
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ServiceAccountToken is a token issued by a TokenRequest.
+type ServiceAccountToken struct {
+	// Token is the signed JWT.
+	Token string
+	// Expiration is the time the token expires at.
+	Expiration time.Time
+	// Claims are the claims of the JWT, decoded without verifying its
+	// signature, so that they can be matched with jq.Match.
+	Claims map[string]any
+}
+
+// TokenFor returns a function issuing a TokenRequest for the ServiceAccount
+// with the given key, bound to the given audiences (the API server audiences
+// if none), i.e.:
+//
+//	token, err := k.TokenFor(key, "vault")(ctx)
+//	Expect(err).NotTo(HaveOccurred())
+//	Expect(token.Claims).Should(jq.Match(`.aud == ["vault"]`))
+func (m *Matcher) TokenFor(key client.ObjectKey, audiences ...string) func(ctx context.Context) (*ServiceAccountToken, error) {
+	return func(ctx context.Context) (*ServiceAccountToken, error) {
+		sa := corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		}
+
+		request := authenticationv1.TokenRequest{
+			Spec: authenticationv1.TokenRequestSpec{
+				Audiences: audiences,
+			},
+		}
+
+		if err := m.client.SubResource("token").Create(ctx, &sa, &request); err != nil {
+			return nil, newOpError("request a token for", corev1.SchemeGroupVersion.WithKind("ServiceAccount"), key, err)
+		}
+
+		claims, err := decodeClaims(request.Status.Token)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ServiceAccountToken{
+			Token:      request.Status.Token,
+			Expiration: request.Status.ExpirationTimestamp.Time,
+			Claims:     claims,
+		}, nil
+	}
+}
+
+// decodeClaims decodes the payload of the given JWT.
+func decodeClaims(token string) (map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("unable to decode the token claims: not a JWT")
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode the token claims: %w", err)
+	}
+
+	claims := make(map[string]any)
+	if err := json.Unmarshal(data, &claims); err != nil {
+		return nil, fmt.Errorf("unable to decode the token claims: %w", err)
+	}
+
+	return claims, nil
+}
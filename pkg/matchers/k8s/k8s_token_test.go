@@ -0,0 +1,72 @@
+package k8s_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestTokenFor(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	expiration := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	// the fake client does not issue tokens, hence mint an unsigned one
+	cli := fake.NewClientBuilder().WithScheme(scheme).Build()
+	k := k8s.New(cli, scheme, k8s.WithInterceptors(interceptor.Funcs{
+		SubResourceCreate: func(_ context.Context, _ client.Client, sub string, obj client.Object, sr client.Object, _ ...client.SubResourceCreateOption) error {
+			req, ok := sr.(*authenticationv1.TokenRequest)
+			if sub != "token" || !ok {
+				return errors.New("unexpected subresource")
+			}
+
+			if obj.GetName() == "broken" {
+				req.Status.Token = "not-a-jwt"
+
+				return nil
+			}
+
+			claims, err := json.Marshal(map[string]any{
+				"aud": req.Spec.Audiences,
+				"sub": "system:serviceaccount:" + obj.GetNamespace() + ":" + obj.GetName(),
+			})
+			if err != nil {
+				return err
+			}
+
+			req.Status.Token = "e30." + base64.RawURLEncoding.EncodeToString(claims) + ".c2ln"
+			req.Status.ExpirationTimestamp = metav1.NewTime(expiration)
+
+			return nil
+		},
+	}))
+
+	token, err := k.TokenFor(client.ObjectKey{Namespace: "ns", Name: "app"}, "vault")(t.Context())
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(token.Token).Should(HavePrefix("e30."))
+	g.Expect(token.Expiration).Should(BeTemporally("==", expiration))
+	g.Expect(token.Claims).Should(jq.Match(`.aud == ["vault"] and .sub == "system:serviceaccount:ns:app"`))
+
+	_, err = k.TokenFor(client.ObjectKey{Namespace: "ns", Name: "broken"})(t.Context())
+	g.Expect(err).Should(MatchError("unable to decode the token claims: not a JWT"))
+}
@@ -0,0 +1,131 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// how long the deletion of a tracked object is waited for, unless a
+	// timeout is set with WithDefaultTimeout
+	defaultCleanupTimeout = time.Minute
+)
+
+// Tracked returns a copy of the given Matcher whose Create and ApplyAs
+// register the deletion of the objects they create with t.Cleanup, so that
+// a test failing midway does not leak resources in a shared cluster:
+//
+//	k := k8s.Tracked(t, k)
+//
+//	Expect(k.Create(ctx, &ns)).To(Succeed())
+//	Expect(k.Create(ctx, &deployment)).To(Succeed())
+//
+// The objects are deleted in the reverse order of their creation, with
+// background propagation, each deletion waiting for the object to be gone,
+// i.e. for its finalizers to complete, before the next one starts. Objects
+// that cannot be deleted within the timeout set with WithDefaultTimeout, one
+// minute by default, are reported as test errors.
+func Tracked(t testing.TB, m *Matcher) *Matcher {
+	c := *m
+	c.tracker = &tracker{
+		t:       t,
+		tracked: make(map[trackedKey]struct{}),
+	}
+
+	return &c
+}
+
+// tracker records the objects whose deletion has been registered, as
+// applying the same object several times must not delete it twice.
+type tracker struct {
+	t testing.TB
+
+	lock    sync.Mutex
+	tracked map[trackedKey]struct{}
+}
+
+type trackedKey struct {
+	gvk schema.GroupVersionKind
+	key client.ObjectKey
+}
+
+// track registers the deletion of the given object, if the Matcher has been
+// created by Tracked.
+func (m *Matcher) track(obj client.Object, gvk schema.GroupVersionKind) {
+	if m.tracker == nil {
+		return
+	}
+
+	k := trackedKey{gvk: gvk, key: client.ObjectKeyFromObject(obj)}
+
+	m.tracker.lock.Lock()
+	defer m.tracker.lock.Unlock()
+
+	if _, ok := m.tracker.tracked[k]; ok {
+		return
+	}
+
+	m.tracker.tracked[k] = struct{}{}
+
+	m.tracker.t.Cleanup(func() {
+		if err := m.deleteAndWait(k); err != nil {
+			m.tracker.t.Errorf("unable to clean up: %v", err)
+		}
+	})
+}
+
+// deleteAndWait deletes the object identified by the given key and waits
+// for it to be gone.
+func (m *Matcher) deleteAndWait(k trackedKey) error {
+	timeout := m.timeout
+	if timeout <= 0 {
+		timeout = defaultCleanupTimeout
+	}
+
+	polling := m.polling
+	if polling <= 0 {
+		polling = defaultWaitPolling
+	}
+
+	// the test context is already canceled when cleanup functions run
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(m.ctx), timeout)
+	defer cancel()
+
+	u := unstructured.Unstructured{}
+	u.SetGroupVersionKind(k.gvk)
+	u.SetNamespace(k.key.Namespace)
+	u.SetName(k.key.Name)
+
+	err := m.client.Delete(ctx, &u, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		return newOpError("delete", k.gvk, k.key, err)
+	}
+
+	ticker := time.NewTicker(polling)
+	defer ticker.Stop()
+
+	for {
+		if err := m.client.Get(ctx, k.key, &u); apierrors.IsNotFound(err) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s %s not deleted within %s", k.gvk.Kind, k.key, timeout)
+		case <-ticker.C:
+		}
+	}
+}
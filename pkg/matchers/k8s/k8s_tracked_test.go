@@ -0,0 +1,102 @@
+package k8s_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	. "github.com/onsi/gomega"
+)
+
+// cleanupT records the cleanup functions and the errors reported through
+// it, rather than failing the test.
+type cleanupT struct {
+	testing.TB
+
+	cleanups []func()
+	errors   []string
+}
+
+func (t *cleanupT) Cleanup(fn func()) {
+	t.cleanups = append(t.cleanups, fn)
+}
+
+func (t *cleanupT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestTracked(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	var (
+		lock    sync.Mutex
+		deleted []string
+	)
+
+	k := k8s.NewFakeWithOptions([]k8s.Option{k8s.WithInterceptors(interceptor.Funcs{
+		Delete: func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			lock.Lock()
+			deleted = append(deleted, obj.GetName())
+			lock.Unlock()
+
+			return cli.Delete(ctx, obj, opts...)
+		},
+	})})
+
+	t.Run("create", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tk := k8s.Tracked(t, k)
+
+		g.Expect(tk.Create(t.Context(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "first", Namespace: "ns"}})).Should(Succeed())
+		g.Expect(tk.Create(t.Context(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "second", Namespace: "ns"}})).Should(Succeed())
+
+		applied := func() *corev1.ConfigMap {
+			return &corev1.ConfigMap{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+				ObjectMeta: metav1.ObjectMeta{Name: "third", Namespace: "ns"},
+			}
+		}
+
+		// applying the same object again does not register another deletion
+		g.Expect(tk.ApplyAs(t.Context(), applied(), "test", false)).Should(Succeed())
+		g.Expect(tk.ApplyAs(t.Context(), applied(), "test", false)).Should(Succeed())
+
+		// objects created through the original Matcher are not tracked
+		g.Expect(k.Create(t.Context(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "untracked", Namespace: "ns"}})).Should(Succeed())
+	})
+
+	g.Expect(deleted).Should(Equal([]string{"third", "second", "first"}))
+	g.Expect(k.Objects(&corev1.ConfigMapList{})()).Should(jq.Match(`[.items[].metadata.name] == ["untracked"]`))
+}
+
+func TestTrackedWaitsForDeletion(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	ct := &cleanupT{TB: t}
+
+	k := k8s.Tracked(ct, k8s.NewFakeWithOptions([]k8s.Option{k8s.WithDefaultTimeout(100 * time.Millisecond)}))
+
+	// the finalizer is never removed, hence the object is never gone
+	stuck := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "stuck", Namespace: "ns", Finalizers: []string{"example.com/never"}}}
+	g.Expect(k.Create(t.Context(), &stuck)).Should(Succeed())
+	g.Expect(ct.cleanups).Should(HaveLen(1))
+
+	ct.cleanups[0]()
+
+	g.Expect(ct.errors).Should(ConsistOf("unable to clean up: ConfigMap ns/stuck not deleted within 100ms"))
+	g.Expect(k.Object(&stuck)()).Should(jq.Match(`.metadata.deletionTimestamp != null`))
+}
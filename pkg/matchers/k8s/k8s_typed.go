@@ -0,0 +1,50 @@
+package k8s
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Typed retrieves objects of type T, i.e. *corev1.ConfigMap, for users who
+// prefer standard Gomega field matchers over jq:
+//
+//	cms := k8s.NewTyped[*corev1.ConfigMap](cli)
+//
+//	Eventually(cms.GetFn(key)).
+//	    WithContext(ctx).
+//	    Should(HaveField("Data", HaveKeyWithValue("foo", "bar")))
+type Typed[T client.Object] struct {
+	client client.Client
+}
+
+// NewTyped creates a Typed for objects of type T, which must be a pointer.
+func NewTyped[T client.Object](cli client.Client) *Typed[T] {
+	return &Typed[T]{
+		client: cli,
+	}
+}
+
+// Get retrieves the object identified by the given key.
+func (t *Typed[T]) Get(ctx context.Context, key client.ObjectKey) (T, error) {
+	var zero T
+
+	obj, err := newObject[T]()
+	if err != nil {
+		return zero, err
+	}
+
+	if err := t.client.Get(ctx, key, obj); err != nil {
+		return zero, newObjectError(t.client, "get", obj, key, err)
+	}
+
+	return obj, nil
+}
+
+// GetFn returns a pollable function retrieving the object identified by the
+// given key.
+func (t *Typed[T]) GetFn(key client.ObjectKey) func(ctx context.Context) (T, error) {
+	return func(ctx context.Context) (T, error) {
+		return t.Get(ctx, key)
+	}
+}
@@ -0,0 +1,47 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestTyped(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"},
+			Data:       map[string]string{"foo": "bar"},
+		}).
+		Build()
+
+	cms := k8s.NewTyped[*corev1.ConfigMap](cli)
+	key := client.ObjectKey{Namespace: "ns", Name: "cm"}
+
+	cm, err := cms.Get(t.Context(), key)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(cm.Data).Should(HaveKeyWithValue("foo", "bar"))
+
+	g.Eventually(cms.GetFn(key)).
+		WithContext(t.Context()).
+		Should(HaveField("Data", HaveKeyWithValue("foo", "bar")))
+
+	_, err = cms.Get(t.Context(), client.ObjectKey{Namespace: "ns", Name: "missing"})
+	g.Expect(err).Should(Satisfy(apierrors.IsNotFound))
+}
@@ -0,0 +1,85 @@
+package k8s
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// UnstructuredMatcher provides access to Kubernetes resources identified by
+// a resource string rather than by a typed object. A resource can be given
+// either as group/version/Kind (i.e. "apps/v1/Deployment", "v1/ConfigMap"),
+// or, when the Matcher has been configured with a discovery client, as a
+// kind, plural, singular or short name optionally qualified by its group
+// (i.e. "Deployment", "deployments", "deploy", "deployments.apps").
+type UnstructuredMatcher struct {
+	matcher *Matcher
+}
+
+// Resolve returns the GroupVersionKind the given resource string refers to.
+func (u *UnstructuredMatcher) Resolve(resource string) (schema.GroupVersionKind, error) {
+	return u.matcher.resolver.resolve(resource)
+}
+
+// Get returns a pollable function fetching the resource with the given key.
+func (u *UnstructuredMatcher) Get(resource string, key client.ObjectKey) func(ctx context.Context) (*unstructured.Unstructured, error) {
+	return func(ctx context.Context) (*unstructured.Unstructured, error) {
+		gvk, err := u.Resolve(resource)
+		if err != nil {
+			return nil, err
+		}
+
+		obj := unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+
+		if err := u.matcher.client.Get(ctx, key, &obj); err != nil {
+			return nil, newOpError("get", gvk, key, err)
+		}
+
+		return &obj, nil
+	}
+}
+
+// List returns a pollable function listing the resources matching the given
+// options.
+func (u *UnstructuredMatcher) List(resource string, opts ...client.ListOption) func(ctx context.Context) (*unstructured.UnstructuredList, error) {
+	return func(ctx context.Context) (*unstructured.UnstructuredList, error) {
+		gvk, err := u.Resolve(resource)
+		if err != nil {
+			return nil, err
+		}
+
+		list := unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+		if err := u.matcher.client.List(ctx, &list, opts...); err != nil {
+			return nil, newOpError("list", gvk, listKey(opts), err)
+		}
+
+		return &list, nil
+	}
+}
+
+// Delete returns a pollable function deleting the resource with the given
+// key.
+func (u *UnstructuredMatcher) Delete(resource string, key client.ObjectKey, opts ...client.DeleteOption) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		gvk, err := u.Resolve(resource)
+		if err != nil {
+			return err
+		}
+
+		obj := unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		obj.SetName(key.Name)
+		obj.SetNamespace(key.Namespace)
+
+		if err := u.matcher.client.Delete(ctx, &obj, opts...); err != nil {
+			return newOpError("delete", gvk, key, err)
+		}
+
+		return nil
+	}
+}
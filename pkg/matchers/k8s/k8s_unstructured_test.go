@@ -0,0 +1,182 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery/cached/memory"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clienttesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func newDiscovery() *fakediscovery.FakeDiscovery {
+	return &fakediscovery.FakeDiscovery{
+		Fake: &clienttesting.Fake{
+			Resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "v1",
+					APIResources: []metav1.APIResource{
+						{Name: "configmaps", SingularName: "configmap", Kind: "ConfigMap", Namespaced: true, ShortNames: []string{"cm"}},
+						{Name: "pods", SingularName: "pod", Kind: "Pod", Namespaced: true, ShortNames: []string{"po"}},
+						{Name: "pods/status", Kind: "Pod", Namespaced: true},
+					},
+				},
+				{
+					GroupVersion: "apps/v1",
+					APIResources: []metav1.APIResource{
+						{Name: "deployments", SingularName: "deployment", Kind: "Deployment", Namespaced: true, ShortNames: []string{"deploy"}},
+						{Name: "statefulsets", SingularName: "statefulset", Kind: "StatefulSet", Namespaced: true, ShortNames: []string{"sts"}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestUnstructuredResolve(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	cli := fake.NewClientBuilder().Build()
+
+	deployment := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+	u := k8s.New(cli, cli.Scheme(), k8s.WithDiscovery(newDiscovery())).Unstructured()
+
+	for _, r := range []string{"apps/v1/Deployment", "Deployment", "deployment", "deployments", "deploy", "deployments.apps", "deployment.apps"} {
+		g.Expect(u.Resolve(r)).Should(Equal(deployment), r)
+	}
+
+	g.Expect(u.Resolve("v1/ConfigMap")).Should(Equal(corev1.SchemeGroupVersion.WithKind("ConfigMap")))
+	g.Expect(u.Resolve("cm")).Should(Equal(corev1.SchemeGroupVersion.WithKind("ConfigMap")))
+
+	_, err := u.Resolve("deploymnt")
+	g.Expect(err).Should(MatchError(ContainSubstring("deployment (apps/v1/Deployment)")))
+
+	_, err = u.Resolve("stateful")
+	g.Expect(err).Should(MatchError(ContainSubstring("statefulset (apps/v1/StatefulSet)")))
+
+	_, err = u.Resolve("foo")
+	g.Expect(err).Should(MatchError(ContainSubstring("no matching resource found")))
+
+	_, err = u.Resolve("a/b/c/d")
+	g.Expect(err).Should(HaveOccurred())
+
+	// without discovery only the explicit form is supported
+	u = k8s.New(cli, cli.Scheme()).Unstructured()
+
+	g.Expect(u.Resolve("apps/v1/Deployment")).Should(Equal(deployment))
+
+	_, err = u.Resolve("deploy")
+	g.Expect(err).Should(MatchError(ContainSubstring("no discovery client configured")))
+}
+
+func TestUnstructuredResolveRefresh(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	cli := fake.NewClientBuilder().Build()
+	dc := newDiscovery()
+
+	u := k8s.New(cli, cli.Scheme(),
+		k8s.WithDiscovery(memory.NewMemCacheClient(dc)),
+		k8s.WithDiscoveryRefreshInterval(0),
+	).Unstructured()
+
+	g.Expect(u.Resolve("deploy")).Should(Equal(appsv1.SchemeGroupVersion.WithKind("Deployment")))
+
+	_, err := u.Resolve("widget")
+	g.Expect(err).Should(MatchError(ContainSubstring("no matching resource found")))
+
+	// a CRD installed after the first lookup
+	dc.Resources = append(dc.Resources, &metav1.APIResourceList{
+		GroupVersion: "example.com/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "widgets", SingularName: "widget", Kind: "Widget", Namespaced: true},
+		},
+	})
+
+	g.Expect(u.Resolve("widget")).Should(Equal(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}))
+}
+
+func TestUnstructuredResolveRefreshInterval(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	cli := fake.NewClientBuilder().Build()
+	dc := newDiscovery()
+
+	u := k8s.New(cli, cli.Scheme(), k8s.WithDiscovery(dc)).Unstructured()
+
+	g.Expect(u.Resolve("deploy")).Should(Equal(appsv1.SchemeGroupVersion.WithKind("Deployment")))
+
+	calls := len(dc.Actions())
+
+	// repeated misses, i.e. a typo polled by Eventually, do not re-discover
+	// the API until the refresh interval has elapsed
+	for range 5 {
+		_, err := u.Resolve("widget")
+		g.Expect(err).Should(MatchError(ContainSubstring("no matching resource found")))
+	}
+
+	g.Expect(dc.Actions()).Should(HaveLen(calls))
+}
+
+func TestUnstructured(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"},
+				Spec:       appsv1.DeploymentSpec{Replicas: ptrTo[int32](3)},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "cm-1", Namespace: "ns"},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "cm-2", Namespace: "ns"},
+			},
+		).
+		Build()
+
+	u := k8s.New(cli, scheme, k8s.WithDiscovery(newDiscovery())).Unstructured()
+	key := client.ObjectKey{Namespace: "ns", Name: "app"}
+
+	g.Eventually(u.Get("deploy", key)).
+		WithContext(t.Context()).
+		Should(jq.Match(`.spec.replicas == 3`))
+
+	g.Expect(u.List("cm", client.InNamespace("ns"))(t.Context())).
+		Should(jq.Match(`.items | length == 2`))
+
+	g.Expect(u.Delete("apps/v1/Deployment", key)(t.Context())).Should(Succeed())
+
+	_, err := u.Get("deploy", key)(t.Context())
+	g.Expect(err).Should(Satisfy(apierrors.IsNotFound))
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,297 @@
+package k8s
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+// MountVolume succeeds if the pod spec of the actual object defines the given
+// volume and mounts it in a container (or init container), checking the
+// volume definition and the mounts consistently so that a volume defined but
+// mounted nowhere is reported as such. It works with Pods and any kind
+// embedding a PodTemplateSpec. Further expectations can be added with
+// InContainer, At, ReadOnly and the From* methods:
+//
+//	Expect(deployment).Should(k8s.MountVolume("config").At("/etc/app").FromConfigMap("app-config"))
+func MountVolume(name string) *VolumeMatcher {
+	return &VolumeMatcher{
+		name: name,
+	}
+}
+
+var _ types.GomegaMatcher = &VolumeMatcher{}
+
+// VolumeMatcher is the matcher returned by MountVolume.
+type VolumeMatcher struct {
+	name      string
+	container string
+	path      string
+	readOnly  bool
+	source    *volumeSource
+
+	failure string
+}
+
+// volumeSource identifies the object backing a volume, i.e. a ConfigMap.
+type volumeSource struct {
+	field string
+	key   string
+	name  string
+	kind  string
+}
+
+// InContainer only considers the mounts of the container (or init container)
+// with the given name, rather than those of any container of the pod spec.
+func (matcher *VolumeMatcher) InContainer(name string) *VolumeMatcher {
+	matcher.container = name
+
+	return matcher
+}
+
+// At requires the volume to be mounted at the given path.
+func (matcher *VolumeMatcher) At(path string) *VolumeMatcher {
+	matcher.path = path
+
+	return matcher
+}
+
+// ReadOnly requires the volume to be mounted read-only.
+func (matcher *VolumeMatcher) ReadOnly() *VolumeMatcher {
+	matcher.readOnly = true
+
+	return matcher
+}
+
+// FromConfigMap requires the volume to project the ConfigMap with the given
+// name.
+func (matcher *VolumeMatcher) FromConfigMap(name string) *VolumeMatcher {
+	matcher.source = &volumeSource{field: "configMap", key: "name", name: name, kind: kindConfigMap}
+
+	return matcher
+}
+
+// FromSecret requires the volume to project the Secret with the given name.
+func (matcher *VolumeMatcher) FromSecret(name string) *VolumeMatcher {
+	matcher.source = &volumeSource{field: "secret", key: "secretName", name: name, kind: kindSecret}
+
+	return matcher
+}
+
+// FromPersistentVolumeClaim requires the volume to be backed by the
+// PersistentVolumeClaim with the given name.
+func (matcher *VolumeMatcher) FromPersistentVolumeClaim(name string) *VolumeMatcher {
+	matcher.source = &volumeSource{field: "persistentVolumeClaim", key: "claimName", name: name, kind: "PersistentVolumeClaim"}
+
+	return matcher
+}
+
+func (matcher *VolumeMatcher) Match(actual interface{}) (bool, error) {
+	spec, err := toPodSpec(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.failure = ""
+
+	volumes, _ := spec["volumes"].([]any)
+
+	var (
+		volume map[string]any
+		names  []string
+	)
+
+	for i := range volumes {
+		v, ok := volumes[i].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		n, _ := v["name"].(string)
+		names = append(names, n)
+
+		if n == matcher.name && volume == nil {
+			volume = v
+		}
+	}
+
+	if volume == nil {
+		matcher.failure = format.Message(names, "to contain volume", matcher.name)
+
+		if containers := mountedBy(spec, matcher.name); len(containers) > 0 {
+			matcher.failure += fmt.Sprintf("\n\nthe volume is mounted by %s, but not defined", strings.Join(containers, ", "))
+		}
+
+		return false, nil
+	}
+
+	if matcher.source != nil {
+		ref, _ := volume[matcher.source.field].(map[string]any)
+		if n, _ := ref[matcher.source.key].(string); ref == nil || n != matcher.source.name {
+			matcher.failure = fmt.Sprintf("Expected volume %s to be backed by %s %s, but it is backed by %s",
+				matcher.name, matcher.source.kind, matcher.source.name, describeVolume(volume))
+
+			return false, nil
+		}
+	}
+
+	return matcher.matchMounts(spec), nil
+}
+
+// matchMounts checks the mounts of the volume, recording why none of them
+// satisfies the expectations.
+func (matcher *VolumeMatcher) matchMounts(spec map[string]any) bool {
+	var (
+		containers []string
+		mounts     []string
+	)
+
+	for _, field := range []string{"containers", "initContainers"} {
+		items, _ := spec[field].([]any)
+
+		for i := range items {
+			c, ok := items[i].(map[string]any)
+			if !ok {
+				continue
+			}
+
+			n, _ := c["name"].(string)
+			if matcher.container != "" && n != matcher.container {
+				continue
+			}
+
+			containers = append(containers, n)
+
+			vms, _ := c["volumeMounts"].([]any)
+
+			for j := range vms {
+				vm, ok := vms[j].(map[string]any)
+				if !ok || vm["name"] != matcher.name {
+					continue
+				}
+
+				path, _ := vm["mountPath"].(string)
+				readOnly, _ := vm["readOnly"].(bool)
+
+				if (matcher.path == "" || path == matcher.path) && (!matcher.readOnly || readOnly) {
+					return true
+				}
+
+				mount := fmt.Sprintf("%s in container %s", path, n)
+				if readOnly {
+					mount += " (read-only)"
+				}
+
+				mounts = append(mounts, mount)
+			}
+		}
+	}
+
+	switch {
+	case matcher.container != "" && len(containers) == 0:
+		matcher.failure = fmt.Sprintf("Expected container %s to mount volume %s, but there is no such container", matcher.container, matcher.name)
+	case len(mounts) == 0 && matcher.container != "":
+		matcher.failure = fmt.Sprintf("Expected container %s to mount volume %s, but the volume is not mounted by it", matcher.container, matcher.name)
+	case len(mounts) == 0:
+		matcher.failure = fmt.Sprintf("Expected volume %s to be mounted, but it is not mounted by any container", matcher.name)
+	default:
+		matcher.failure = fmt.Sprintf("Expected volume %s to be mounted%s, but it is mounted at:\n%s",
+			matcher.name, matcher.expectedMount(), strings.Join(mounts, "\n"))
+	}
+
+	return false
+}
+
+func (matcher *VolumeMatcher) expectedMount() string {
+	var expected []string
+
+	if matcher.path != "" {
+		expected = append(expected, "at "+matcher.path)
+	}
+
+	if matcher.readOnly {
+		expected = append(expected, "read-only")
+	}
+
+	if len(expected) == 0 {
+		return ""
+	}
+
+	return " " + strings.Join(expected, ", ")
+}
+
+func (matcher *VolumeMatcher) FailureMessage(_ interface{}) string {
+	return matcher.failure
+}
+
+func (matcher *VolumeMatcher) NegatedFailureMessage(_ interface{}) string {
+	msg := "Expected not to mount volume " + matcher.name + matcher.expectedMount()
+
+	if matcher.container != "" {
+		msg += " in container " + matcher.container
+	}
+
+	if matcher.source != nil {
+		msg += fmt.Sprintf(", backed by %s %s", matcher.source.kind, matcher.source.name)
+	}
+
+	return msg
+}
+
+// mountedBy returns the names of the containers mounting the given volume.
+func mountedBy(spec map[string]any, volume string) []string {
+	var containers []string
+
+	for _, field := range []string{"containers", "initContainers"} {
+		items, _ := spec[field].([]any)
+
+		for i := range items {
+			c, ok := items[i].(map[string]any)
+			if !ok {
+				continue
+			}
+
+			vms, _ := c["volumeMounts"].([]any)
+
+			if slices.ContainsFunc(vms, func(vm any) bool {
+				m, ok := vm.(map[string]any)
+
+				return ok && m["name"] == volume
+			}) {
+				n, _ := c["name"].(string)
+				containers = append(containers, n)
+			}
+		}
+	}
+
+	return containers
+}
+
+// describeVolume describes the source of the given volume, for failure
+// messages, i.e. "ConfigMap app-config" or "emptyDir".
+func describeVolume(volume map[string]any) string {
+	for _, s := range []volumeSource{
+		{field: "configMap", key: "name", kind: kindConfigMap},
+		{field: "secret", key: "secretName", kind: kindSecret},
+		{field: "persistentVolumeClaim", key: "claimName", kind: "PersistentVolumeClaim"},
+	} {
+		if ref, ok := volume[s.field].(map[string]any); ok {
+			return fmt.Sprintf("%s %v", s.kind, ref[s.key])
+		}
+	}
+
+	fields := make([]string, 0, len(volume))
+
+	for k := range volume {
+		if k != "name" {
+			fields = append(fields, k)
+		}
+	}
+
+	slices.Sort(fields)
+
+	return strings.Join(fields, ", ")
+}
@@ -0,0 +1,112 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func newVolumeDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{
+				{Name: "config", VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"}},
+				}},
+				{Name: "certs", VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{SecretName: "app-tls"},
+				}},
+				{Name: "data", VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "app-data"},
+				}},
+				{Name: "scratch", VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				}},
+			},
+			InitContainers: []corev1.Container{{
+				Name: "init",
+				VolumeMounts: []corev1.VolumeMount{
+					{Name: "data", MountPath: "/data"},
+				},
+			}},
+			Containers: []corev1.Container{{
+				Name: "app",
+				VolumeMounts: []corev1.VolumeMount{
+					{Name: "config", MountPath: "/etc/app"},
+					{Name: "certs", MountPath: "/etc/tls", ReadOnly: true},
+					{Name: "orphan", MountPath: "/orphan"},
+				},
+			}},
+		}}},
+	}
+}
+
+func TestMountVolume(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	d := newVolumeDeployment()
+
+	g.Expect(d).Should(k8s.MountVolume("config"))
+	g.Expect(d).Should(k8s.MountVolume("config").At("/etc/app").FromConfigMap("app-config"))
+	g.Expect(d).Should(k8s.MountVolume("certs").InContainer("app").ReadOnly().FromSecret("app-tls"))
+	g.Expect(d).Should(k8s.MountVolume("data").InContainer("init").FromPersistentVolumeClaim("app-data"))
+
+	g.Expect(d).ShouldNot(k8s.MountVolume("scratch"))
+	g.Expect(d).ShouldNot(k8s.MountVolume("orphan"))
+	g.Expect(d).ShouldNot(k8s.MountVolume("config").At("/etc/config"))
+	g.Expect(d).ShouldNot(k8s.MountVolume("config").ReadOnly())
+	g.Expect(d).ShouldNot(k8s.MountVolume("config").FromSecret("app-config"))
+	g.Expect(d).ShouldNot(k8s.MountVolume("config").FromConfigMap("other"))
+	g.Expect(d).ShouldNot(k8s.MountVolume("data").InContainer("app"))
+
+	_, err := k8s.MountVolume("config").Match(&corev1.ConfigMap{})
+	g.Expect(err).Should(MatchError(ContainSubstring("unable to find a pod spec")))
+}
+
+func TestMountVolumeFailureMessage(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	d := newVolumeDeployment()
+
+	m := k8s.MountVolume("scratch")
+	g.Expect(m.Match(d)).Should(BeFalse())
+	g.Expect(m.FailureMessage(d)).Should(Equal("Expected volume scratch to be mounted, but it is not mounted by any container"))
+
+	m = k8s.MountVolume("orphan")
+	g.Expect(m.Match(d)).Should(BeFalse())
+	g.Expect(m.FailureMessage(d)).Should(And(
+		ContainSubstring("to contain volume"),
+		ContainSubstring("the volume is mounted by app, but not defined"),
+	))
+
+	m = k8s.MountVolume("config").At("/etc/config").ReadOnly()
+	g.Expect(m.Match(d)).Should(BeFalse())
+	g.Expect(m.FailureMessage(d)).Should(Equal("Expected volume config to be mounted at /etc/config, read-only, but it is mounted at:\n/etc/app in container app"))
+
+	m = k8s.MountVolume("scratch").FromConfigMap("app-config")
+	g.Expect(m.Match(d)).Should(BeFalse())
+	g.Expect(m.FailureMessage(d)).Should(Equal("Expected volume scratch to be backed by ConfigMap app-config, but it is backed by emptyDir"))
+
+	m = k8s.MountVolume("config").FromSecret("app-config")
+	g.Expect(m.Match(d)).Should(BeFalse())
+	g.Expect(m.FailureMessage(d)).Should(Equal("Expected volume config to be backed by Secret app-config, but it is backed by ConfigMap app-config"))
+
+	m = k8s.MountVolume("data").InContainer("app")
+	g.Expect(m.Match(d)).Should(BeFalse())
+	g.Expect(m.FailureMessage(d)).Should(Equal("Expected container app to mount volume data, but the volume is not mounted by it"))
+
+	m = k8s.MountVolume("data").InContainer("sidecar")
+	g.Expect(m.Match(d)).Should(BeFalse())
+	g.Expect(m.FailureMessage(d)).Should(ContainSubstring("there is no such container"))
+
+	m = k8s.MountVolume("config").At("/etc/app").FromConfigMap("app-config")
+	g.Expect(m.NegatedFailureMessage(d)).Should(Equal("Expected not to mount volume config at /etc/app, backed by ConfigMap app-config"))
+}
@@ -0,0 +1,110 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onsi/gomega/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultWaitPolling is the polling interval used by WaitFor when the Matcher
+// has not been configured with WithDefaultPolling.
+const defaultWaitPolling = time.Second
+
+// Condition describes a resource awaited by WaitFor.
+type Condition struct {
+	// Resource identifies the kind of the resource, in any of the forms
+	// accepted by UnstructuredMatcher, i.e. "apps/v1/Deployment".
+	Resource string
+	// Key identifies the resource.
+	Key client.ObjectKey
+	// Matcher is matched against the resource, as *unstructured.Unstructured.
+	Matcher types.GomegaMatcher
+}
+
+func (c Condition) String() string {
+	return fmt.Sprintf("%s %s", c.Resource, c.Key)
+}
+
+// WaitFor concurrently polls the resources of all the given conditions and
+// returns once all of them are satisfied. It gives up when ctx is done, or
+// after the timeout set with WithDefaultTimeout, returning an error reporting
+// the conditions not yet satisfied, along with the reason.
+func WaitFor(ctx context.Context, k *Matcher, conditions ...Condition) error {
+	if k.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, k.timeout)
+		defer cancel()
+	}
+
+	polling := k.polling
+	if polling <= 0 {
+		polling = defaultWaitPolling
+	}
+
+	errs := make([]error, len(conditions))
+
+	wg := sync.WaitGroup{}
+
+	for i := range conditions {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			errs[i] = waitForCondition(ctx, k, conditions[i], polling)
+		}()
+	}
+
+	wg.Wait()
+
+	var failures []string
+
+	for i := range errs {
+		if errs[i] != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", conditions[i], errs[i]))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("%d of %d conditions not satisfied:\n%s", len(failures), len(conditions), strings.Join(failures, "\n"))
+	}
+
+	return nil
+}
+
+func waitForCondition(ctx context.Context, k *Matcher, c Condition, polling time.Duration) error {
+	get := k.Unstructured().Get(c.Resource, c.Key)
+
+	ticker := time.NewTicker(polling)
+	defer ticker.Stop()
+
+	for {
+		obj, err := get(ctx)
+
+		if err == nil {
+			var ok bool
+
+			ok, err = c.Matcher.Match(obj)
+			if err == nil && ok {
+				return nil
+			}
+
+			if err == nil {
+				err = errors.New(c.Matcher.FailureMessage(obj))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-ticker.C:
+		}
+	}
+}
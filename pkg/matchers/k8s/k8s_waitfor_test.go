@@ -0,0 +1,110 @@
+package k8s_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestWaitFor(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptrTo[int32](1)},
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			deploy,
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"}},
+		).
+		WithStatusSubresource(deploy).
+		Build()
+
+	k := k8s.New(cli, scheme, k8s.WithDefaultPolling(10*time.Millisecond))
+	key := client.ObjectKey{Namespace: "ns", Name: "app"}
+
+	conditions := []k8s.Condition{
+		{Resource: "apps/v1/Deployment", Key: key, Matcher: jq.Match(`.status.readyReplicas == 1`)},
+		{Resource: "v1/Service", Key: key, Matcher: jq.Match(`.metadata.name == "app"`)},
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+
+		d := appsv1.Deployment{}
+		if err := cli.Get(context.Background(), key, &d); err == nil {
+			d.Status.ReadyReplicas = 1
+			_ = cli.Status().Update(context.Background(), &d)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	g.Expect(k8s.WaitFor(ctx, k, conditions...)).Should(Succeed())
+}
+
+func TestWaitForTimeout(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"}}).
+		Build()
+
+	k := k8s.New(cli, scheme,
+		k8s.WithDefaultTimeout(100*time.Millisecond),
+		k8s.WithDefaultPolling(10*time.Millisecond))
+
+	err := k8s.WaitFor(t.Context(), k,
+		k8s.Condition{
+			Resource: "v1/ConfigMap",
+			Key:      client.ObjectKey{Namespace: "ns", Name: "cm"},
+			Matcher:  jq.Match(`.metadata.name == "cm"`),
+		},
+		k8s.Condition{
+			Resource: "v1/ConfigMap",
+			Key:      client.ObjectKey{Namespace: "ns", Name: "cm"},
+			Matcher:  jq.Match(`.data.foo == "bar"`),
+		},
+		k8s.Condition{
+			Resource: "v1/Secret",
+			Key:      client.ObjectKey{Namespace: "ns", Name: "missing"},
+			Matcher:  jq.Match(`.metadata.name == "missing"`),
+		},
+	)
+
+	g.Expect(err).Should(HaveOccurred())
+	g.Expect(err.Error()).Should(And(
+		ContainSubstring("2 of 3 conditions not satisfied"),
+		ContainSubstring("v1/ConfigMap ns/cm"),
+		ContainSubstring(`.data.foo == "bar"`),
+		ContainSubstring("v1/Secret ns/missing"),
+		ContainSubstring("not found"),
+	))
+}
@@ -0,0 +1,188 @@
+// Package matchers is a facade over the matcher families provided by this
+// module, so that a single package can be dot-imported:
+//
+//	Expect(doc).Should(MatchJQ(`.kind == "Deployment"`))
+//	Expect(manifest).Should(MatchYQ(`.spec.replicas == 3`))
+//	Expect(config).Should(MatchXPath(`/configuration/root/@level = 'info'`))
+//
+// It also provides a Config holding the settings shared by the jq and yq
+// matchers, so that suites with different needs can each use their own
+// settings rather than the process wide ones. The jq and yq functions of
+// this package use the Config set with SetDefaultConfig.
+package matchers
+
+import (
+	"net/http"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/admission"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/audit"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jq"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jsonpatch"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/k8s"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/xpath"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/yq"
+	"github.com/onsi/gomega/types"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	cradmission "sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// MatchJQ succeeds if the jq expression evaluates to true against the actual
+// value, see jq.Match.
+func MatchJQ(format string, args ...any) types.GomegaMatcher {
+	return loadDefaults().jq.Match(format, args...)
+}
+
+// ExtractJQ returns a transform evaluating the jq expression against its
+// input, see jq.Extract.
+func ExtractJQ(expression string) func(in any) (any, error) {
+	return loadDefaults().jq.Extract(expression)
+}
+
+// FieldJQ succeeds if the value found at the jq path matches the given
+// matcher, see jq.Field.
+func FieldJQ(path string, matcher types.GomegaMatcher) types.GomegaMatcher {
+	return loadDefaults().jq.Field(path, matcher)
+}
+
+// MatchRegexpJQ succeeds if the string found at the jq path matches the given
+// Go regular expression, see jq.MatchRegexp.
+func MatchRegexpJQ(path string, pattern string) types.GomegaMatcher {
+	return loadDefaults().jq.MatchRegexp(path, pattern)
+}
+
+// ContainSubsetJQ succeeds if the actual document contains all the keys and
+// values of the expected JSON fragment, see jq.ContainSubset.
+func ContainSubsetJQ(expected any) *jq.SubsetMatcher {
+	return loadDefaults().jq.ContainSubset(expected)
+}
+
+// ContainElementsInOrderJQ succeeds if the actual array contains the given
+// elements in the same relative order, see jq.ContainElementsInOrder.
+func ContainElementsInOrderJQ(elements ...any) types.GomegaMatcher {
+	return loadDefaults().jq.ContainElementsInOrder(elements...)
+}
+
+// MatchErrorJSONJQ succeeds if the jq expression evaluates to true against
+// the JSON document carried by the actual error, see jq.MatchErrorJSON.
+func MatchErrorJSONJQ(format string, args ...any) types.GomegaMatcher {
+	return loadDefaults().jq.MatchErrorJSON(format, args...)
+}
+
+// MatchYQ succeeds if the yq expression evaluates to true against the actual
+// value, see yq.Match.
+func MatchYQ(format string, args ...any) types.GomegaMatcher {
+	return loadDefaults().yq.Match(format, args...)
+}
+
+// ExtractYQ returns a transform evaluating the yq expression against its
+// input, see yq.Extract.
+func ExtractYQ(expression string) func(in any) (any, error) {
+	return loadDefaults().yq.Extract(expression)
+}
+
+// FieldYQ succeeds if the value found at the yq path matches the given
+// matcher, see yq.Field.
+func FieldYQ(path string, matcher types.GomegaMatcher) types.GomegaMatcher {
+	return loadDefaults().yq.Field(path, matcher)
+}
+
+// ContainSubsetYQ succeeds if the actual document contains all the keys and
+// values of the expected YAML fragment, see yq.ContainSubset.
+func ContainSubsetYQ(expected string) *yq.SubsetMatcher {
+	return loadDefaults().yq.ContainSubset(expected)
+}
+
+// MatchXPath succeeds if the XPath expression matches the actual document,
+// see xpath.Match.
+func MatchXPath(format string, args ...any) types.GomegaMatcher {
+	return xpath.Match(format, args...)
+}
+
+// ExtractXPath returns a transform evaluating the XPath expression against
+// its input, see xpath.Extract.
+func ExtractXPath(expression string) func(in any) (any, error) {
+	return xpath.Extract(expression)
+}
+
+// MatchJSONPatch succeeds if the actual JSON patch contains all the expected
+// operations, in any order, see jsonpatch.MatchPatch.
+func MatchJSONPatch(expected ...jsonpatch.Operation) types.GomegaMatcher {
+	return jsonpatch.MatchPatch(expected...)
+}
+
+// ApplyAndMatchJSONPatch succeeds if the actual JSON document, once patched,
+// matches the inner matcher, see jsonpatch.ApplyAndMatch.
+func ApplyAndMatchJSONPatch(patch any, inner types.GomegaMatcher) types.GomegaMatcher {
+	return jsonpatch.ApplyAndMatch(patch, inner)
+}
+
+// BeAdmissionAllowed succeeds if the actual admission response allows the
+// request, see admission.BeAllowed.
+func BeAdmissionAllowed() types.GomegaMatcher {
+	return admission.BeAllowed()
+}
+
+// BeAdmissionDeniedWith succeeds if the actual admission response denies the
+// request with a message containing the given substring, see
+// admission.BeDeniedWith.
+func BeAdmissionDeniedWith(substr string) types.GomegaMatcher {
+	return admission.BeDeniedWith(substr)
+}
+
+// NewAdmissionHarness creates a Harness invoking the given admission handler,
+// see admission.New.
+func NewAdmissionHarness(handler cradmission.Handler, opts ...admission.Option) *admission.Harness {
+	return admission.New(handler, opts...)
+}
+
+// NewAdmissionHarnessHTTP creates a Harness posting AdmissionReview requests
+// to the given http.Handler, see admission.NewHTTP.
+func NewAdmissionHarnessHTTP(handler http.Handler, opts ...admission.Option) *admission.Harness {
+	return admission.NewHTTP(handler, opts...)
+}
+
+// HaveAuditEvent succeeds if the actual audit log holds an event for the
+// given verb, resource and user, see audit.HaveEvent.
+func HaveAuditEvent(verb string, resource string, user string) *audit.EventMatcher {
+	return audit.HaveEvent(verb, resource, user)
+}
+
+// HaveOnlyAuditEvents succeeds if all the events of the given user in the
+// actual audit log are allowed, see audit.HaveOnlyEvents.
+func HaveOnlyAuditEvents(user string, allowed ...*audit.EventMatcher) *audit.OnlyEventsMatcher {
+	return audit.HaveOnlyEvents(user, allowed...)
+}
+
+// NewK8s creates a Kubernetes matcher backed by the given client and scheme,
+// see k8s.New.
+func NewK8s(cli client.Client, scheme *runtime.Scheme, opts ...k8s.Option) *k8s.Matcher {
+	return k8s.New(cli, scheme, opts...)
+}
+
+// NewK8sFromConfig creates a Kubernetes matcher from the given REST config,
+// see k8s.NewFromConfig.
+func NewK8sFromConfig(cfg *rest.Config, opts ...k8s.Option) (*k8s.Matcher, error) {
+	return k8s.NewFromConfig(cfg, opts...)
+}
+
+// NewK8sFromKubeconfig creates a Kubernetes matcher from the given kubeconfig
+// file and context, see k8s.NewFromKubeconfig.
+func NewK8sFromKubeconfig(path string, kubeContext string, opts ...k8s.Option) (*k8s.Matcher, error) {
+	return k8s.NewFromKubeconfig(path, kubeContext, opts...)
+}
+
+// NewK8sDynamic creates a Kubernetes matcher backed by the given client-go
+// dynamic client and REST mapper, see k8s.NewDynamic.
+func NewK8sDynamic(dyn dynamic.Interface, mapper meta.RESTMapper, opts ...k8s.Option) (*k8s.Matcher, error) {
+	return k8s.NewDynamic(dyn, mapper, opts...)
+}
+
+// NewK8sFake creates a Kubernetes matcher backed by a fake client seeded with
+// the given objects, see k8s.NewFake.
+func NewK8sFake(objs ...client.Object) *k8s.Matcher {
+	return k8s.NewFake(objs...)
+}
@@ -0,0 +1,84 @@
+package matchers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/audit"
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/jsonpatch"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	cradmission "sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	. "github.com/lburgazzoli/gomega-matchers/pkg/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func TestFacade(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	g.Expect(`{"a":{"b":1}}`).Should(MatchJQ(`.a.b == %d`, 1))
+	g.Expect(`{"a":{"b":1}}`).Should(WithTransform(ExtractJQ(`.a`), MatchJQ(`.b == 1`)))
+	g.Expect(`{"a":{"b":1}}`).Should(FieldJQ(`.a.b`, BeNumerically("==", 1)))
+	g.Expect(`{"a":{"b":"v1"}}`).Should(MatchRegexpJQ(`.a.b`, `^v\d+$`))
+	g.Expect(`{"a":{"b":1,"c":2}}`).Should(ContainSubsetJQ(`{"a":{"b":1}}`))
+	g.Expect(`{"a":["x","y","z"]}`).Should(FieldJQ(`.a`, ContainElementsInOrderJQ("x", "z")))
+	g.Expect(errors.New(`denied: {"reason":"Forbidden"}`)).Should(MatchErrorJSONJQ(`.reason == "Forbidden"`))
+
+	g.Expect("a:\n  b: 1\n").Should(MatchYQ(`.a.b == %d`, 1))
+	g.Expect("a:\n  b: 1\n").Should(WithTransform(ExtractYQ(`.a`), MatchYQ(`.b == 1`)))
+	g.Expect("a:\n  b: 1\n").Should(FieldYQ(`.a.b`, Equal(1)))
+	g.Expect("a:\n  b: 1\n  c: 2\n").Should(ContainSubsetYQ("a: {b: 1}"))
+
+	g.Expect(`<root level="info"/>`).Should(MatchXPath(`/root/@level = '%s'`, "info"))
+	g.Expect(`<root level="info"/>`).Should(WithTransform(ExtractXPath(`string(/root/@level)`), Equal("info")))
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"}}).
+		Build()
+
+	k := NewK8s(cli, scheme)
+
+	g.Expect(k.Unstructured().List("v1/ConfigMap")(t.Context())).Should(MatchJQ(`.items | length == 1`))
+
+	k = NewK8sFake(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"}})
+
+	g.Expect(k.Unstructured().List("v1/ConfigMap")(t.Context())).Should(MatchJQ(`.items | length == 1`))
+}
+
+const auditEvent = `{"kind":"Event","apiVersion":"audit.k8s.io/v1","level":"Metadata","auditID":"1","stage":"ResponseComplete","verb":"get","user":{"username":"alice"},"objectRef":{"resource":"pods","namespace":"ns","name":"app","apiVersion":"v1"},"responseStatus":{"metadata":{},"code":200},"requestReceivedTimestamp":"2024-05-01T10:00:00.000000Z","stageTimestamp":"2024-05-01T10:00:00.010000Z"}`
+
+func TestFacadeKubernetes(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	patch := `[{"op":"add","path":"/metadata/labels","value":{"app":"foo"}}]`
+
+	g.Expect(patch).Should(MatchJSONPatch(jsonpatch.Operation{Op: "add", Path: "/metadata/labels", Value: HaveKey("app")}))
+	g.Expect(`{"metadata":{}}`).Should(ApplyAndMatchJSONPatch(patch, MatchJQ(`.metadata.labels.app == "foo"`)))
+
+	h := NewAdmissionHarness(cradmission.HandlerFunc(func(_ context.Context, req cradmission.Request) cradmission.Response {
+		if req.Name == "bad" {
+			return cradmission.Denied("bad pods are not allowed")
+		}
+
+		return cradmission.Allowed("")
+	}))
+
+	g.Expect(h.Create(t.Context(), &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "good", Namespace: "ns"}})).Should(BeAdmissionAllowed())
+	g.Expect(h.Create(t.Context(), &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "bad", Namespace: "ns"}})).Should(BeAdmissionDeniedWith("not allowed"))
+
+	g.Expect(auditEvent).Should(HaveAuditEvent("get", "pods", "alice").InNamespace("ns"))
+	g.Expect(auditEvent).Should(HaveOnlyAuditEvents("alice", audit.HaveEvent("get", "", "")))
+}
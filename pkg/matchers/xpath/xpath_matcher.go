@@ -0,0 +1,52 @@
+package xpath
+
+import (
+	"fmt"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+// Match succeeds if the XPath 1.0 expression evaluates to true against the
+// actual XML document. Results that are not booleans are converted following
+// the XPath boolean() function rules, so a non-empty node-set matches.
+func Match(format string, args ...any) types.GomegaMatcher {
+	return &xpathMatcher{
+		Expression: fmt.Sprintf(format, args...),
+	}
+}
+
+var _ types.GomegaMatcher = &xpathMatcher{}
+
+type xpathMatcher struct {
+	Expression string
+}
+
+func (matcher *xpathMatcher) Match(actual interface{}) (bool, error) {
+	result, err := evaluate(matcher.Expression, actual)
+	if err != nil {
+		return false, err
+	}
+
+	switch v := result.(type) {
+	case bool:
+		return v, nil
+	case float64:
+		return v != 0, nil
+	case string:
+		return v != "", nil
+	case []*xmlquery.Node:
+		return len(v) != 0, nil
+	default:
+		return false, nil
+	}
+}
+
+func (matcher *xpathMatcher) FailureMessage(actual interface{}) string {
+	return format.Message(fmt.Sprintf("%v", actual), "to match expression", matcher.Expression)
+}
+
+func (matcher *xpathMatcher) NegatedFailureMessage(actual interface{}) string {
+	return format.Message(fmt.Sprintf("%v", actual), "not to match expression", matcher.Expression)
+}
@@ -0,0 +1,73 @@
+package xpath_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/xpath"
+
+	. "github.com/onsi/gomega"
+)
+
+const logging = `
+<configuration>
+  <appender name="console" class="ConsoleAppender"/>
+  <logger name="org.acme" level="debug"/>
+  <root level="info">
+    <appender-ref ref="console"/>
+  </root>
+</configuration>
+`
+
+func TestMatcher(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	g.Expect(logging).Should(
+		xpath.Match(`/configuration/root/@level = 'info'`),
+	)
+
+	g.Expect(logging).Should(
+		Not(
+			xpath.Match(`/configuration/root/@level = 'debug'`),
+		),
+	)
+
+	g.Expect(logging).Should(
+		xpath.Match(`//logger[@name='%s']`, "org.acme"),
+	)
+
+	g.Expect(logging).Should(
+		Not(
+			xpath.Match(`//logger[@name='org.foo']`),
+		),
+	)
+
+	g.Expect(logging).Should(
+		And(
+			xpath.Match(`count(//appender) = 1`),
+			xpath.Match(`//root/appender-ref/@ref = //appender/@name`),
+		),
+	)
+}
+
+func TestMatcherWithType(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	g.Expect([]byte(logging)).Should(
+		xpath.Match(`/configuration/root/@level = 'info'`),
+	)
+
+	g.Expect(strings.NewReader(logging)).Should(
+		xpath.Match(`/configuration/root/@level = 'info'`),
+	)
+
+	_, err := xpath.Match(`/configuration`).Match(1)
+	g.Expect(err).Should(HaveOccurred())
+
+	_, err = xpath.Match(`/configuration[`).Match(logging)
+	g.Expect(err).Should(HaveOccurred())
+}
@@ -0,0 +1,99 @@
+package xpath
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/gbytes"
+
+	xpathlib "github.com/antchfx/xpath"
+)
+
+func toReader(in any) (io.Reader, error) {
+	switch v := in.(type) {
+	case string:
+		return strings.NewReader(v), nil
+	case []byte:
+		return bytes.NewReader(v), nil
+	case *gbytes.Buffer:
+		return bytes.NewReader(v.Contents()), nil
+	case io.Reader:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("unsupported type:\n%s", format.Object(in, 1))
+	}
+}
+
+// evaluate runs the expression against the actual document and returns
+// either a scalar (bool, float64, string) or the selected []*xmlquery.Node.
+func evaluate(expression string, actual interface{}) (any, error) {
+	expr, err := xpathlib.Compile(expression)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse expression %s, %w", expression, err)
+	}
+
+	r, err := toReader(actual)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := xmlquery.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("failure reading document: %w", err)
+	}
+
+	result := expr.Evaluate(xmlquery.CreateXPathNavigator(doc))
+	if it, ok := result.(*xpathlib.NodeIterator); ok {
+		return iteratorNodes(it), nil
+	}
+
+	return result, nil
+}
+
+// iteratorNodes collects the nodes selected by the given iterator, as
+// xmlquery.QuerySelectorAll does, so that the expression is only evaluated
+// once. Attributes are returned as attribute nodes holding their value.
+func iteratorNodes(it *xpathlib.NodeIterator) []*xmlquery.Node {
+	var nodes []*xmlquery.Node
+
+	for it.MoveNext() {
+		nav, ok := it.Current().(*xmlquery.NodeNavigator)
+		if !ok {
+			continue
+		}
+
+		if nav.NodeType() != xpathlib.AttributeNode {
+			nodes = append(nodes, nav.Current())
+
+			continue
+		}
+
+		value := &xmlquery.Node{
+			Type: xmlquery.TextNode,
+			Data: nav.Value(),
+		}
+
+		nodes = append(nodes, &xmlquery.Node{
+			Parent:     nav.Current(),
+			Type:       xmlquery.AttributeNode,
+			Data:       nav.LocalName(),
+			FirstChild: value,
+			LastChild:  value,
+		})
+	}
+
+	return nodes
+}
+
+func nodeToString(n *xmlquery.Node) string {
+	switch n.Type {
+	case xmlquery.DocumentNode, xmlquery.ElementNode:
+		return n.OutputXML(true)
+	default:
+		return n.InnerText()
+	}
+}
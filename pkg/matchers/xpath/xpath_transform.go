@@ -0,0 +1,37 @@
+package xpath
+
+import (
+	"github.com/antchfx/xmlquery"
+)
+
+// Extract returns a transform that evaluates the XPath 1.0 expression against
+// the actual XML document. Element nodes are rendered back as XML so that the
+// result can be fed to Match again, any other node is rendered as its text.
+// When the expression selects more than one node, a []string is returned.
+func Extract(expression string) func(in any) (any, error) {
+	return func(in any) (any, error) {
+		result, err := evaluate(expression, in)
+		if err != nil {
+			return nil, err
+		}
+
+		nodes, ok := result.([]*xmlquery.Node)
+		if !ok {
+			return result, nil
+		}
+
+		switch len(nodes) {
+		case 0:
+			return nil, nil
+		case 1:
+			return nodeToString(nodes[0]), nil
+		default:
+			values := make([]string, 0, len(nodes))
+			for _, n := range nodes {
+				values = append(values, nodeToString(n))
+			}
+
+			return values, nil
+		}
+	}
+}
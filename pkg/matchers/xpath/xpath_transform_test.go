@@ -0,0 +1,35 @@
+package xpath_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/xpath"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestExtract(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	g.Expect(logging).Should(
+		WithTransform(xpath.Extract(`/configuration/root`), xpath.Match(`/root/@level = 'info'`)),
+	)
+
+	g.Expect(logging).Should(
+		WithTransform(xpath.Extract(`/configuration/logger/@level`), Equal("debug")),
+	)
+
+	g.Expect(logging).Should(
+		WithTransform(xpath.Extract(`//@name`), ConsistOf("console", "org.acme")),
+	)
+
+	g.Expect(logging).Should(
+		WithTransform(xpath.Extract(`count(//appender-ref)`), BeNumerically("==", 1)),
+	)
+
+	g.Expect(logging).Should(
+		WithTransform(xpath.Extract(`//missing`), BeNil()),
+	)
+}
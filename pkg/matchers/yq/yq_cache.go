@@ -0,0 +1,106 @@
+package yq
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+)
+
+type cacheKey struct {
+	kind       string
+	expression string
+	digest     [sha256.Size]byte
+}
+
+type cacheEntry struct {
+	key   cacheKey
+	value any
+}
+
+// resultCache is a least recently used cache of evaluation results.
+type resultCache struct {
+	lock    sync.Mutex
+	size    int
+	order   *list.List
+	entries map[cacheKey]*list.Element
+}
+
+func newResultCache(size int) *resultCache {
+	return &resultCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[cacheKey]*list.Element),
+	}
+}
+
+func (c *resultCache) get(key cacheKey) (any, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(e)
+
+	//nolint:forcetypeassert
+	return e.Value.(*cacheEntry).value, true
+}
+
+func (c *resultCache) put(key cacheKey, value any) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		c.order.MoveToFront(e)
+
+		//nolint:forcetypeassert
+		e.Value.(*cacheEntry).value = value
+
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, value: value})
+
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+
+		//nolint:forcetypeassert
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// cached returns the result of evaluating fn against the given document,
+// looking it up by kind, expression and content first when the Matcher has
+// been configured with WithCache.
+func (m *Matcher) cached(kind string, expression string, actual any, fn func(data string) (any, error)) (any, error) {
+	data, err := toString(actual)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.cache == nil {
+		return fn(data)
+	}
+
+	key := cacheKey{
+		kind:       kind,
+		expression: expression,
+		digest:     sha256.Sum256([]byte(data)),
+	}
+
+	if v, ok := m.cache.get(key); ok {
+		return v, nil
+	}
+
+	v, err := fn(data)
+	if err != nil {
+		return nil, err
+	}
+
+	m.cache.put(key, v)
+
+	return v, nil
+}
@@ -0,0 +1,96 @@
+package yq
+
+import (
+	"fmt"
+
+	"github.com/mikefarah/yq/v4/pkg/yqlib"
+	"github.com/onsi/gomega/types"
+)
+
+// Field succeeds if the value found at the given path, i.e.
+// .spec.template.spec.containers[0].image, matches the given matcher. The
+// node is decoded as YAML to a Go value (i.e. int, string, map[string]any)
+// before being passed to the matcher:
+//
+//	Expect(manifest).Should(yq.Field(".spec.replicas", BeNumerically(">=", 3)))
+//
+// Only the first value is considered when the path produces several of them,
+// a path not producing any value yields nil.
+func Field(path string, matcher types.GomegaMatcher) types.GomegaMatcher {
+	return New().Field(path, matcher)
+}
+
+// Field succeeds if the value found at the given path matches the given
+// matcher.
+func (m *Matcher) Field(path string, matcher types.GomegaMatcher) types.GomegaMatcher {
+	return &fieldMatcher{
+		Path:    path,
+		config:  m,
+		matcher: matcher,
+	}
+}
+
+var _ types.GomegaMatcher = &fieldMatcher{}
+
+type fieldMatcher struct {
+	Path    string
+	config  *Matcher
+	matcher types.GomegaMatcher
+	value   any
+}
+
+func (matcher *fieldMatcher) Match(actual interface{}) (bool, error) {
+	v, err := matcher.config.cached("field", matcher.Path, actual, func(data string) (any, error) {
+		return matcher.extract(data)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	matcher.value = v
+
+	return matcher.matcher.Match(matcher.value)
+}
+
+func (matcher *fieldMatcher) extract(data string) (any, error) {
+	return matcher.config.decode(matcher.Path, data)
+}
+
+// decode evaluates the expression against the document and decodes the first
+// resulting node as YAML to a Go value, i.e. int, string, map[string]any. An
+// expression not producing any value yields nil.
+func (m *Matcher) decode(expression string, data string) (any, error) {
+	results, err := m.evaluate(expression, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if results == nil || results.Len() == 0 {
+		return nil, nil
+	}
+
+	n, ok := results.Front().Value.(*yqlib.CandidateNode)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type %T", results.Front().Value)
+	}
+
+	node, err := n.MarshalYAML()
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode %s: %w", expression, err)
+	}
+
+	var value any
+	if err := node.Decode(&value); err != nil {
+		return nil, fmt.Errorf("unable to decode %s: %w", expression, err)
+	}
+
+	return value, nil
+}
+
+func (matcher *fieldMatcher) FailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected field %s to match:\n%s", matcher.Path, matcher.matcher.FailureMessage(matcher.value))
+}
+
+func (matcher *fieldMatcher) NegatedFailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected field %s not to match:\n%s", matcher.Path, matcher.matcher.NegatedFailureMessage(matcher.value))
+}
@@ -0,0 +1,62 @@
+package yq_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/yq"
+
+	. "github.com/onsi/gomega"
+)
+
+const deployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  labels:
+    tier: web
+spec:
+  replicas: 3
+  paused: false
+  template:
+    spec:
+      containers:
+        - name: app
+          image: registry.example.com/app:1.0
+          ports:
+            - containerPort: 8080
+`
+
+func TestField(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	g.Expect(deployment).Should(yq.Field(".spec.template.spec.containers[0].image", HavePrefix("registry.example.com/")))
+	g.Expect(deployment).Should(yq.Field(".spec.replicas", Equal(3)))
+	g.Expect(deployment).Should(yq.Field(".spec.paused", BeFalse()))
+	g.Expect(deployment).Should(yq.Field(".metadata.labels", HaveKeyWithValue("tier", "web")))
+	g.Expect(deployment).Should(yq.Field(".spec.template.spec.containers[0].ports", ContainElement(HaveKeyWithValue("containerPort", 8080))))
+	g.Expect(deployment).Should(yq.Field(".spec.missing", BeNil()))
+
+	g.Expect(deployment).ShouldNot(yq.Field(".spec.replicas", BeNumerically(">", 3)))
+
+	_, err := yq.Field(".spec[", BeNil()).Match(deployment)
+	g.Expect(err).Should(HaveOccurred())
+}
+
+func TestFieldFailureMessage(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	m := yq.Field(".spec.replicas", BeNumerically(">=", 5))
+
+	ok, err := m.Match(deployment)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ok).Should(BeFalse())
+	g.Expect(m.FailureMessage(deployment)).Should(And(
+		HavePrefix("Expected field .spec.replicas to match:\n"),
+		ContainSubstring("to be >="),
+	))
+}
@@ -0,0 +1,141 @@
+package yq
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Files returns an actual value standing for the YAML files matching the
+// given glob patterns, i.e. "deploy/*.yaml", loaded as a single stream of
+// documents that all the yq matchers and transforms accept. A pattern naming
+// a directory stands for all the .yaml and .yml files found in it,
+// recursively. It makes repository level policy checks possible:
+//
+//	Expect(yq.Files("config/manifests")).Should(yq.Match(
+//	    `[select(.kind == "Deployment")] | all_c(.spec.template.spec.containers | all_c(.resources.limits != null))`,
+//	))
+//
+// Expressions are evaluated against all the documents at once, hence they
+// must reduce them to a single boolean, i.e. with [...] and all_c. The files
+// are read again each time the value is evaluated, in lexical order, and
+// patterns not matching any file are reported as errors.
+func Files(patterns ...string) *FileSet {
+	return &FileSet{
+		patterns: patterns,
+	}
+}
+
+// FileSet is the actual value returned by Files.
+type FileSet struct {
+	patterns []string
+}
+
+// Paths returns the paths of the files matching the patterns, in lexical
+// order.
+func (f *FileSet) Paths() ([]string, error) {
+	var paths []string
+
+	for _, pattern := range f.patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %s: %w", pattern, err)
+		}
+
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no file matches pattern %s", pattern)
+		}
+
+		for _, m := range matches {
+			found, err := yamlFiles(m)
+			if err != nil {
+				return nil, err
+			}
+
+			paths = append(paths, found...)
+		}
+	}
+
+	slices.Sort(paths)
+
+	return slices.Compact(paths), nil
+}
+
+// MarshalYAML returns the content of the files as a stream of documents.
+func (f *FileSet) MarshalYAML() ([]byte, error) {
+	paths, err := f.Paths()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s: %w", p, err)
+		}
+
+		if len(bytes.TrimSpace(data)) == 0 {
+			continue
+		}
+
+		// a separator is only added if the file does not start with one,
+		// as an empty document would be evaluated as well
+		if buf.Len() > 0 && !bytes.HasPrefix(bytes.TrimLeft(data, " \t\r\n"), []byte("---")) {
+			buf.WriteString("---\n")
+		}
+
+		buf.Write(data)
+
+		if !bytes.HasSuffix(data, []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (f *FileSet) String() string {
+	return "files " + strings.Join(f.patterns, ", ")
+}
+
+// yamlFiles returns the given path if it is a file, or the YAML files found
+// in it if it is a directory.
+func yamlFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to stat %s: %w", path, err)
+	}
+
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() && (filepath.Ext(p) == ".yaml" || filepath.Ext(p) == ".yml") {
+			files = append(files, p)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to walk %s: %w", path, err)
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no YAML file found in %s", path)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,84 @@
+package yq_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/yq"
+
+	. "github.com/onsi/gomega"
+)
+
+func writeManifests(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestFiles(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	dir := writeManifests(t, map[string]string{
+		"app/deployment.yaml": "kind: Deployment\nmetadata:\n  name: app\nspec:\n  replicas: 3",
+		"app/service.yml":     "---\nkind: Service\nmetadata:\n  name: app\n",
+		"db/statefulset.yaml": "kind: StatefulSet\nmetadata:\n  name: db\n---\nkind: Service\nmetadata:\n  name: db\n",
+		"db/empty.yaml":       "\n",
+		"README.md":           "# not a manifest\n",
+	})
+
+	files := yq.Files(dir)
+
+	paths, err := files.Paths()
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(paths).Should(HaveLen(4))
+
+	g.Expect(files).Should(yq.Match(`[.] | length == 4`))
+	g.Expect(files).Should(yq.Match(`[select(.kind == "Service")] | all_c(.metadata.name == "app" or .metadata.name == "db")`))
+	g.Expect(files).ShouldNot(yq.Match(`[select(.kind == "Deployment")] | all_c(.spec.replicas > 3)`))
+	g.Expect(files).Should(WithTransform(yq.Extract(`[select(.kind == "Service") | .metadata.name] | join(",")`), Equal("app,db\n")))
+
+	g.Expect(yq.Files(filepath.Join(dir, "*", "*.yaml"))).Should(yq.Match(`[.] | length == 3`))
+	g.Expect(yq.Files(filepath.Join(dir, "app"), filepath.Join(dir, "app", "service.yml"))).Should(yq.Match(`[.] | length == 2`))
+
+	_, err = yq.Match(`true`).Match(yq.Files(filepath.Join(dir, "missing", "*.yaml")))
+	g.Expect(err).Should(MatchError(ContainSubstring("no file matches pattern")))
+
+	_, err = yq.Match(`true`).Match(yq.Files(t.TempDir()))
+	g.Expect(err).Should(MatchError(ContainSubstring("no YAML file found in")))
+}
+
+func TestFilesFailureMessage(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	dir := writeManifests(t, map[string]string{
+		"deployment.yaml": "kind: Deployment\n",
+	})
+
+	files := yq.Files(filepath.Join(dir, "*.yaml"))
+
+	m := yq.Match(`[.] | length == 2`)
+
+	ok, err := m.Match(files)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ok).Should(BeFalse())
+	g.Expect(m.FailureMessage(files)).Should(ContainSubstring("files " + filepath.Join(dir, "*.yaml")))
+}
@@ -11,20 +11,31 @@ import (
 )
 
 func Match(format string, args ...any) types.GomegaMatcher {
-	return &yqMatcher{
-		Expression: fmt.Sprintf(format, args...),
-	}
+	return New().Match(format, args...)
 }
 
 var _ types.GomegaMatcher = &yqMatcher{}
 
 type yqMatcher struct {
 	Expression       string
+	config           *Matcher
 	firstFailurePath []interface{}
 }
 
 func (matcher *yqMatcher) Match(actual interface{}) (bool, error) {
-	results, err := evaluate(matcher.Expression, actual)
+	v, err := matcher.config.cached("match", matcher.Expression, actual, func(data string) (any, error) {
+		return matcher.match(data)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	//nolint:forcetypeassert
+	return v.(bool), nil
+}
+
+func (matcher *yqMatcher) match(data string) (bool, error) {
+	results, err := matcher.config.evaluate(matcher.Expression, data)
 	if err != nil {
 		return false, err
 	}
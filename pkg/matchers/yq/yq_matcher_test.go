@@ -1,6 +1,7 @@
 package yq_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/goccy/go-yaml"
@@ -60,3 +61,22 @@ func TestMatcherWithType(t *testing.T) {
 			WithTransform(yaml.Marshal, yq.Match(`.a == 1`)),
 		)
 }
+
+func TestMatcherConcurrent(t *testing.T) {
+	t.Parallel()
+
+	for i := range 32 {
+		t.Run(fmt.Sprintf("doc-%d", i), func(t *testing.T) {
+			t.Parallel()
+
+			g := NewWithT(t)
+
+			g.Expect(fmt.Sprintf("a: %d\nb: [1, 2]", i)).Should(
+				yq.Match(`.a == %d and (.b | length) == 2`, i),
+			)
+			g.Expect(fmt.Sprintf("a: %d\nb: [1, 2]", i)).Should(
+				WithTransform(yq.Extract(`.b`), Equal("[1, 2]\n")),
+			)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package yq
+
+import (
+	"container/list"
+	"fmt"
+
+	"github.com/mikefarah/yq/v4/pkg/yqlib"
+	"github.com/onsi/gomega/types"
+)
+
+// Option configures a Matcher.
+type Option func(*Matcher)
+
+// PreserveComments keeps the comments found before the first node of a
+// document attached to the document itself, so that they can be asserted
+// with head_comment (i.e. to verify generated files carry a license header)
+// rather than being set aside and only re-emitted when printing.
+func PreserveComments() Option {
+	return func(m *Matcher) {
+		m.preserveComments = true
+	}
+}
+
+// WithCache keeps the results of the last size evaluations, keyed by
+// expression and document content, so that Eventually polling a document
+// that has not changed skips parsing and evaluating it again. It must not be
+// used with expressions depending on anything but the document, i.e. env or
+// now. Edit results are never cached.
+func WithCache(size int) Option {
+	return func(m *Matcher) {
+		if size > 0 {
+			m.cache = newResultCache(size)
+		}
+	}
+}
+
+// Matcher creates yq matchers and transforms sharing the same configuration.
+// The package level Match and Extract functions use the default
+// configuration.
+type Matcher struct {
+	preserveComments bool
+	cache            *resultCache
+}
+
+// New creates a Matcher with the given options.
+func New(opts ...Option) *Matcher {
+	m := Matcher{}
+
+	for _, opt := range opts {
+		opt(&m)
+	}
+
+	return &m
+}
+
+// Match succeeds if the expression evaluates to true against the actual
+// value.
+func (m *Matcher) Match(format string, args ...any) types.GomegaMatcher {
+	return &yqMatcher{
+		Expression: fmt.Sprintf(format, args...),
+		config:     m,
+	}
+}
+
+// Extract returns a transform evaluating the expression against its input
+// and rendering the results as YAML.
+func (m *Matcher) Extract(expression string) func(in any) (any, error) {
+	return extract(m, expression)
+}
+
+// Edit returns a transform applying the mutating expression to its input
+// and rendering the modified documents as YAML.
+func (m *Matcher) Edit(expression string) func(in any) (any, error) {
+	return edit(m, expression)
+}
+
+func (m *Matcher) preferences() yqlib.YamlPreferences {
+	prefs := yamlPreferences()
+
+	if m.preserveComments {
+		prefs.LeadingContentPreProcessing = false
+	}
+
+	return prefs
+}
+
+func (m *Matcher) evaluate(expression string, data string) (*list.List, error) {
+	return newEvaluator(m.preferences()).evaluate(expression, data)
+}
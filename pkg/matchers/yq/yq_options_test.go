@@ -0,0 +1,83 @@
+package yq_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/yq"
+
+	. "github.com/onsi/gomega"
+)
+
+const commented = `# Copyright ACME Corp.
+# SPDX-License-Identifier: Apache-2.0
+
+base: &base
+  name: foo # the name
+app: *base
+`
+
+func TestPreserveComments(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	y := yq.New(yq.PreserveComments())
+
+	g.Expect(commented).Should(
+		y.Match(`head_comment == "Copyright ACME Corp.\nSPDX-License-Identifier: Apache-2.0"`),
+	)
+
+	g.Expect(commented).Should(
+		And(
+			y.Match(`head_comment | test("SPDX-License-Identifier: Apache-2.0")`),
+			y.Match(`.base.name | line_comment == "the name"`),
+		),
+	)
+
+	g.Expect(commented).Should(
+		WithTransform(y.Extract(`head_comment`), Equal("Copyright ACME Corp.\nSPDX-License-Identifier: Apache-2.0\n")),
+	)
+}
+
+func TestAnchors(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	g.Expect(commented).Should(
+		And(
+			yq.Match(`.base | anchor == "base"`),
+			yq.Match(`.app | alias == "base"`),
+			yq.Match(`explode(.) | .app.name == "foo"`),
+		),
+	)
+}
+
+// the environment is used to observe whether results are recomputed, which
+// is why the test cannot run in parallel.
+//
+//nolint:paralleltest
+func TestWithCache(t *testing.T) {
+	g := NewWithT(t)
+
+	y := yq.New(yq.WithCache(2))
+
+	t.Setenv("YQ_CACHE_TEST", "foo")
+
+	g.Expect(`a: foo`).Should(y.Match(`.a == strenv(YQ_CACHE_TEST)`))
+	g.Expect(y.Extract(`strenv(YQ_CACHE_TEST)`)(`a: foo`)).Should(Equal("foo\n"))
+
+	t.Setenv("YQ_CACHE_TEST", "bar")
+
+	// same expression and document, the cached results are returned
+	g.Expect(`a: foo`).Should(y.Match(`.a == strenv(YQ_CACHE_TEST)`))
+	g.Expect(y.Extract(`strenv(YQ_CACHE_TEST)`)(`a: foo`)).Should(Equal("foo\n"))
+
+	// a different document is evaluated, and evicts the least recently used
+	// result
+	g.Expect(`a: bar`).Should(y.Match(`.a == strenv(YQ_CACHE_TEST)`))
+	g.Expect(`a: foo`).ShouldNot(y.Match(`.a == strenv(YQ_CACHE_TEST)`))
+
+	// without cache, results are always recomputed
+	g.Expect(`a: foo`).ShouldNot(yq.Match(`.a == strenv(YQ_CACHE_TEST)`))
+}
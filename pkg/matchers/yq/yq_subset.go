@@ -0,0 +1,314 @@
+package yq
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+//nolint:gochecknoglobals
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ContainSubset succeeds if the actual document contains all the keys and
+// values of the expected YAML fragment, i.e.:
+//
+//	Expect(manifest).Should(yq.ContainSubset(`
+//	spec:
+//	  replicas: 3
+//	`))
+//
+// Mappings must hold every expected key, with nested mappings compared the
+// same way, and sequences must hold an element containing each expected
+// element, regardless of the order. Only the first document is considered.
+// The failure message lists the missing and mismatching paths.
+func ContainSubset(expected string) *SubsetMatcher {
+	return New().ContainSubset(expected)
+}
+
+// ContainSubset succeeds if the actual document contains all the keys and
+// values of the expected YAML fragment.
+func (m *Matcher) ContainSubset(expected string) *SubsetMatcher {
+	return &SubsetMatcher{
+		Expected: expected,
+		config:   m,
+	}
+}
+
+var _ types.GomegaMatcher = &SubsetMatcher{}
+
+// SubsetMatcher is the matcher returned by ContainSubset.
+type SubsetMatcher struct {
+	Expected string
+	config   *Matcher
+	anywhere bool
+	expected any
+	value    any
+	found    string
+}
+
+// Anywhere makes the matcher succeed if any mapping or sequence of the actual
+// document, and not only its root, contains the expected fragment, i.e. to
+// look for a container regardless of its position:
+//
+//	Expect(manifest).Should(yq.ContainSubset("{name: app, image: app:1.0}").Anywhere())
+func (matcher *SubsetMatcher) Anywhere() *SubsetMatcher {
+	matcher.anywhere = true
+
+	return matcher
+}
+
+func (matcher *SubsetMatcher) Match(actual interface{}) (bool, error) {
+	if matcher.expected == nil {
+		expected, err := matcher.config.decode(".", matcher.Expected)
+		if err != nil {
+			return false, fmt.Errorf("unable to decode expected subset: %w", err)
+		}
+
+		matcher.expected = expected
+	}
+
+	v, err := matcher.config.cached("decode", ".", actual, func(data string) (any, error) {
+		return matcher.config.decode(".", data)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	matcher.value = v
+	matcher.found = ""
+
+	if !matcher.anywhere {
+		if len(subsetDiff("", matcher.expected, v)) > 0 {
+			return false, nil
+		}
+
+		matcher.found = "."
+
+		return true, nil
+	}
+
+	for _, c := range candidates("", v) {
+		if len(subsetDiff(c.path, matcher.expected, c.value)) == 0 {
+			matcher.found = displayPath(c.path)
+
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (matcher *SubsetMatcher) FailureMessage(actual interface{}) string {
+	return format.Message(fmt.Sprintf("%v", actual), matcher.message("to contain subset"), matcher.Expected) + matcher.mismatches()
+}
+
+func (matcher *SubsetMatcher) NegatedFailureMessage(actual interface{}) string {
+	return format.Message(fmt.Sprintf("%v", actual), matcher.message("not to contain subset"), matcher.Expected) + "\n\nsubset found at " + matcher.found
+}
+
+func (matcher *SubsetMatcher) message(message string) string {
+	if matcher.anywhere {
+		return message + " anywhere"
+	}
+
+	return message
+}
+
+// mismatches describes why the last evaluated document does not contain the
+// expected subset.
+func (matcher *SubsetMatcher) mismatches() string {
+	if !matcher.anywhere {
+		return "\n\nmismatches:\n  " + strings.Join(subsetDiff("", matcher.expected, matcher.value), "\n  ")
+	}
+
+	// the closest candidate is the one lacking the fewest expected keys,
+	// then the one with the fewest differences, the outermost one being
+	// preferred on ties
+	var closest []string
+
+	at := ""
+	missing := -1
+
+	for _, c := range candidates("", matcher.value) {
+		diff := subsetDiff(c.path, matcher.expected, c.value)
+		n := missingKeys(matcher.expected, c.value)
+
+		if missing == -1 || n < missing || (n == missing && len(diff) < len(closest)) {
+			closest = diff
+			at = c.path
+			missing = n
+		}
+	}
+
+	if missing == -1 {
+		return "\n\nthe document holds no mapping nor sequence"
+	}
+
+	return fmt.Sprintf("\n\nclosest match at %s:\n  %s", displayPath(at), strings.Join(closest, "\n  "))
+}
+
+type candidate struct {
+	path  string
+	value any
+}
+
+// candidates returns the given value followed by all the mappings and
+// sequences nested into it, depth first.
+func candidates(path string, value any) []candidate {
+	var result []candidate
+
+	switch v := value.(type) {
+	case map[string]any:
+		result = append(result, candidate{path: path, value: v})
+
+		for _, k := range sortedKeys(v) {
+			result = append(result, candidates(path+keyPath(k), v[k])...)
+		}
+	case []any:
+		result = append(result, candidate{path: path, value: v})
+
+		for i, e := range v {
+			result = append(result, candidates(fmt.Sprintf("%s[%d]", path, i), e)...)
+		}
+	}
+
+	return result
+}
+
+// subsetDiff returns the paths at which actual does not contain expected,
+// along with the reason.
+func subsetDiff(path string, expected any, actual any) []string {
+	switch e := expected.(type) {
+	case map[string]any:
+		a, ok := actual.(map[string]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected a mapping, got %s", displayPath(path), kindOf(actual))}
+		}
+
+		var diff []string
+
+		for _, k := range sortedKeys(e) {
+			v, ok := a[k]
+			if !ok {
+				diff = append(diff, displayPath(path+keyPath(k))+": missing")
+
+				continue
+			}
+
+			diff = append(diff, subsetDiff(path+keyPath(k), e[k], v)...)
+		}
+
+		return diff
+	case []any:
+		a, ok := actual.([]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected a sequence, got %s", displayPath(path), kindOf(actual))}
+		}
+
+		var diff []string
+
+		for _, ee := range e {
+			found := slices.ContainsFunc(a, func(ae any) bool {
+				return len(subsetDiff(path, ee, ae)) == 0
+			})
+
+			if !found {
+				diff = append(diff, fmt.Sprintf("%s: no element containing %s", displayPath(path), toFlow(ee)))
+			}
+		}
+
+		return diff
+	default:
+		switch actual.(type) {
+		case map[string]any, []any:
+			return []string{fmt.Sprintf("%s: expected %s, got %s", displayPath(path), toFlow(expected), kindOf(actual))}
+		}
+
+		if !reflect.DeepEqual(expected, actual) {
+			return []string{fmt.Sprintf("%s: expected %s, got %s", displayPath(path), toFlow(expected), toFlow(actual))}
+		}
+
+		return nil
+	}
+}
+
+// missingKeys returns how many of the keys of the expected mapping are not
+// found in actual.
+func missingKeys(expected any, actual any) int {
+	e, ok := expected.(map[string]any)
+	if !ok {
+		return 0
+	}
+
+	a, ok := actual.(map[string]any)
+	if !ok {
+		return len(e)
+	}
+
+	n := 0
+
+	for k := range e {
+		if _, ok := a[k]; !ok {
+			n++
+		}
+	}
+
+	return n
+}
+
+func kindOf(v any) string {
+	switch v.(type) {
+	case map[string]any:
+		return "a mapping"
+	case []any:
+		return "a sequence"
+	case nil:
+		return "null"
+	default:
+		return "a scalar"
+	}
+}
+
+// toFlow renders the given value in flow style, JSON being valid YAML.
+func toFlow(in any) string {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Sprintf("%v", in)
+	}
+
+	return string(data)
+}
+
+func sortedKeys(in map[string]any) []string {
+	keys := make([]string, 0, len(in))
+	for k := range in {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+// keyPath renders the given key as a yq path segment, i.e. .foo or ["foo.bar"].
+func keyPath(key string) string {
+	if identifier.MatchString(key) {
+		return "." + key
+	}
+
+	return "[" + toFlow(key) + "]"
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "."
+	}
+
+	return path
+}
@@ -0,0 +1,86 @@
+package yq_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/yq"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestContainSubset(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	g.Expect(deployment).Should(yq.ContainSubset("spec: {replicas: 3}"))
+	g.Expect(deployment).Should(yq.ContainSubset(`
+metadata:
+  labels:
+    tier: web
+spec:
+  template:
+    spec:
+      containers:
+        - name: app
+          ports:
+            - containerPort: 8080
+`))
+
+	g.Expect(deployment).ShouldNot(yq.ContainSubset("spec: {replicas: 2}"))
+	g.Expect(deployment).ShouldNot(yq.ContainSubset("spec: {paused: true}"))
+	g.Expect(deployment).ShouldNot(yq.ContainSubset("{name: app, image: registry.example.com/app:1.0}"))
+
+	g.Expect(deployment).Should(yq.ContainSubset("{name: app, image: registry.example.com/app:1.0}").Anywhere())
+	g.Expect(deployment).ShouldNot(yq.ContainSubset("{name: app, image: registry.example.com/app:2.0}").Anywhere())
+
+	g.Expect(deployment).Should(yq.New(yq.WithCache(8)).ContainSubset("kind: Deployment"))
+
+	_, err := yq.ContainSubset("spec: [").Match(deployment)
+	g.Expect(err).Should(MatchError(ContainSubstring("unable to decode expected subset")))
+}
+
+func TestContainSubsetFailureMessage(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	m := yq.ContainSubset(`
+metadata:
+  labels:
+    tier: backend
+    app.kubernetes.io/name: app
+spec:
+  replicas: 2
+  template: x
+`)
+
+	ok, err := m.Match(deployment)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ok).Should(BeFalse())
+	g.Expect(m.FailureMessage(deployment)).Should(And(
+		ContainSubstring("to contain subset"),
+		ContainSubstring(`.metadata.labels["app.kubernetes.io/name"]: missing`),
+		ContainSubstring(`.metadata.labels.tier: expected "backend", got "web"`),
+		ContainSubstring(".spec.replicas: expected 2, got 3"),
+		ContainSubstring(`.spec.template: expected "x", got a mapping`),
+	))
+
+	m = yq.ContainSubset("containers: [{name: web}]").Anywhere()
+
+	ok, err = m.Match(deployment)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ok).Should(BeFalse())
+	g.Expect(m.FailureMessage(deployment)).Should(And(
+		ContainSubstring("to contain subset anywhere"),
+		ContainSubstring("closest match at .spec.template.spec:"),
+		ContainSubstring(`.spec.template.spec.containers: no element containing {"name":"web"}`),
+	))
+
+	m = yq.ContainSubset("containerPort: 8080").Anywhere()
+
+	ok, err = m.Match(deployment)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ok).Should(BeTrue())
+	g.Expect(m.NegatedFailureMessage(deployment)).Should(ContainSubstring("subset found at .spec.template.spec.containers[0].ports[0]"))
+}
@@ -66,18 +66,41 @@ func toString(in any) (string, error) {
 	}
 }
 
-func evaluate(expression string, actual interface{}) (*list.List, error) {
-	data, err := toString(actual)
-	if err != nil {
-		return nil, err
+// yamlPreferences returns a fresh set of preferences so that decoders and
+// encoders never share mutable configuration across goroutines.
+func yamlPreferences() yqlib.YamlPreferences {
+	return yqlib.YamlPreferences{
+		Indent:                      defaultIndent,
+		ColorsEnabled:               false,
+		LeadingContentPreProcessing: true,
+		PrintDocSeparators:          true,
+		UnwrapScalar:                true,
+		EvaluateTogether:            false,
 	}
+}
+
+// evaluator bundles the yqlib components needed to evaluate an expression.
+// yqlib does not guarantee its decoders and evaluators to be safe for
+// concurrent use, hence a new instance is created for each evaluation.
+type evaluator struct {
+	decoder   yqlib.Decoder
+	evaluator yqlib.Evaluator
+}
 
-	documents, err := readDocuments([]byte(data))
+func newEvaluator(prefs yqlib.YamlPreferences) *evaluator {
+	return &evaluator{
+		decoder:   yqlib.NewYamlDecoder(prefs),
+		evaluator: yqlib.NewAllAtOnceEvaluator(),
+	}
+}
+
+func (e *evaluator) evaluate(expression string, data string) (*list.List, error) {
+	documents, err := e.readDocuments([]byte(data))
 	if err != nil {
 		return nil, err
 	}
 
-	results, err := yqlib.NewAllAtOnceEvaluator().EvaluateCandidateNodes(expression, documents)
+	results, err := e.evaluator.EvaluateCandidateNodes(expression, documents)
 	if err != nil {
 		return nil, fmt.Errorf("failure evaluating expression: %w", err)
 	}
@@ -85,20 +108,11 @@ func evaluate(expression string, actual interface{}) (*list.List, error) {
 	return results, nil
 }
 
-func readDocuments(data []byte) (*list.List, error) {
+func (e *evaluator) readDocuments(data []byte) (*list.List, error) {
 	br := bytes.NewReader(data)
 	reader := bufio.NewReader(br)
 
-	decoder := yqlib.NewYamlDecoder(yqlib.YamlPreferences{
-		Indent:                      defaultIndent,
-		ColorsEnabled:               false,
-		LeadingContentPreProcessing: true,
-		PrintDocSeparators:          true,
-		UnwrapScalar:                true,
-		EvaluateTogether:            false,
-	})
-
-	documents, err := yqlib.ReadDocuments(reader, decoder)
+	documents, err := yqlib.ReadDocuments(reader, e.decoder)
 	if err != nil {
 		return nil, fmt.Errorf("failure reading document: %w", err)
 	}
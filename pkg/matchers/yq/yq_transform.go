@@ -2,34 +2,74 @@ package yq
 
 import (
 	"bytes"
+	"container/list"
 	"fmt"
 
 	"github.com/mikefarah/yq/v4/pkg/yqlib"
 )
 
 func Extract(expression string) func(in any) (any, error) {
+	return New().Extract(expression)
+}
+
+func extract(m *Matcher, expression string) func(in any) (any, error) {
 	return func(in any) (any, error) {
-		results, err := evaluate(expression, in)
+		v, err := m.cached("extract", expression, in, func(data string) (any, error) {
+			results, err := m.evaluate(expression, data)
+			if err != nil {
+				return nil, err
+			}
+
+			return m.render(results)
+		})
 		if err != nil {
 			return false, err
 		}
 
-		out := new(bytes.Buffer)
+		return v, nil
+	}
+}
 
-		encoder := yqlib.NewYamlEncoder(yqlib.YamlPreferences{
-			Indent:                      defaultIndent,
-			ColorsEnabled:               false,
-			LeadingContentPreProcessing: true,
-			PrintDocSeparators:          true,
-			UnwrapScalar:                true,
-			EvaluateTogether:            false,
-		})
+// Edit returns a transform applying the given mutating expression, i.e.
+// `.spec.replicas = 5` or `del(.metadata.annotations)`, to its input and
+// returning the modified documents as YAML, whatever the expression
+// evaluates to. It can be used to derive test inputs from base manifests.
+func Edit(expression string) func(in any) (any, error) {
+	return New().Edit(expression)
+}
+
+func edit(m *Matcher, expression string) func(in any) (any, error) {
+	return func(in any) (any, error) {
+		e := newEvaluator(m.preferences())
+
+		data, err := toString(in)
+		if err != nil {
+			return nil, err
+		}
+
+		documents, err := e.readDocuments([]byte(data))
+		if err != nil {
+			return nil, err
+		}
 
-		printer := yqlib.NewPrinter(encoder, yqlib.NewSinglePrinterWriter(out))
-		if err := printer.PrintResults(results); err != nil {
-			return "", fmt.Errorf("failure rendering results: %w", err)
+		// assignments update the nodes of the documents in place
+		if _, err := e.evaluator.EvaluateCandidateNodes(expression, documents); err != nil {
+			return nil, fmt.Errorf("failure evaluating expression: %w", err)
 		}
 
-		return out.String(), nil
+		return m.render(documents)
 	}
 }
+
+func (m *Matcher) render(results *list.List) (string, error) {
+	out := new(bytes.Buffer)
+
+	encoder := yqlib.NewYamlEncoder(m.preferences())
+
+	printer := yqlib.NewPrinter(encoder, yqlib.NewSinglePrinterWriter(out))
+	if err := printer.PrintResults(results); err != nil {
+		return "", fmt.Errorf("failure rendering results: %w", err)
+	}
+
+	return out.String(), nil
+}
@@ -38,3 +38,51 @@ func TestExtract(t *testing.T) {
 		),
 	)
 }
+
+const manifest = `# base manifest
+
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  annotations:
+    foo: bar
+spec:
+  replicas: 1
+`
+
+func TestEdit(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	g.Expect(manifest).Should(
+		WithTransform(yq.Edit(`.spec.replicas = 5`),
+			And(
+				yq.Match(`.spec.replicas == 5`),
+				yq.Match(`.metadata.name == "app"`),
+			),
+		),
+	)
+
+	// the whole document is returned whatever the expression evaluates to
+	g.Expect(manifest).Should(
+		WithTransform(yq.Edit(`.spec.replicas = 5 | .spec`), yq.Match(`.kind == "Deployment"`)),
+	)
+
+	g.Expect(manifest).Should(
+		WithTransform(yq.Edit(`del(.metadata.annotations) | .metadata.labels.app = "app"`),
+			And(
+				yq.Match(`.metadata | has("annotations") | not`),
+				yq.Match(`.metadata.labels.app == "app"`),
+			),
+		),
+	)
+
+	edited, err := yq.Edit(`.spec.replicas = 3`)("a: 1\n---\nspec:\n  replicas: 1\n")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(edited).Should(Equal("a: 1\nspec:\n  replicas: 3\n---\nspec:\n  replicas: 3\n"))
+
+	_, err = yq.Edit(`.spec.replicas =`)(manifest)
+	g.Expect(err).Should(HaveOccurred())
+}
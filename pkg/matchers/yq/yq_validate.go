@@ -0,0 +1,29 @@
+package yq
+
+import (
+	"fmt"
+
+	"github.com/mikefarah/yq/v4/pkg/yqlib"
+)
+
+// Validate parses the given expression, returning an error describing the
+// problem (including its position, as reported by the yq lexer) if it is not
+// valid.
+func Validate(expression string) error {
+	if _, err := yqlib.ExpressionParser.ParseExpression(expression); err != nil {
+		return fmt.Errorf("invalid expression %s: %w", expression, err)
+	}
+
+	return nil
+}
+
+// MustValidate is like Validate but panics if the expression is not valid.
+// It returns the expression so that it can be used to initialize variables
+// holding shared expressions, making malformed ones fail fast.
+func MustValidate(expression string) string {
+	if err := Validate(expression); err != nil {
+		panic(err)
+	}
+
+	return expression
+}
@@ -0,0 +1,22 @@
+package yq_test
+
+import (
+	"testing"
+
+	"github.com/lburgazzoli/gomega-matchers/pkg/matchers/yq"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithT(t)
+
+	g.Expect(yq.Validate(`.status.phase == "Running"`)).Should(Succeed())
+	g.Expect(yq.Validate(`.foo | select(`)).Should(MatchError(ContainSubstring("invalid expression .foo | select(")))
+	g.Expect(yq.Validate(`.foo ^ 1`)).Should(MatchError(ContainSubstring("1:6")))
+
+	g.Expect(yq.MustValidate(`.a == 1`)).Should(Equal(`.a == 1`))
+	g.Expect(func() { yq.MustValidate(`.a | select(`) }).Should(Panic())
+}